@@ -0,0 +1,255 @@
+// Reference https://github.com/getamis/alice/blob/master/crypto/zkproof/paillier/affgproof.go
+
+package affgproof
+
+import (
+	"math/big"
+
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/alice/utils"
+
+	errors2 "github.com/pkg/errors"
+)
+
+var (
+	big0 = big.NewInt(0)
+	big1 = big.NewInt(1)
+	big2 = big.NewInt(2)
+
+	ErrVerifyFailure = "the affgproof verification is failure"
+)
+
+// Paillier affine operation with group commitment in range (Πaff-g).
+// Common input is (G, q, N0, N1, C, D, Y, X, g).
+// The Prover has secret input (x, y, ρ, ρy) such that x ∈ ±2^l, y ∈ ±2^l',
+// D = C^x · (1+N0)^y · ρ^N0 mod N0^2, Y = (1+N1)^y · ρy^N1 mod N1^2 and X = g^x ∈ G
+func NewPaillierAffineGroupMessage(
+	config *crypto.ProofConfig,
+	ssidInfo []byte,
+	x, y, rho, rhoy, N0, N1, C, D, Y *big.Int,
+	ped *pailliera.PedPubKey,
+	X *crypto.ECPoint,
+) (*PaillierAffineGroupMessage, error) {
+	n0Square := new(big.Int).Mul(N0, N0)
+	n1Square := new(big.Int).Mul(N1, N1)
+	pedN := ped.N
+	peds := ped.S
+	pedt := ped.T
+	// Sample α in ± 2^{l+ε}, β in ± 2^{l'+ε}.
+	alpha, err := utils.RandomAbsoluteRangeInt(config.TwoExpLAddepsilon)
+	if err != nil {
+		return nil, err
+	}
+	beta, err := utils.RandomAbsoluteRangeInt(config.TwoExpLpaiAddepsilon)
+	if err != nil {
+		return nil, err
+	}
+	// Sample r in Z_{N0}^ast, ry in Z_{N1}^ast.
+	r, err := utils.RandomCoprimeInt(N0)
+	if err != nil {
+		return nil, err
+	}
+	ry, err := utils.RandomCoprimeInt(N1)
+	if err != nil {
+		return nil, err
+	}
+	// Sample γ, δ in ± 2^{l+ε}·Nˆ and m, μ in ± 2^l·Nˆ.
+	gamma, err := utils.RandomAbsoluteRangeInt(new(big.Int).Mul(config.TwoExpLAddepsilon, pedN))
+	if err != nil {
+		return nil, err
+	}
+	delta, err := utils.RandomAbsoluteRangeInt(new(big.Int).Mul(config.TwoExpLAddepsilon, pedN))
+	if err != nil {
+		return nil, err
+	}
+	m, err := utils.RandomAbsoluteRangeInt(new(big.Int).Mul(config.TwoExpL, pedN))
+	if err != nil {
+		return nil, err
+	}
+	mu, err := utils.RandomAbsoluteRangeInt(new(big.Int).Mul(config.TwoExpL, pedN))
+	if err != nil {
+		return nil, err
+	}
+
+	// A = C^α·(1+N0)^β·r^N0 mod N0^2
+	A := new(big.Int).Mul(new(big.Int).Exp(C, alpha, n0Square), new(big.Int).Exp(new(big.Int).Add(big1, N0), beta, n0Square))
+	A.Mod(A, n0Square)
+	A.Mul(A, new(big.Int).Exp(r, N0, n0Square))
+	A.Mod(A, n0Square)
+	// Bx = α*g
+	Bx := crypto.ScalarBaseMult(X.Curve(), alpha)
+	// By = (1+N1)^β·ry^N1 mod N1^2
+	By := new(big.Int).Mul(new(big.Int).Exp(new(big.Int).Add(big1, N1), beta, n1Square), new(big.Int).Exp(ry, N1, n1Square))
+	By.Mod(By, n1Square)
+	// E = s^α·t^γ, S = s^x·t^m, F = s^β·t^δ, T = s^y·t^μ mod Nˆ
+	E := pedersen(peds, pedt, alpha, gamma, pedN)
+	S := pedersen(peds, pedt, x, m, pedN)
+	F := pedersen(peds, pedt, beta, delta, pedN)
+	T := pedersen(peds, pedt, y, mu, pedN)
+
+	msgs := utils.GetAnyMsg(ssidInfo, new(big.Int).SetUint64(config.LAddEpsilon).Bytes(), new(big.Int).SetUint64(config.LpaiAddEpsilon).Bytes(),
+		N0.Bytes(), N1.Bytes(), pedN.Bytes(), peds.Bytes(), pedt.Bytes(), C.Bytes(), D.Bytes(), Y.Bytes(), X.X().Bytes(), X.Y().Bytes(),
+		A.Bytes(), Bx.X().Bytes(), Bx.Y().Bytes(), By.Bytes(), E.Bytes(), S.Bytes(), F.Bytes(), T.Bytes())
+	e, salt, err := pailliera.GetE(config.CurveN, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// z1 = α+ex, z2 = β+ey, z3 = γ+em, z4 = δ+eμ
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	z4 := new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	// w = r·ρ^e mod N0, wy = ry·ρy^e mod N1
+	w := new(big.Int).Mul(r, new(big.Int).Exp(rho, e, N0))
+	w.Mod(w, N0)
+	wy := new(big.Int).Mul(ry, new(big.Int).Exp(rhoy, e, N1))
+	wy.Mod(wy, N1)
+
+	return &PaillierAffineGroupMessage{
+		Salt: salt,
+		A:    A.Bytes(),
+		BxX:  Bx.X().Bytes(),
+		BxY:  Bx.Y().Bytes(),
+		By:   By.Bytes(),
+		E:    E.Bytes(),
+		S:    S.Bytes(),
+		F:    F.Bytes(),
+		T:    T.Bytes(),
+		Z1:   z1.String(),
+		Z2:   z2.String(),
+		Z3:   z3.String(),
+		Z4:   z4.String(),
+		W:    w.Bytes(),
+		Wy:   wy.Bytes(),
+	}, nil
+}
+
+func (msg *PaillierAffineGroupMessage) Verify(
+	config *crypto.ProofConfig,
+	ssidInfo []byte,
+	N0, N1, C, D, Y *big.Int,
+	ped *pailliera.PedPubKey,
+	X *crypto.ECPoint,
+) error {
+	n0Square := new(big.Int).Mul(N0, N0)
+	n1Square := new(big.Int).Mul(N1, N1)
+	pedN := ped.N
+	peds := ped.S
+	pedt := ped.T
+
+	// check A in Z_{N0^2}^\ast, By in Z_{N1^2}^\ast, E,S,F,T in Z_{Nˆ}^\ast, w in Z_{N0}^\ast and wy in Z_{N1}^\ast
+	A := new(big.Int).SetBytes(msg.A)
+	if err := inGroup(A, n0Square, N0); err != nil {
+		return errors2.Errorf("%s: A, %s", ErrVerifyFailure, err)
+	}
+	By := new(big.Int).SetBytes(msg.By)
+	if err := inGroup(By, n1Square, N1); err != nil {
+		return errors2.Errorf("%s: By, %s", ErrVerifyFailure, err)
+	}
+	E := new(big.Int).SetBytes(msg.E)
+	S := new(big.Int).SetBytes(msg.S)
+	F := new(big.Int).SetBytes(msg.F)
+	T := new(big.Int).SetBytes(msg.T)
+	for _, v := range []*big.Int{E, S, F, T} {
+		if err := inGroup(v, pedN, pedN); err != nil {
+			return errors2.Errorf("%s: E, S, F or T, %s", ErrVerifyFailure, err)
+		}
+	}
+	w := new(big.Int).SetBytes(msg.W)
+	if err := inGroup(w, N0, N0); err != nil {
+		return errors2.Errorf("%s: w, %s", ErrVerifyFailure, err)
+	}
+	wy := new(big.Int).SetBytes(msg.Wy)
+	if err := inGroup(wy, N1, N1); err != nil {
+		return errors2.Errorf("%s: wy, %s", ErrVerifyFailure, err)
+	}
+	z1, ok1 := new(big.Int).SetString(msg.Z1, 10)
+	z2, ok2 := new(big.Int).SetString(msg.Z2, 10)
+	z3, ok3 := new(big.Int).SetString(msg.Z3, 10)
+	z4, ok4 := new(big.Int).SetString(msg.Z4, 10)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return errors2.Errorf("%s: parse z", ErrVerifyFailure)
+	}
+	Bx, err := crypto.NewECPoint(X.Curve(), new(big.Int).SetBytes(msg.GetBxX()), new(big.Int).SetBytes(msg.GetBxY()))
+	if err != nil {
+		return err
+	}
+
+	msgs := utils.GetAnyMsg(ssidInfo, new(big.Int).SetUint64(config.LAddEpsilon).Bytes(), new(big.Int).SetUint64(config.LpaiAddEpsilon).Bytes(),
+		N0.Bytes(), N1.Bytes(), pedN.Bytes(), peds.Bytes(), pedt.Bytes(), C.Bytes(), D.Bytes(), Y.Bytes(), X.X().Bytes(), X.Y().Bytes(),
+		A.Bytes(), Bx.X().Bytes(), Bx.Y().Bytes(), By.Bytes(), E.Bytes(), S.Bytes(), F.Bytes(), T.Bytes())
+	seed, err := utils.HashProtos(msg.Salt, msgs...)
+	if err != nil {
+		return err
+	}
+	e := utils.RandomAbsoluteRangeIntBySeed(msg.Salt, seed, config.CurveN)
+	err = utils.InRange(e, new(big.Int).Neg(config.CurveN), new(big.Int).Add(big1, config.CurveN))
+	if err != nil {
+		return err
+	}
+
+	// Check z1 ∈ ±2^{l+ε} and z2 ∈ ±2^{l'+ε}.
+	if new(big.Int).Abs(z1).Cmp(new(big.Int).Lsh(big2, uint(config.LAddEpsilon))) > 0 {
+		return errors2.Errorf("%s: z1 out of range", ErrVerifyFailure)
+	}
+	if new(big.Int).Abs(z2).Cmp(new(big.Int).Lsh(big2, uint(config.LpaiAddEpsilon))) > 0 {
+		return errors2.Errorf("%s: z2 out of range", ErrVerifyFailure)
+	}
+	// Check C^{z1}·(1+N0)^{z2}·w^{N0} = A·D^e mod N0^2.
+	left := new(big.Int).Mul(new(big.Int).Exp(C, z1, n0Square), new(big.Int).Exp(new(big.Int).Add(big1, N0), z2, n0Square))
+	left.Mod(left, n0Square)
+	left.Mul(left, new(big.Int).Exp(w, N0, n0Square))
+	left.Mod(left, n0Square)
+	right := new(big.Int).Mul(A, new(big.Int).Exp(D, e, n0Square))
+	right.Mod(right, n0Square)
+	if left.Cmp(right) != 0 {
+		return errors2.Errorf("%s: C^z1·(1+N0)^z2·w^N0 != A·D^e", ErrVerifyFailure)
+	}
+	// Check z1*g = Bx + e*X.
+	BxXe, err := X.ScalarMult(e).Add(Bx)
+	if err != nil {
+		return err
+	}
+	if !crypto.ScalarBaseMult(X.Curve(), z1).Equals(BxXe) {
+		return errors2.Errorf("%s: z1*g != Bx + e*X", ErrVerifyFailure)
+	}
+	// Check (1+N1)^{z2}·wy^{N1} = By·Y^e mod N1^2.
+	left = new(big.Int).Mul(new(big.Int).Exp(new(big.Int).Add(big1, N1), z2, n1Square), new(big.Int).Exp(wy, N1, n1Square))
+	left.Mod(left, n1Square)
+	right = new(big.Int).Mul(By, new(big.Int).Exp(Y, e, n1Square))
+	right.Mod(right, n1Square)
+	if left.Cmp(right) != 0 {
+		return errors2.Errorf("%s: (1+N1)^z2·wy^N1 != By·Y^e", ErrVerifyFailure)
+	}
+	// Check s^{z1}·t^{z3} = E·S^e and s^{z2}·t^{z4} = F·T^e mod Nˆ.
+	right = new(big.Int).Mul(E, new(big.Int).Exp(S, e, pedN))
+	right.Mod(right, pedN)
+	if pedersen(peds, pedt, z1, z3, pedN).Cmp(right) != 0 {
+		return errors2.Errorf("%s: s^z1·t^z3 != E·S^e", ErrVerifyFailure)
+	}
+	right = new(big.Int).Mul(F, new(big.Int).Exp(T, e, pedN))
+	right.Mod(right, pedN)
+	if pedersen(peds, pedt, z2, z4, pedN).Cmp(right) != 0 {
+		return errors2.Errorf("%s: s^z2·t^z4 != F·T^e", ErrVerifyFailure)
+	}
+	return nil
+}
+
+// s^a·t^b mod n
+func pedersen(s, t, a, b, n *big.Int) *big.Int {
+	v := new(big.Int).Mul(new(big.Int).Exp(s, a, n), new(big.Int).Exp(t, b, n))
+	return v.Mod(v, n)
+}
+
+// checks v in [0, n) and gcd(v, m) = 1
+func inGroup(v, n, m *big.Int) error {
+	if err := utils.InRange(v, big0, n); err != nil {
+		return err
+	}
+	if !utils.IsRelativePrime(v, m) {
+		return errors2.New("not relatively prime")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package ckd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// Child key derivation schemes of a threshold key
+const (
+	// SchemeBip32 is the default: BIP 32 HMAC tweaks over the compressed group key, with a chain code per
+	// keygen party or a single group chain code
+	SchemeBip32 = ""
+
+	// SchemeBip32Ed25519 is BIP32-Ed25519 (Khovratovich–Law) soft derivation, as Cardano wallets use it.
+	// The key needs the Ed25519 curve and a single chain code: the group key and that code are the
+	// extended public key of the wallet, usually the account key at 1852'/1815'/account'.
+	SchemeBip32Ed25519 = "bip32-ed25519"
+)
+
+// CheckScheme reports whether a key with the given curve and chainCodes can derive children with scheme
+func CheckScheme(scheme string, pub *crypto.ECPoint, chainCodes []*big.Int) error {
+	switch scheme {
+	case SchemeBip32:
+		return nil
+	case SchemeBip32Ed25519:
+		if !isEdwards(pub) {
+			return errors.New("bip32-ed25519 derivation needs an Ed25519 key")
+		}
+		if len(chainCodes) != 1 {
+			return fmt.Errorf("bip32-ed25519 derivation needs a single chain code, the key has %d", len(chainCodes))
+		}
+		if chainCodes[0].BitLen() > 256 {
+			return errors.New("bip32-ed25519 derivation: chain code longer than 32 bytes")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown derivation scheme %q", scheme)
+	}
+}
+
+func isEdwards(pub *crypto.ECPoint) bool {
+	return pub.Curve().Params().N.Cmp(edwards.Edwards().N) == 0
+}
+
+// DeriveBip32Ed25519ChildTweak returns the sum of the 8*ZL values along the non-hardened path from the extended
+// public key (pub, chainCode), so the child public key is pub + tweak*G and a share of the parent key plus the
+// tweak is a share of the child. pub + tweak*G is the public key a BIP32-Ed25519 wallet derives at the path.
+func DeriveBip32Ed25519ChildTweak(
+	pub *crypto.ECPoint,
+	chainCode []byte,
+	path string,
+) (*big.Int, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(chainCode) != 32 {
+		return nil, fmt.Errorf("bip32-ed25519 chain code of length %d, expected 32", len(chainCode))
+	}
+	modN := common.ModInt(edwards.Edwards().N)
+	tweak := big.NewInt(0)
+	for _, idx := range indexes {
+		var t *big.Int
+		t, pub, chainCode, err = deriveBip32Ed25519ChildPubKey(idx, pub, chainCode)
+		if err != nil {
+			return nil, fmt.Errorf("derive child tweak err: %s", err.Error())
+		}
+		tweak = modN.Add(tweak, t)
+	}
+	return tweak, nil
+}
+
+// deriveBip32Ed25519ChildPubKey is the soft derivation step of BIP32-Ed25519:
+// Z = HMAC-SHA512(c, 0x02 || A || i), c_i = HMAC-SHA512(c, 0x03 || A || i)[32:], A_i = A + 8*ZL*G,
+// with A the 32-byte Ed25519 encoding, i little-endian and ZL the first 28 bytes of Z, little-endian
+func deriveBip32Ed25519ChildPubKey(
+	index uint32,
+	pub *crypto.ECPoint,
+	chainCode []byte,
+) (tweak *big.Int, childPub *crypto.ECPoint, childChainCode []byte, err error) {
+	if index >= HardenedKeyStart {
+		return nil, nil, nil, errors.New("the index must be non-hardened")
+	}
+	pubKeyBytes := edwards.NewPublicKey(pub.X(), pub.Y()).Serialize()
+	var indexBytes [4]byte
+	binary.LittleEndian.PutUint32(indexBytes[:], index)
+
+	hmac512 := hmac.New(sha512.New, chainCode)
+	hmac512.Write([]byte{0x02})
+	hmac512.Write(pubKeyBytes)
+	hmac512.Write(indexBytes[:])
+	z := hmac512.Sum(nil)
+
+	hmac512 = hmac.New(sha512.New, chainCode)
+	hmac512.Write([]byte{0x03})
+	hmac512.Write(pubKeyBytes)
+	hmac512.Write(indexBytes[:])
+	childChainCode = hmac512.Sum(nil)[32:]
+
+	// 8*ZL < 2^227 is below the group order, no reduction
+	zl := make([]byte, 28)
+	for i := range zl {
+		zl[i] = z[27-i]
+	}
+	tweak = new(big.Int).Lsh(new(big.Int).SetBytes(zl), 3)
+	if tweak.Sign() == 0 {
+		common.Logger.Error("error deriving child key")
+		return nil, nil, nil, errors.New("invalid derived key")
+	}
+	childPub, err = pub.Add(crypto.ScalarBaseMult(edwards.Edwards(), tweak))
+	if err != nil {
+		common.Logger.Error("error adding delta G to parent key")
+		return nil, nil, nil, err
+	}
+	return tweak, childPub, childChainCode, nil
+}
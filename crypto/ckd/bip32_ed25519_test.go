@@ -0,0 +1,101 @@
+package ckd_test
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	. "tss_sdk/crypto/ckd"
+	"tss_sdk/tss"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// bip32Ed25519ChildPrivKey is the soft private derivation of BIP32-Ed25519 on kL alone, kL_i = kL + 8*ZL
+func bip32Ed25519ChildPrivKey(kL *big.Int, chainCode []byte, index uint32) (*big.Int, []byte) {
+	pub := crypto.ScalarBaseMult(edwards.Edwards(), kL)
+	data := edwards.NewPublicKey(pub.X(), pub.Y()).Serialize()
+	data = binary.LittleEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(append([]byte{0x02}, data...))
+	z := mac.Sum(nil)
+	mac = hmac.New(sha512.New, chainCode)
+	mac.Write(append([]byte{0x03}, data...))
+	childChainCode := mac.Sum(nil)[32:]
+
+	zl := new(big.Int)
+	for i := 27; i >= 0; i-- {
+		zl.Lsh(zl, 8).Or(zl, big.NewInt(int64(z[i])))
+	}
+	childKL := new(big.Int).Add(kL, zl.Lsh(zl, 3))
+	return childKL.Mod(childKL, edwards.Edwards().N), childChainCode
+}
+
+func TestBip32Ed25519ChildTweak(t *testing.T) {
+	chainCode, _ := hex.DecodeString("00015bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f243")
+	kL := common.GetRandomPositiveInt(rand.Reader, edwards.Edwards().N)
+	pub := crypto.ScalarBaseMult(edwards.Edwards(), kL)
+
+	childKey, childChainCode := kL, chainCode
+	for _, idx := range []uint32{0, 5} {
+		childKey, childChainCode = bip32Ed25519ChildPrivKey(childKey, childChainCode, idx)
+	}
+	want := crypto.ScalarBaseMult(edwards.Edwards(), childKey)
+
+	tweak, err := DeriveBip32Ed25519ChildTweak(pub, chainCode, "m/0/5")
+	assert.NoError(t, err)
+	got, _ := pub.Add(crypto.ScalarBaseMult(edwards.Edwards(), tweak))
+	assert.True(t, want.Equals(got))
+
+	// the scheme differs from the default one
+	other, err := DeriveEddsaChildTweak(pub, chainCode, "m/0/5")
+	assert.NoError(t, err)
+	assert.NotEqual(t, other, tweak)
+
+	tweak, err = DeriveBip32Ed25519ChildTweak(pub, chainCode, "")
+	assert.NoError(t, err)
+	assert.Zero(t, tweak.Sign())
+
+	_, err = DeriveBip32Ed25519ChildTweak(pub, chainCode[1:], "0")
+	assert.Error(t, err)
+	_, err = DeriveBip32Ed25519ChildTweak(pub, chainCode, "1852'/1815'/0'")
+	assert.Error(t, err)
+}
+
+func TestBip32Ed25519GroupKey(t *testing.T) {
+	chainCode, _ := hex.DecodeString("00015bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f243")
+	chainCodes := []*big.Int{new(big.Int).SetBytes(chainCode)}
+	x1 := common.GetRandomPositiveInt(rand.Reader, edwards.Edwards().N)
+	x2 := common.GetRandomPositiveInt(rand.Reader, edwards.Edwards().N)
+	X1, X2 := crypto.ScalarBaseMult(edwards.Edwards(), x1), crypto.ScalarBaseMult(edwards.Edwards(), x2)
+	pub, _ := X1.Add(X2)
+
+	k, err := NewGroupExtendedKey(pub, []*crypto.ECPoint{X1, X2}, chainCodes, SchemeBip32Ed25519)
+	assert.NoError(t, err)
+	parsed, err := NewGroupExtendedKeyFromString(k.String())
+	assert.NoError(t, err)
+	assert.Equal(t, SchemeBip32Ed25519, parsed.Scheme())
+
+	// the child of the group key is the child of the whole key x1 + x2
+	got, err := parsed.DeriveChildPubKey("2")
+	assert.NoError(t, err)
+	want, _ := bip32Ed25519ChildPrivKey(common.ModInt(edwards.Edwards().N).Add(x1, x2), chainCode, 2)
+	assert.True(t, crypto.ScalarBaseMult(edwards.Edwards(), want).Equals(got))
+
+	// BIP32-Ed25519 needs an Ed25519 key with one chain code
+	_, err = NewGroupExtendedKey(pub, []*crypto.ECPoint{X1, X2}, append(chainCodes, chainCodes[0]), SchemeBip32Ed25519)
+	assert.Error(t, err)
+	s256 := crypto.ScalarBaseMult(tss.S256(), x1)
+	_, err = NewGroupExtendedKey(s256, []*crypto.ECPoint{s256}, chainCodes, SchemeBip32Ed25519)
+	assert.Error(t, err)
+	_, err = NewGroupExtendedKey(pub, []*crypto.ECPoint{X1, X2}, chainCodes, "slip10")
+	assert.Error(t, err)
+}
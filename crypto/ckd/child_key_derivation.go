@@ -14,8 +14,6 @@ import (
 	"fmt"
 	"hash"
 	"math/big"
-	"strconv"
-	"strings"
 	"tss_sdk/tss"
 
 	"tss_sdk/common"
@@ -174,6 +172,11 @@ func paddedBytes(size int, src []byte) []byte {
 	return tmp
 }
 
+// ChainCodeBytes returns the 32-byte chain code kept as an integer, with the leading zeros the integer drops
+func ChainCodeBytes(code *big.Int) []byte {
+	return paddedBytes(32, code.Bytes())
+}
+
 // SerializeCompressed serializes a public key 33-byte compressed format
 func serializeCompressed(publicKeyX *big.Int, publicKeyY *big.Int) []byte {
 	b := make([]byte, 0, PubKeyBytesLenCompressed)
@@ -216,13 +219,11 @@ func DeriveChildKey(index uint32, harden bool, pk *ExtendedKey, curve elliptic.C
 	cryptoPk, err := crypto.NewECPoint(curve, pk.PublicKey.X, pk.PublicKey.Y)
 	if err != nil {
 		common.Logger.Error("error getting pubkey from extendedkey")
-		fmt.Errorf("error getting pubkey from extendedkey")
 		return nil, nil, err
 	}
 	deducePk, err := crypto.NewECPoint(curve, pk.DeducePubKey.X, pk.DeducePubKey.Y)
 	if err != nil {
 		common.Logger.Error("error getting deduce pubkey from extendedkey")
-		fmt.Errorf("error getting deduce pubkey from extendedkey")
 		return nil, nil, err
 	}
 
@@ -398,27 +399,14 @@ func NewExtendKey(privKey []byte, pubKeyPoint, deducePubkeyPoint *crypto.ECPoint
 
 // DerivePrivateKeyForPath derives the private key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
-func DerivePrivateKeyForPath(pk *ExtendedKey, path string) ([32]byte, []byte, error) {
+func DerivePrivateKeyForPath(pk *ExtendedKey, path Path) ([32]byte, []byte, error) {
 	extPk := pk
-	parts := strings.Split(path, "/")
-	if pk.Depth > 0 {
-		parts = parts[pk.Depth:]
-	}
-	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		if harden {
-			part = part[:len(part)-1]
-		}
-		// harden == private derivation, else public derivation:
-		idx, err := strconv.Atoi(part)
-		if err != nil {
-			return [32]byte{}, nil, fmt.Errorf("invalid BIP 32 path: %s", err)
-		}
-		if idx < 0 {
-			return [32]byte{}, nil, errors.New("invalid BIP 32 path: index negative ot too large")
-		}
-		_, extPk, err = DeriveChildKey(uint32(idx), harden, extPk, tss.S256())
+	if int(pk.Depth) > len(path) {
+		return [32]byte{}, nil, errors.New("invalid BIP 32 path: shorter than the key depth")
+	}
+	for _, idx := range path[pk.Depth:] {
+		var err error
+		_, extPk, err = DeriveChildKey(idx, false, extPk, tss.S256())
 		if err != nil {
 			return [32]byte{}, nil, fmt.Errorf("DeriveChildKey error: %s", err)
 		}
@@ -439,27 +427,14 @@ func DerivePrivateKeyForPath(pk *ExtendedKey, path string) ([32]byte, []byte, er
 
 // DerivePublicKeyForPath derives the public key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
-func DerivePublicKeyForPath(pk *ExtendedKey, path string) (*crypto.ECPoint, error) {
+func DerivePublicKeyForPath(pk *ExtendedKey, path Path) (*crypto.ECPoint, error) {
 	extPk := pk
-	parts := strings.Split(path, "/")
-	if pk.Depth > 0 {
-		parts = parts[pk.Depth:]
-	}
-	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		if harden {
-			return nil, fmt.Errorf("harden not suppored")
-		}
-		// harden == private derivation, else public derivation:
-		idx, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
-		}
-		if idx < 0 {
-			return nil, errors.New("invalid BIP 32 path: index negative ot too large")
-		}
-		_, extPk, err = DeriveChildPubKey(uint32(idx), extPk, tss.S256())
+	if int(pk.Depth) > len(path) {
+		return nil, errors.New("invalid BIP 32 path: shorter than the key depth")
+	}
+	for _, idx := range path[pk.Depth:] {
+		var err error
+		_, extPk, err = DeriveChildPubKey(idx, extPk, tss.S256())
 		if err != nil {
 			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
 		}
@@ -470,3 +445,23 @@ func DerivePublicKeyForPath(pk *ExtendedKey, path string) (*crypto.ECPoint, erro
 	}
 	return pubkeyPoint, nil
 }
+
+// DeriveEcdsaChildTweak returns the sum of the IL values along the non-hardened BIP 32 path over secp256k1,
+// so the child public key is pubkey + tweak*G and a share of the parent key plus the tweak is a share of the child
+func DeriveEcdsaChildTweak(
+	pubkey *crypto.ECPoint,
+	codeByte []byte,
+	path string,
+) (*big.Int, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	extendedKey := NewExtendKey(make([]byte, 32), pubkey, pubkey, 0, 0, codeByte)
+
+	tweak, _, err := DerivePrivateKeyForPath(extendedKey, indexes)
+	if err != nil {
+		return nil, fmt.Errorf("derive child tweak err: %s", err.Error())
+	}
+	return new(big.Int).SetBytes(tweak[:]), nil
+}
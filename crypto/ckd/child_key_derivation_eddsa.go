@@ -9,8 +9,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha512"
 
-	"strconv"
-	"strings"
 	"tss_sdk/common"
 	"tss_sdk/crypto"
 
@@ -24,12 +22,16 @@ func DeriveEddsaChildPrivKey(
 	codeByte []byte,
 	path string,
 ) (childPrivKey [32]byte, childPubKey []byte, err error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return childPrivKey, nil, err
+	}
 	var buf [32]byte
 	privkeyBytes := privkey.FillBytes(buf[:])
 
 	extendedKey := NewExtendKeyD(privkeyBytes, pubkey, deducePubkey, 0, 0, codeByte)
 
-	childPrivKey, childPubKey, err = DerivePrivateKeyForPathD(extendedKey, path, edwards.Edwards())
+	childPrivKey, childPubKey, err = DerivePrivateKeyForPathD(extendedKey, indexes, edwards.Edwards())
 	if err != nil {
 		return childPrivKey, nil, fmt.Errorf("derive child private err: %s", err.Error())
 	}
@@ -41,15 +43,40 @@ func DeriveEddsaChildPubKey(
 	codeByte []byte,
 	path string,
 ) (childPubKeyPoint *crypto.ECPoint, err error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
 	extendedKey := NewExtendKeyD(nil, srcEcPoint, deduceEcPoint, 0, 0, codeByte)
 
-	childPubKeyPoint, err1 := DerivePublicKeyForPathD(extendedKey, path, edwards.Edwards())
-	if err1 != nil {
-		return nil, fmt.Errorf("derive child private err: %s", err.Error())
+	childPubKeyPoint, err = DerivePublicKeyForPathD(extendedKey, indexes, edwards.Edwards())
+	if err != nil {
+		return nil, fmt.Errorf("derive child public err: %s", err.Error())
 	}
 	return
 }
 
+// DeriveEddsaChildTweak returns the sum of the IL values along the non-hardened path, that is the scalar
+// DeriveEddsaChildPrivKey adds to a share: child = parent + tweak*G. The HMAC input is the Edwards point
+// serialized as a secp256k1 one, so no other Ed25519 wallet derives the same keys; see DeriveBip32Ed25519ChildTweak.
+func DeriveEddsaChildTweak(
+	deduceEcPoint *crypto.ECPoint,
+	codeByte []byte,
+	path string,
+) (*big.Int, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	extendedKey := NewExtendKeyD(make([]byte, 32), deduceEcPoint, deduceEcPoint, 0, 0, codeByte)
+
+	tweak, _, err := DerivePrivateKeyForPathD(extendedKey, indexes, edwards.Edwards())
+	if err != nil {
+		return nil, fmt.Errorf("derive child tweak err: %s", err.Error())
+	}
+	return new(big.Int).SetBytes(tweak[:]), nil
+}
+
 type ExtendedKeyD struct {
 	PublicKey    *crypto.ECPoint
 	DeducePubKey *crypto.ECPoint
@@ -86,27 +113,14 @@ func NewExtendKeyD(
 
 // DerivePrivateKeyForPath derives the private key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
-func DerivePrivateKeyForPathD(pk *ExtendedKeyD, path string, curve elliptic.Curve) ([32]byte, []byte, error) {
+func DerivePrivateKeyForPathD(pk *ExtendedKeyD, path Path, curve elliptic.Curve) ([32]byte, []byte, error) {
 	extPk := pk
-	parts := strings.Split(path, "/")
-	if pk.Depth > 0 {
-		parts = parts[pk.Depth:]
+	if int(pk.Depth) > len(path) {
+		return [32]byte{}, nil, errors.New("invalid BIP 32 path: shorter than the key depth")
 	}
-	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		if harden {
-			part = part[:len(part)-1]
-		}
-		// harden == private derivation, else public derivation:
-		idx, err := strconv.Atoi(part)
-		if err != nil {
-			return [32]byte{}, nil, fmt.Errorf("invalid BIP 32 path: %s", err)
-		}
-		if idx < 0 {
-			return [32]byte{}, nil, errors.New("invalid BIP 32 path: index negative ot too large")
-		}
-		_, extPk, err = DeriveChildKeyD(uint32(idx), harden, extPk, curve)
+	for _, idx := range path[pk.Depth:] {
+		var err error
+		_, extPk, err = DeriveChildKeyD(idx, false, extPk, curve)
 		if err != nil {
 			return [32]byte{}, nil, fmt.Errorf("DeriveChildKey error: %s", err)
 		}
@@ -196,27 +210,14 @@ func DeriveChildKeyD(index uint32, harden bool, pk *ExtendedKeyD, curve elliptic
 
 // DerivePublicKeyForPath derives the public key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
-func DerivePublicKeyForPathD(pk *ExtendedKeyD, path string, curve elliptic.Curve) (*crypto.ECPoint, error) {
+func DerivePublicKeyForPathD(pk *ExtendedKeyD, path Path, curve elliptic.Curve) (*crypto.ECPoint, error) {
 	extPk := pk
-	parts := strings.Split(path, "/")
-	if pk.Depth > 0 {
-		parts = parts[pk.Depth:]
+	if int(pk.Depth) > len(path) {
+		return nil, errors.New("invalid BIP 32 path: shorter than the key depth")
 	}
-	for _, part := range parts {
-		// do we have an apostrophe?
-		harden := part[len(part)-1:] == "'"
-		if harden {
-			return nil, fmt.Errorf("harden not suppored")
-		}
-		// harden == private derivation, else public derivation:
-		idx, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
-		}
-		if idx < 0 {
-			return nil, errors.New("invalid BIP 32 path: index negative ot too large")
-		}
-		_, extPk, err = DeriveChildPubKeyD(uint32(idx), extPk, curve)
+	for _, idx := range path[pk.Depth:] {
+		var err error
+		_, extPk, err = DeriveChildPubKeyD(idx, extPk, curve)
 		if err != nil {
 			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
 		}
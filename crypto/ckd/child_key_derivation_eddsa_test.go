@@ -132,3 +132,25 @@ func TestTss(t *testing.T) {
 	assert.True(t, ok)
 	assert.NoError(t, err)
 }
+
+func TestDerivationTweak(t *testing.T) {
+	priKeyBytes, _ := hex.DecodeString("ae1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f241")
+	chainCode, _ := hex.DecodeString("be1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f242")
+	priKey := new(big.Int).SetBytes(priKeyBytes)
+	pubKey := crypto.ScalarBaseMult(edwards.Edwards(), priKey)
+	deduceKey, _ := pubKey.Add(pubKey)
+
+	childPrivKey, _, err := DeriveEddsaChildPrivKey(priKey, pubKey, deduceKey, chainCode, "81/0/0/35/0")
+	assert.NoError(t, err)
+
+	tweak, err := DeriveEddsaChildTweak(deduceKey, chainCode, "81/0/0/35/0")
+	assert.NoError(t, err)
+
+	modN := common.ModInt(edwards.Edwards().N)
+	assert.Zero(t, modN.Add(priKey, tweak).Cmp(new(big.Int).SetBytes(childPrivKey[:])))
+
+	childPubKeyPt, err := DeriveEddsaChildPubKey(pubKey, deduceKey, chainCode, "81/0/0/35/0")
+	assert.NoError(t, err)
+	tweakPub, _ := pubKey.Add(crypto.ScalarBaseMult(edwards.Edwards(), tweak))
+	assert.True(t, tweakPub.Equals(childPubKeyPt))
+}
@@ -9,6 +9,7 @@ package ckd_test
 import (
 	"testing"
 
+	"tss_sdk/crypto"
 	. "tss_sdk/crypto/ckd"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -131,3 +132,27 @@ tests:
 		}
 	}
 }
+
+func TestEcdsaChildTweak(t *testing.T) {
+	master := "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"
+	extKey, err := NewExtendedKeyFromString(master, btcec.S256())
+	if err != nil {
+		t.Fatalf("NewKeyFromString: %v", err)
+	}
+	pub, err := crypto.NewECPoint(btcec.S256(), extKey.PublicKey.X, extKey.PublicKey.Y)
+	if err != nil {
+		t.Fatalf("NewECPoint: %v", err)
+	}
+	childPub, err := DerivePublicKeyForPath(NewExtendKey(nil, pub, pub, 0, 0, extKey.ChainCode), Path{0, 1, 2})
+	if err != nil {
+		t.Fatalf("DerivePublicKeyForPath: %v", err)
+	}
+	tweak, err := DeriveEcdsaChildTweak(pub, extKey.ChainCode, "0/1/2")
+	if err != nil {
+		t.Fatalf("DeriveEcdsaChildTweak: %v", err)
+	}
+	tweakPub, err := pub.Add(crypto.ScalarBaseMult(btcec.S256(), tweak))
+	if err != nil || !tweakPub.Equals(childPub) {
+		t.Errorf("pubkey + tweak*G != child pubkey")
+	}
+}
@@ -0,0 +1,273 @@
+package ckd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// GroupExtendedKey is the public derivation material of a threshold key: the group key, the public share
+// of every party and every chain code. Each chain code tweaks the group key along the path on its own and
+// the child group key takes the sum of the tweaks, so it is derived from the group key and the chain codes
+// alone, without a share. Only the root of a key is serialized: a child of a threshold key has no single
+// chain code to extend it with. The version tells the curve and the derivation scheme.
+type GroupExtendedKey struct {
+	PublicKey  *crypto.ECPoint   // the group key, EdDSAPub
+	PubXj      []*crypto.ECPoint // the public shares, Xj
+	ChainCodes []*big.Int
+	Depth      uint8
+	ChildIndex uint32
+	ParentFP   []byte // parent fingerprint
+	Version    []byte
+}
+
+var (
+	groupVersionEd25519      = []byte{0x74, 0x65, 0x64, 0x01} // "ted\x01"
+	groupVersionSecp256k1    = []byte{0x74, 0x6b, 0x31, 0x01} // "tk1\x01"
+	groupVersionBip32Ed25519 = []byte{0x74, 0x62, 0x65, 0x01} // "tbe\x01"
+)
+
+// NewGroupExtendedKey returns the root group key of pub over Ed25519 or secp256k1, deriving with scheme
+func NewGroupExtendedKey(pub *crypto.ECPoint, pubXj []*crypto.ECPoint, chainCodes []*big.Int, scheme string) (*GroupExtendedKey, error) {
+	version := groupVersionEd25519
+	switch name, _ := tss.GetCurveName(pub.Curve()); name {
+	case tss.Ed25519:
+	case tss.Secp256k1:
+		version = groupVersionSecp256k1
+	default:
+		return nil, errors.New("group key: unsupported curve")
+	}
+	if err := CheckScheme(scheme, pub, chainCodes); err != nil {
+		return nil, fmt.Errorf("group key: %s", err.Error())
+	}
+	if scheme == SchemeBip32Ed25519 {
+		version = groupVersionBip32Ed25519
+	}
+	if len(pubXj) > 0xff || len(chainCodes) > 0xff {
+		return nil, errors.New("group key: too many parties")
+	}
+	for _, code := range chainCodes {
+		if code.BitLen() > 256 {
+			return nil, errors.New("group key: chain code longer than 32 bytes")
+		}
+	}
+	return &GroupExtendedKey{
+		PublicKey:  pub,
+		PubXj:      pubXj,
+		ChainCodes: chainCodes,
+		ParentFP:   make([]byte, 4),
+		Version:    version,
+	}, nil
+}
+
+func (k *GroupExtendedKey) isEd25519() bool {
+	return bytes.Equal(k.Version, groupVersionEd25519) || bytes.Equal(k.Version, groupVersionBip32Ed25519)
+}
+
+// Scheme is the child key derivation scheme of the key, SchemeBip32 or SchemeBip32Ed25519
+func (k *GroupExtendedKey) Scheme() string {
+	if bytes.Equal(k.Version, groupVersionBip32Ed25519) {
+		return SchemeBip32Ed25519
+	}
+	return SchemeBip32
+}
+
+// SerializePubKey encodes a key of the group curve: 32 bytes for Ed25519, 33 bytes compressed for secp256k1
+func (k *GroupExtendedKey) SerializePubKey(pub *crypto.ECPoint) []byte {
+	if k.isEd25519() {
+		return edwards.NewPublicKey(pub.X(), pub.Y()).Serialize()
+	}
+	return serializeCompressed(pub.X(), pub.Y())
+}
+
+func (k *GroupExtendedKey) parseKey(keyData []byte) (*crypto.ECPoint, error) {
+	if k.isEd25519() {
+		pk, err := edwards.ParsePubKey(keyData)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.NewECPoint(edwards.Edwards(), pk.X, pk.Y)
+	}
+	pk, err := btcec.ParsePubKey(keyData, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewECPoint(tss.S256(), pk.X, pk.Y)
+}
+
+func (k *GroupExtendedKey) keyLen() int {
+	if k.isEd25519() {
+		return 32
+	}
+	return PubKeyBytesLenCompressed
+}
+
+// Fingerprint identifies the group key, the first 4 bytes of hash160 of its serialization
+func (k *GroupExtendedKey) Fingerprint() []byte {
+	return hash160(k.SerializePubKey(k.PublicKey))[:4]
+}
+
+// String serializes the group key in base58 with a checksum, like a BIP32 extended public key
+func (k *GroupExtendedKey) String() string {
+	// version(4) || depth(1) || parentFP (4) || childindex(4) || key ||
+	// count(1) || count * Xj || count(1) || count * chaincode(32) || checksum(4)
+	var childNumBytes [4]byte
+	binary.BigEndian.PutUint32(childNumBytes[:], k.ChildIndex)
+
+	serializedBytes := make([]byte, 0, 14+(1+len(k.PubXj))*k.keyLen()+1+32*len(k.ChainCodes)+4)
+	serializedBytes = append(serializedBytes, k.Version...)
+	serializedBytes = append(serializedBytes, k.Depth)
+	serializedBytes = append(serializedBytes, k.ParentFP...)
+	serializedBytes = append(serializedBytes, childNumBytes[:]...)
+	serializedBytes = append(serializedBytes, k.SerializePubKey(k.PublicKey)...)
+	serializedBytes = append(serializedBytes, byte(len(k.PubXj)))
+	for _, Xj := range k.PubXj {
+		serializedBytes = append(serializedBytes, k.SerializePubKey(Xj)...)
+	}
+	serializedBytes = append(serializedBytes, byte(len(k.ChainCodes)))
+	for _, code := range k.ChainCodes {
+		serializedBytes = append(serializedBytes, ChainCodeBytes(code)...)
+	}
+
+	checkSum := doubleHashB(serializedBytes)[:4]
+	serializedBytes = append(serializedBytes, checkSum...)
+	return base58.Encode(serializedBytes)
+}
+
+// NewGroupExtendedKeyFromString returns the group key serialized by GroupExtendedKey.String
+func NewGroupExtendedKeyFromString(key string) (*GroupExtendedKey, error) {
+	decoded := base58.Decode(key)
+	if len(decoded) < 13+4 {
+		return nil, errors.New("invalid group key")
+	}
+
+	// Split the payload and checksum up and ensure the checksum matches.
+	payload := decoded[:len(decoded)-4]
+	checkSum := decoded[len(decoded)-4:]
+	if !bytes.Equal(checkSum, doubleHashB(payload)[:4]) {
+		return nil, errors.New("invalid group key: checksum")
+	}
+
+	k := &GroupExtendedKey{
+		Version:    payload[:4],
+		Depth:      payload[4],
+		ParentFP:   payload[5:9],
+		ChildIndex: binary.BigEndian.Uint32(payload[9:13]),
+	}
+	if !k.isEd25519() && !bytes.Equal(k.Version, groupVersionSecp256k1) {
+		return nil, errors.New("invalid group key: unknown version")
+	}
+	if k.Depth != 0 {
+		return nil, errors.New("invalid group key: only the root of a key can be derived from")
+	}
+
+	rest := payload[13:]
+	keyLen := k.keyLen()
+	readKey := func() (*crypto.ECPoint, error) {
+		if len(rest) < keyLen {
+			return nil, errors.New("invalid group key: too short")
+		}
+		pub, err := k.parseKey(rest[:keyLen])
+		if err != nil {
+			return nil, fmt.Errorf("invalid group key: %s", err.Error())
+		}
+		rest = rest[keyLen:]
+		return pub, nil
+	}
+	readCount := func() (int, error) {
+		if len(rest) < 1 {
+			return 0, errors.New("invalid group key: too short")
+		}
+		count := int(rest[0])
+		rest = rest[1:]
+		return count, nil
+	}
+
+	var err error
+	if k.PublicKey, err = readKey(); err != nil {
+		return nil, err
+	}
+	count, err := readCount()
+	if err != nil {
+		return nil, err
+	}
+	k.PubXj = make([]*crypto.ECPoint, count)
+	for j := range k.PubXj {
+		if k.PubXj[j], err = readKey(); err != nil {
+			return nil, err
+		}
+	}
+	if count, err = readCount(); err != nil {
+		return nil, err
+	}
+	if len(rest) != 32*count {
+		return nil, errors.New("invalid group key: chain codes")
+	}
+	k.ChainCodes = make([]*big.Int, count)
+	for j := range k.ChainCodes {
+		k.ChainCodes[j] = new(big.Int).SetBytes(rest[32*j : 32*(j+1)])
+	}
+	if err := CheckScheme(k.Scheme(), k.PublicKey, k.ChainCodes); err != nil {
+		return nil, fmt.Errorf("invalid group key: %s", err.Error())
+	}
+	return k, nil
+}
+
+// DeriveChildPubKey derives the child group key at the non-hardened path, "" for the group key itself
+func (k *GroupExtendedKey) DeriveChildPubKey(path string) (*crypto.ECPoint, error) {
+	tweak, err := GroupChildTweak(k.PublicKey, k.ChainCodes, k.Scheme(), path)
+	if err != nil {
+		return nil, err
+	}
+	if tweak.Sign() == 0 {
+		return k.PublicKey, nil
+	}
+	return k.PublicKey.Add(crypto.ScalarBaseMult(k.PublicKey.Curve(), tweak))
+}
+
+// GroupChildTweak returns the scalar the group key pub is tweaked by for a non-hardened path, the sum of the
+// tweaks of every chain code, so the child group key is pub + tweak*G. Keys over secp256k1 use BIP 32 tweaks.
+// With SchemeBip32Ed25519 it is the BIP32-Ed25519 tweak from pub and the single chain code.
+// A key without chain codes, such as an imported one, only has the root key.
+func GroupChildTweak(pub *crypto.ECPoint, chainCodes []*big.Int, scheme string, path string) (*big.Int, error) {
+	if err := CheckScheme(scheme, pub, chainCodes); err != nil {
+		return nil, err
+	}
+	if len(chainCodes) == 0 {
+		indexes, err := ParsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		if !indexes.IsRoot() {
+			return nil, errors.New("the key has no chain codes, it derives no child keys")
+		}
+		return big.NewInt(0), nil
+	}
+	if scheme == SchemeBip32Ed25519 {
+		return DeriveBip32Ed25519ChildTweak(pub, ChainCodeBytes(chainCodes[0]), path)
+	}
+	modN := common.ModInt(pub.Curve().Params().N)
+	deriveTweak := DeriveEddsaChildTweak
+	if name, _ := tss.GetCurveName(pub.Curve()); name == tss.Secp256k1 {
+		deriveTweak = DeriveEcdsaChildTweak
+	}
+	tweak := big.NewInt(0)
+	for _, code := range chainCodes {
+		t, err := deriveTweak(pub, ChainCodeBytes(code), path)
+		if err != nil {
+			return nil, err
+		}
+		tweak = modN.Add(tweak, t)
+	}
+	return tweak, nil
+}
@@ -0,0 +1,120 @@
+package ckd_test
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	. "tss_sdk/crypto/ckd"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupExtendedKey(t *testing.T) {
+	chainCode1, _ := hex.DecodeString("be1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f242")
+	chainCode2, _ := hex.DecodeString("00015bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f243")
+	chainCodes := []*big.Int{new(big.Int).SetBytes(chainCode1), new(big.Int).SetBytes(chainCode2)}
+
+	for _, ec := range []tss.CurveName{tss.Ed25519, tss.Secp256k1} {
+		curve, _ := tss.GetCurveByName(ec)
+		x1 := common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+		x2 := common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+		X1, X2 := crypto.ScalarBaseMult(curve, x1), crypto.ScalarBaseMult(curve, x2)
+		pub, _ := X1.Add(X2)
+
+		k, err := NewGroupExtendedKey(pub, []*crypto.ECPoint{X1, X2}, chainCodes, SchemeBip32)
+		assert.NoError(t, err)
+		parsed, err := NewGroupExtendedKeyFromString(k.String())
+		assert.NoError(t, err, ec)
+		assert.True(t, parsed.PublicKey.Equals(pub))
+		assert.True(t, parsed.PubXj[1].Equals(X2))
+		assert.Equal(t, chainCodes, parsed.ChainCodes)
+		assert.Equal(t, k.Fingerprint(), parsed.Fingerprint())
+
+		for _, path := range []string{"", "81/0/0/35/0", "m/44/501/0/0"} {
+			tweak, err := GroupChildTweak(pub, chainCodes, SchemeBip32, path)
+			assert.NoError(t, err)
+			want := pub
+			if tweak.Sign() != 0 {
+				want, _ = pub.Add(crypto.ScalarBaseMult(curve, tweak))
+			}
+			got, err := parsed.DeriveChildPubKey(path)
+			assert.NoError(t, err)
+			assert.True(t, want.Equals(got), "%s %s", ec, path)
+		}
+		_, err = parsed.DeriveChildPubKey("44'/0")
+		assert.Error(t, err)
+
+		bad := []byte(k.String())
+		bad[10] ^= 1
+		_, err = NewGroupExtendedKeyFromString(string(bad))
+		assert.Error(t, err)
+	}
+}
+
+func TestGroupExtendedKeyShares(t *testing.T) {
+	// an n-of-n key: the child of every share sums to the child of the group key
+	chainCode1, _ := hex.DecodeString("be1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f242")
+	chainCode2, _ := hex.DecodeString("ce1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f243")
+	x1 := common.GetRandomPositiveInt(rand.Reader, edwards.Edwards().N)
+	x2 := common.GetRandomPositiveInt(rand.Reader, edwards.Edwards().N)
+	X1, X2 := crypto.ScalarBaseMult(edwards.Edwards(), x1), crypto.ScalarBaseMult(edwards.Edwards(), x2)
+	pub, _ := X1.Add(X2)
+
+	child1, err := DeriveEddsaChildPubKey(X1, pub, chainCode1, "81/0/0/35/0")
+	assert.NoError(t, err)
+	child2, err := DeriveEddsaChildPubKey(X2, pub, chainCode2, "81/0/0/35/0")
+	assert.NoError(t, err)
+	want, _ := child1.Add(child2)
+
+	k, _ := NewGroupExtendedKey(pub, []*crypto.ECPoint{X1, X2},
+		[]*big.Int{new(big.Int).SetBytes(chainCode1), new(big.Int).SetBytes(chainCode2)}, SchemeBip32)
+	parsed, err := NewGroupExtendedKeyFromString(k.String())
+	assert.NoError(t, err)
+	got, err := parsed.DeriveChildPubKey("81/0/0/35/0")
+	assert.NoError(t, err)
+	assert.True(t, want.Equals(got))
+}
+
+func TestGroupChildTweakLeadingZeroChainCode(t *testing.T) {
+	// the chain code keeps its leading zero byte in the HMAC key, as in a BIP 32 wallet
+	chainCode, _ := hex.DecodeString("00a8f9c6cbd8a8cfe3d2a4c4f52a8a7d3c64a7c0ab6b6be85b6a1f59c6a0e1d2")
+	x, _ := new(big.Int).SetString("e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", 16)
+	pub := crypto.ScalarBaseMult(btcec.S256(), x)
+
+	// BIP 32 CKDpub of m/0/1
+	want, code := pub, chainCode
+	for _, index := range []uint32{0, 1} {
+		mac := hmac.New(sha512.New, code)
+		mac.Write((*btcec.PublicKey)(want.ToECDSAPubKey()).SerializeCompressed())
+		mac.Write([]byte{byte(index >> 24), byte(index >> 16), byte(index >> 8), byte(index)})
+		I := mac.Sum(nil)
+		want, _ = want.Add(crypto.ScalarBaseMult(btcec.S256(), new(big.Int).SetBytes(I[:32])))
+		code = I[32:]
+	}
+
+	tweak, err := GroupChildTweak(pub, []*big.Int{new(big.Int).SetBytes(chainCode)}, SchemeBip32, "0/1")
+	assert.NoError(t, err)
+	got, err := pub.Add(crypto.ScalarBaseMult(btcec.S256(), tweak))
+	assert.NoError(t, err)
+	assert.True(t, want.Equals(got))
+}
+
+func TestGroupChildTweakNoChainCodes(t *testing.T) {
+	pub := crypto.ScalarBaseMult(tss.Edwards(), big.NewInt(7))
+	for _, path := range []string{"", "m"} {
+		tweak, err := GroupChildTweak(pub, nil, SchemeBip32, path)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, tweak.Sign())
+	}
+	_, err := GroupChildTweak(pub, nil, SchemeBip32, "81/0/0/35/0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package ckd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a non-hardened BIP 32 derivation path, the child indexes from the root key down.
+// The empty path is the root key itself.
+type Path []uint32
+
+// ParsePath reads a path such as "m/44/501/0/0" or "44/501/0/0"; "" and "m" are the root key.
+// Hardened segments ("0'", "0h") are rejected: threshold keys can only derive non-hardened children,
+// since hardened derivation needs the whole private key. SLIP-10 Ed25519 paths, as Solana, Aptos and Sui
+// wallets use, are hardened-only and so not supported: that would need a maliciously secure multi-party
+// HMAC-SHA512 over the keygen shares, which this SDK does not provide.
+func ParsePath(path string) (Path, error) {
+	path = strings.TrimSpace(path)
+	if path == "m" || path == "M" {
+		path = ""
+	}
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "m/"), "M/")
+	if path == "" {
+		return Path{}, nil
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) > maxDepth {
+		return nil, fmt.Errorf("invalid BIP 32 path: depth %d beyond max depth %d", len(parts), maxDepth)
+	}
+	indexes := make(Path, len(parts))
+	for l, part := range parts {
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			return nil, fmt.Errorf("invalid BIP 32 path: hardened segment %q not supported, only non-hardened derivation", part)
+		}
+		idx, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BIP 32 path: segment %q: %s", part, err)
+		}
+		if idx >= HardenedKeyStart {
+			return nil, fmt.Errorf("invalid BIP 32 path: hardened segment %q not supported, only non-hardened derivation", part)
+		}
+		indexes[l] = uint32(idx)
+	}
+	return indexes, nil
+}
+
+// String is the canonical form of the path, "m" for the root key
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, idx := range p {
+		b.WriteString("/")
+		b.WriteString(strconv.FormatUint(uint64(idx), 10))
+	}
+	return b.String()
+}
+
+// IsRoot reports whether the path is the root key
+func (p Path) IsRoot() bool {
+	return len(p) == 0
+}
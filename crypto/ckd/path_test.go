@@ -0,0 +1,69 @@
+package ckd_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"tss_sdk/crypto"
+	. "tss_sdk/crypto/ckd"
+
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	for path, want := range map[string]Path{
+		"":                {},
+		"m":               {},
+		"m/44/501/0/0":    {44, 501, 0, 0},
+		"44/501/0/0":      {44, 501, 0, 0},
+		"81/0/0/35/0":     {81, 0, 0, 35, 0},
+		"m/1/2/3/4/5/6/7": {1, 2, 3, 4, 5, 6, 7},
+		"2147483647":      {HardenedKeyStart - 1},
+	} {
+		got, err := ParsePath(path)
+		assert.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+	}
+
+	for _, path := range []string{"m/44'/501'/0'/0'", "0h", "2147483648", "0//1", "a/1", "-1"} {
+		_, err := ParsePath(path)
+		assert.Error(t, err, path)
+	}
+	_, err := ParsePath(strings.Repeat("0/", 255) + "0")
+	assert.Error(t, err)
+
+	p, _ := ParsePath("44/501/0/0")
+	assert.Equal(t, "m/44/501/0/0", p.String())
+	assert.Equal(t, "m", Path{}.String())
+	assert.True(t, Path{}.IsRoot())
+}
+
+func TestRootPathDerivation(t *testing.T) {
+	priKeyBytes, _ := hex.DecodeString("ae1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f241")
+	chainCode, _ := hex.DecodeString("be1e5bf5f3d6bf58b5c222088671fcbe78b437e28fae944c793897b26091f242")
+	priKey := new(big.Int).SetBytes(priKeyBytes)
+	pubKey := crypto.ScalarBaseMult(edwards.Edwards(), priKey)
+
+	childPubKeyPt, err := DeriveEddsaChildPubKey(pubKey, pubKey, chainCode, "")
+	assert.NoError(t, err)
+	assert.True(t, pubKey.Equals(childPubKeyPt))
+
+	tweak, err := DeriveEddsaChildTweak(pubKey, chainCode, "m")
+	assert.NoError(t, err)
+	assert.Zero(t, tweak.Sign())
+
+	// the prefix does not change the key, and deeper paths derive
+	pub1, err := DeriveEddsaChildPubKey(pubKey, pubKey, chainCode, "m/44/501/0/0")
+	assert.NoError(t, err)
+	pub2, err := DeriveEddsaChildPubKey(pubKey, pubKey, chainCode, "44/501/0/0")
+	assert.NoError(t, err)
+	assert.True(t, pub1.Equals(pub2))
+	_, err = DeriveEddsaChildPubKey(pubKey, pubKey, chainCode, "1/2/3/4/5/6/7/8")
+	assert.NoError(t, err)
+
+	_, err = DeriveEddsaChildPubKey(pubKey, pubKey, chainCode, "m/44'/501'")
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Feldman VSS, based on Paul Feldman, 1987., A practical scheme for non-interactive verifiable secret sharing.
+// In Foundations of Computer Science, 1987., 28th Annual Symposium on. IEEE, 427–43
+//
+// Unlike tss-lib, `threshold` here is the number of shares required to reconstruct the secret,
+// i.e. the dealt polynomial has degree threshold-1.
+
+package vss
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+)
+
+type (
+	Share struct {
+		Threshold int
+		ID,       // xi
+		Share *big.Int // Sigma i
+	}
+
+	Vs []*crypto.ECPoint // v0..vt-1
+
+	Shares []*Share
+)
+
+var (
+	ErrNumSharesBelowThreshold = fmt.Errorf("not enough shares to satisfy the threshold")
+
+	zero = big.NewInt(0)
+	one  = big.NewInt(1)
+)
+
+// CheckIndexes checks that the share indexes are non-zero and distinct modulo the curve order
+func CheckIndexes(ec elliptic.Curve, indexes []*big.Int) ([]*big.Int, error) {
+	visited := make(map[string]struct{})
+	for _, v := range indexes {
+		vMod := new(big.Int).Mod(v, ec.Params().N)
+		if vMod.Cmp(zero) == 0 {
+			return nil, errors.New("party index should not be 0")
+		}
+		vModStr := vMod.String()
+		if _, ok := visited[vModStr]; ok {
+			return nil, fmt.Errorf("duplicate indexes %s", vModStr)
+		}
+		visited[vModStr] = struct{}{}
+	}
+	return indexes, nil
+}
+
+// Create returns a new array of secret shares created by Shamir's Secret Sharing Algorithm,
+// requiring `threshold` shares to reconstruct, and a list of commitments to the polynomial coefficients
+func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.Int, rand io.Reader) (Vs, Shares, error) {
+	if secret == nil || indexes == nil {
+		return nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+
+	ids, err := CheckIndexes(ec, indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(ec, threshold, secret, rand)
+	v := make(Vs, len(poly))
+	for i, ai := range poly {
+		v[i] = crypto.ScalarBaseMult(ec, ai)
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(ec, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	return v, shares, nil
+}
+
+// Verify checks the share against the polynomial commitments: share*G == sum(vs[c] * id^c)
+func (share *Share) Verify(ec elliptic.Curve, threshold int, vs Vs) bool {
+	if share.Threshold != threshold || vs == nil || len(vs) != threshold {
+		return false
+	}
+	v, err := vs.Evaluate(share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(ec, share.Share)
+	return sigmaGi.Equals(v)
+}
+
+// Evaluate computes sum(vs[c] * id^c), the public share point of the party with the given index
+func (vs Vs) Evaluate(id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("vss commitments are empty")
+	}
+	ec := vs[0].Curve()
+	modQ := common.ModInt(ec.Params().N)
+	var err error
+	v, t := vs[0], one // YRO : we need to have our accumulator outside of the loop
+	for j := 1; j < len(vs); j++ {
+		// t = k_i^j
+		t = modQ.Mul(t, id)
+		// v = v * v_j^t
+		vjt := vs[j].ScalarMult(t)
+		v, err = v.Add(vjt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// ReConstruct recovers the secret from at least `threshold` shares using Lagrange interpolation at 0
+func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error) {
+	if shares != nil && len(shares) > 0 && shares[0].Threshold > len(shares) {
+		return nil, ErrNumSharesBelowThreshold
+	}
+	modN := common.ModInt(ec.Params().N)
+
+	// x coords
+	xs := make([]*big.Int, 0)
+	for _, share := range shares {
+		xs = append(xs, share.ID)
+	}
+
+	secret = zero
+	for i, share := range shares {
+		secret = modN.Add(secret, modN.Mul(share.Share, LagrangeCoefficient(ec, i, xs)))
+	}
+	return secret, nil
+}
+
+// LagrangeCoefficient returns the coefficient of the i-th point when interpolating at 0 over the given x coords
+func LagrangeCoefficient(ec elliptic.Curve, i int, xs []*big.Int) *big.Int {
+	modN := common.ModInt(ec.Params().N)
+	times := one
+	for j := range xs {
+		if j == i {
+			continue
+		}
+		sub := modN.Sub(xs[j], xs[i])
+		subInv := modN.ModInverse(sub)
+		div := modN.Mul(xs[j], subInv)
+		times = modN.Mul(times, div)
+	}
+	return times
+}
+
+// samplePolynomial returns a random polynomial of degree threshold-1 whose constant term is the secret
+func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int, rand io.Reader) []*big.Int {
+	q := ec.Params().N
+	v := make([]*big.Int, threshold)
+	v[0] = secret
+	for i := 1; i < threshold; i++ {
+		ai := common.GetRandomPositiveInt(rand, q)
+		v[i] = ai
+	}
+	return v
+}
+
+// Evauluates a polynomial with coefficients such that:
+// evaluatePolynomial([a, b, c, d], x):
+//
+//	returns a + bx + cx^2 + dx^3
+func evaluatePolynomial(ec elliptic.Curve, v []*big.Int, id *big.Int) (result *big.Int) {
+	q := ec.Params().N
+	modQ := common.ModInt(q)
+	result = new(big.Int).Set(v[0])
+	X := big.NewInt(int64(1))
+	for i := 1; i < len(v); i++ {
+		ai := v[i]
+		X = modQ.Mul(X, id)
+		aiXi := new(big.Int).Mul(ai, X)
+		result = modQ.Add(result, aiXi)
+	}
+	return
+}
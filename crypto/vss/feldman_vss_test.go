@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package vss_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	. "tss_sdk/crypto/vss"
+	"tss_sdk/tss"
+)
+
+func TestCheckIndexesDup(t *testing.T) {
+	indexes := make([]*big.Int, 0)
+	for i := 0; i < 1000; i++ {
+		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+	_, e := CheckIndexes(tss.EC(), indexes)
+	assert.NoError(t, e)
+
+	indexes = append(indexes, indexes[99])
+	_, e = CheckIndexes(tss.EC(), indexes)
+	assert.Error(t, e)
+}
+
+func TestCheckIndexesZero(t *testing.T) {
+	indexes := make([]*big.Int, 0)
+	for i := 0; i < 1000; i++ {
+		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+	_, e := CheckIndexes(tss.EC(), indexes)
+	assert.NoError(t, e)
+
+	indexes = append(indexes, tss.EC().Params().N)
+	_, e = CheckIndexes(tss.EC(), indexes)
+	assert.Error(t, e)
+}
+
+func TestCreate(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, _, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.Nil(t, err)
+
+	assert.Equal(t, threshold, len(vs))
+
+	// ensure that each vs has two points on the curve
+	for i, pg := range vs {
+		assert.NotZero(t, pg.X())
+		assert.NotZero(t, pg.Y())
+		assert.True(t, pg.IsOnCurve())
+		assert.NotZero(t, vs[i].X())
+		assert.NotZero(t, vs[i].Y())
+	}
+	assert.True(t, vs[0].Equals(crypto.ScalarBaseMult(tss.EC(), secret)))
+}
+
+func TestVerify(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	vs, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+
+	for i := 0; i < num; i++ {
+		assert.True(t, shares[i].Verify(tss.EC(), threshold, vs))
+	}
+
+	// a tampered share must not verify
+	bad := &Share{Threshold: threshold, ID: shares[0].ID, Share: new(big.Int).Add(shares[0].Share, big.NewInt(1))}
+	assert.False(t, bad.Verify(tss.EC(), threshold, vs))
+}
+
+func TestReconstruct(t *testing.T) {
+	num, threshold := 5, 3
+
+	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+	}
+
+	_, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	assert.NoError(t, err)
+
+	secret2, err2 := shares[:threshold-1].ReConstruct(tss.EC())
+	assert.Error(t, err2) // not enough shares to satisfy the threshold
+	assert.Nil(t, secret2)
+
+	secret3, err3 := shares[:threshold].ReConstruct(tss.EC())
+	assert.NoError(t, err3)
+	assert.Zero(t, secret3.Cmp(secret))
+
+	secret4, err4 := shares[num-threshold:].ReConstruct(tss.EC())
+	assert.NoError(t, err4)
+	assert.Zero(t, secret4.Cmp(secret))
+
+	secret5, err5 := shares.ReConstruct(tss.EC())
+	assert.NoError(t, err5)
+	assert.Zero(t, secret5.Cmp(secret))
+}
@@ -0,0 +1,174 @@
+package tss_sdk
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"strings"
+	ecdsakeygen "tss_sdk/ecdsacmp/keygen"
+	ecdsasign "tss_sdk/ecdsacmp/sign"
+)
+
+// ---------------------ecdsa keygen------------------------
+
+// secp256k1 keygen; the party is run with the Keygen* functions above, the protocol of its
+// session manifest is "ecdsa-cmp-keygen"
+func NewEcdsaKeygenLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // number of parties required to sign, partyCount for an n-of-n key
+	pIDs string,
+	rootPrivKey string, // hex string
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := ecdsakeygen.NewLocalParty(key, partyIndex, partyCount, threshold, ids, rootPrivKey)
+	return resFromKeygen(res)
+}
+
+// ---------------------ecdsa sign------------------------
+
+func NewEcdsaSignLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // the keygen threshold
+	pIDs string,
+	msg string, // 32-byte message digest, hex string
+	keyData string, // keygen.LocalPartySaveData of a secp256k1 key, base64 string
+	walletPath string, // non-hardened BIP 32 path, "" signs with the root key
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := ecdsasign.NewLocalParty(key, partyIndex, partyCount, threshold, ids, msg, keyData, walletPath)
+	return resFromEcdsaSign(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "ecdsa-cmp-sign",
+// the parties are the signers and the threshold is the keygen threshold
+func SetEcdsaSignSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := ecdsasign.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromEcdsaSign(res)
+}
+
+func RemoveEcdsaSignParty(key string) bool {
+	return ecdsasign.RemoveSignParty(key)
+}
+
+func EcdsaSignRound1Exec(key string) *MpcExecResult {
+	res := ecdsasign.SignRound1Exec(key)
+	return execResFromEcdsaSign(res)
+}
+
+func GetEcdsaSignRound1Msg(key string, to int) *MpcExecResult {
+	res := ecdsasign.GetRound1Msg2(key, to)
+	return execResFromEcdsaSign(res)
+}
+
+func EcdsaSignRound1MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := ecdsasign.SignRound1MsgAccept(key, from, msgWireBytes)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound1Finish(key string) *MpcResult {
+	res := ecdsasign.SignRound1Finish(key)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound2Exec(key string) *MpcResult {
+	res := ecdsasign.SignRound2Exec(key)
+	return resFromEcdsaSign(res)
+}
+
+func GetEcdsaSignRound2Msg(key string, to int) *MpcExecResult {
+	res := ecdsasign.GetRound2Msg(key, to)
+	return execResFromEcdsaSign(res)
+}
+
+func EcdsaSignRound2MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := ecdsasign.SignRound2MsgAccept(key, from, msgWireBytes)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound2Finish(key string) *MpcResult {
+	res := ecdsasign.SignRound2Finish(key)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound3Exec(key string) *MpcResult {
+	res := ecdsasign.SignRound3Exec(key)
+	return resFromEcdsaSign(res)
+}
+
+func GetEcdsaSignRound3Msg(key string, to int) *MpcExecResult {
+	res := ecdsasign.GetRound3Msg(key, to)
+	return execResFromEcdsaSign(res)
+}
+
+func EcdsaSignRound3MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := ecdsasign.SignRound3MsgAccept(key, from, msgWireBytes)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound3Finish(key string) *MpcResult {
+	res := ecdsasign.SignRound3Finish(key)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound4Exec(key string) *MpcExecResult {
+	res := ecdsasign.SignRound4Exec(key)
+	return execResFromEcdsaSign(res)
+}
+
+func EcdsaSignRound4MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := ecdsasign.SignRound4MsgAccept(key, from, msgWireBytes)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignRound4Finish(key string) *MpcResult {
+	res := ecdsasign.SignRound4Finish(key)
+	return resFromEcdsaSign(res)
+}
+
+// the output is common.SignatureData, r || s and the recovery id in SignatureRecovery
+func EcdsaSignFinalExec(key string) *MpcExecResult {
+	res := ecdsasign.SignFinalExec(key)
+	return execResFromEcdsaSign(res)
+}
+
+// optional echo of broadcast round 1 or 4, run between EcdsaSignRoundNFinish and the next exec
+// when the transport is not a reliable broadcast; every signer must run it for the same rounds
+func EcdsaSignEchoExec(key string, round int) *MpcExecResult {
+	res := ecdsasign.SignEchoExec(key, round)
+	return execResFromEcdsaSign(res)
+}
+
+func EcdsaSignEchoAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := ecdsasign.SignEchoAccept(key, from, msgWireBytes)
+	return resFromEcdsaSign(res)
+}
+
+func EcdsaSignEchoFinish(key string, round int) *MpcResult {
+	res := ecdsasign.SignEchoFinish(key, round)
+	return resFromEcdsaSign(res)
+}
+
+func execResFromEcdsaSign(res ecdsasign.SignExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromEcdsaSign(res ecdsasign.SignResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
@@ -0,0 +1,29 @@
+package keygen
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/tss"
+)
+
+// The CMP keygen does not depend on the curve: ECDSA keys are made by the rounds of eddsacmp/keygen
+// over secp256k1. Parties created here are run with the keygen round functions of that package and
+// their output is a keygen.LocalPartySaveData whose PubKey() is the ECDSA public key.
+
+type LocalPartySaveData = keygen.LocalPartySaveData
+
+// NewLocalParty creates a secp256k1 keygen party, the session manifest protocol is TaskName
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // number of parties required to sign
+	pIDs []string,
+	rootPrivKey string,
+) (result keygen.KeygenResult) {
+	return keygen.NewCurveLocalParty(key, tss.S256(), TaskName, partyIndex, partyCount, threshold, pIDs, rootPrivKey)
+}
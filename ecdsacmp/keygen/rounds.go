@@ -0,0 +1,5 @@
+package keygen
+
+const (
+	TaskName = "ecdsa-cmp-keygen"
+)
@@ -0,0 +1,279 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/tss"
+
+	"github.com/ipfs/go-log"
+)
+
+// CMP ECDSA signing (presigning and signing in one session):
+// round 1 encrypts k_i and γ_i under our Paillier key, round 2 runs the MtA of γ_j·k_i and w_j·k_i with
+// every Pj and sends Γ_i = γ_i·G, round 3 sends δ_i and Δ_i = k_i·Γ, round 4 checks δ·G = ΣΔ_j and sends
+// σ_i = k_i·m + r·χ_i, and the final round adds up σ and outputs the signature with its recovery id.
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys     keygen.LocalPartySaveData
+		temp     localTempData
+		data     *common.SignatureData
+		manifest *tss.SessionManifest
+		number   int
+	}
+
+	localMessageStore struct {
+		signRound1Message1s,
+		signRound1Message2s,
+		signRound2Messages,
+		signRound3Messages,
+		signRound4Messages [][]byte // msg.WireBytes()
+		echo *tss.Echo // echoes of the broadcast rounds
+	}
+
+	sendMessageStore struct {
+		signRound1Message2s,
+		signRound2Messages,
+		signRound3Messages [][]byte // msg.WireBytes()
+	}
+
+	localTempData struct {
+		localMessageStore
+		send sendMessageStore
+
+		// temp data (thrown away after sign) / round 1
+		k, gamma  *big.Int
+		rho, nu   *big.Int // randomness of K_i and G_i
+		Ks, Gs    []*big.Int
+		m         *big.Int
+		mBytes    []byte
+		walletPub *crypto.ECPoint
+
+		// round 2: β_{i,j} and β̂_{i,j}, the shares we keep of the MtA with Pj
+		betas, betaHats []*big.Int
+		bigGamma        *crypto.ECPoint // Γ_i
+
+		// round 3
+		Gamma      *crypto.ECPoint // Γ = ΣΓ_j
+		delta, chi *big.Int
+
+		// round 4
+		R     *crypto.ECPoint
+		r     *big.Int
+		sigma *big.Int
+
+		ssid []byte
+	}
+)
+
+var SignParties = map[string]*LocalParty{}
+
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // number of parties required to sign, as given to keygen
+	pIDs []string,
+	msg string, // 32-byte message digest, hex string
+	keyData string, // keygen.LocalPartySaveData of a secp256k1 key, base64 string
+	walletPath string, // non-hardened BIP 32 path, "" signs with the root key
+) (result SignResult) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		result.Err = fmt.Sprintf("set log level, err: %s", err.Error())
+		return
+	}
+	tss.SetCurve(tss.S256())
+
+	if partyIndex < 0 || partyIndex >= partyCount || len(pIDs) != partyCount {
+		common.Logger.Errorf("party index err: %d, party count: %d", partyIndex, partyCount)
+		result.Err = fmt.Sprintf("party index err: %d, party count: %d", partyIndex, partyCount)
+		return
+	}
+	uIds := make(tss.UnSortedPartyIDs, 0, partyCount)
+	for i := 0; i < partyCount; i++ {
+		pId, _ := new(big.Int).SetString(pIDs[i], 10)
+		common.Logger.Infof("id: %d", pId)
+		uIds = append(uIds, tss.NewPartyID(fmt.Sprintf("%d", i), fmt.Sprintf("m_%d", i), pId))
+	}
+	ids := tss.SortPartyIDs(uIds)
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(ids), ids[partyIndex], partyCount, threshold)
+
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := &keygen.LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.PubKey() == nil {
+		result.Err = "keygen data has no public key"
+		return
+	}
+	if name, _ := tss.GetCurveName(keys.PubKey().Curve()); name != tss.Secp256k1 {
+		common.Logger.Errorf("keygen data is not a secp256k1 key")
+		result.Err = "keygen data is not a secp256k1 key"
+		return
+	}
+	if !keys.HasAuxInfo() {
+		common.Logger.Errorf("no aux info in keygen data")
+		result.Err = "no aux info in keygen data"
+		return
+	}
+
+	if threshold != keys.SignThreshold() {
+		common.Logger.Errorf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		result.Err = fmt.Sprintf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		return
+	}
+	if partyCount < threshold || (keys.IsAdditive() && partyCount != len(keys.Ks)) {
+		common.Logger.Errorf("party count err: %d, threshold: %d", partyCount, threshold)
+		result.Err = fmt.Sprintf("party count err: %d, threshold: %d", partyCount, threshold)
+		return
+	}
+
+	mBytes, err := hex.DecodeString(msg)
+	if err != nil || len(mBytes) != 32 {
+		common.Logger.Errorf("msg must be a 32-byte digest, hex string")
+		result.Err = "msg must be a 32-byte digest, hex string"
+		return
+	}
+
+	keyParty, err := keygen.BuildLocalSaveDataSubset(*keys, params.Parties().IDs())
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	if !keys.IsAdditive() {
+		onsign.PrepareForSigning(params.EC(), partyIndex, &keyParty)
+	}
+	walletPub, err := keyParty.DeriveChildKeys(partyIndex, walletPath)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keyParty,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+	}
+	// msgs init
+	p.temp.signRound1Message1s = make([][]byte, partyCount)
+	p.temp.signRound1Message2s = make([][]byte, partyCount)
+	p.temp.signRound2Messages = make([][]byte, partyCount)
+	p.temp.signRound3Messages = make([][]byte, partyCount)
+	p.temp.signRound4Messages = make([][]byte, partyCount)
+	p.temp.send.signRound1Message2s = make([][]byte, partyCount)
+	p.temp.send.signRound2Messages = make([][]byte, partyCount)
+	p.temp.send.signRound3Messages = make([][]byte, partyCount)
+	p.temp.echo = tss.NewEcho(TaskName, p.PartyID(), p.params.Parties().IDs(), p.broadcastMessages)
+
+	// temp data init
+	p.temp.m = new(big.Int).SetBytes(mBytes)
+	p.temp.mBytes = mBytes
+	p.temp.walletPub = walletPub
+	p.temp.Ks = make([]*big.Int, partyCount)
+	p.temp.Gs = make([]*big.Int, partyCount)
+	p.temp.betas = make([]*big.Int, partyCount)
+	p.temp.betaHats = make([]*big.Int, partyCount)
+
+	SignParties[key] = p
+	result.Ok = true
+	return
+}
+
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(TaskName, party.params.Parties().IDs().Keys(), nil, party.params.Threshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
+func RemoveSignParty(key string) bool {
+	if _, ok := SignParties[key]; !ok {
+		return false
+	}
+	delete(SignParties, key)
+	return true
+}
+
+// blame returns an identifiable abort error naming Pj as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.PartyID(), p.params.Parties().IDs()[j])
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// proofContext binds the proofs of party j to this session
+func (p *LocalParty) proofContext(j int) []byte {
+	return append(append([]byte{}, p.temp.ssid...), big.NewInt(int64(j)).Bytes()...)
+}
+
+// get ssid from the session manifest and local params
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	BigXjList, err := crypto.FlattenECPoints(p.keys.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList := BigXjList // BigXj
+	for j, pk := range p.keys.RingPedersenPKs {
+		if pk == nil || p.keys.PaillierPKs[j] == nil {
+			return nil, errors.New("found nil paillier or pedersen pk")
+		}
+		ssidList = append(ssidList, p.keys.PaillierPKs[j].N, pk.S, pk.T)
+	}
+	return p.manifest.SSID(p.params.EC(), p.params.Parties().IDs().Keys(), ssidList...), nil
+}
@@ -0,0 +1,253 @@
+package message
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/affgproof"
+	"tss_sdk/crypto/encproof"
+	"tss_sdk/crypto/logproof"
+	"tss_sdk/tss"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cmp-sign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message1)(nil),
+		(*SignRound1Message2)(nil),
+		(*SignRound2Message)(nil),
+		(*SignRound3Message)(nil),
+		(*SignRound4Message)(nil),
+	}
+)
+
+func NewSignRound1Message1(
+	from *tss.PartyID,
+	ssid []byte,
+	K, G *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message1{
+		Ssid: ssid,
+		BigK: K.Bytes(),
+		BigG: G.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetBigK()) &&
+		common.NonEmptyBytes(m.GetBigG())
+}
+
+func (m *SignRound1Message1) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigK())
+}
+
+func (m *SignRound1Message1) UnmarshalG() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigG())
+}
+
+// ----- //
+
+func NewSignRound1Message2(
+	to, from *tss.PartyID,
+	encProof []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound1Message2{
+		EncProof: encProof,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetEncProof())
+}
+
+func (m *SignRound1Message2) UnmarshalEncProof() (*encproof.EncryptRangeMessage, error) {
+	encProof := &encproof.EncryptRangeMessage{}
+	if err := proto.Unmarshal(m.GetEncProof(), encProof); err != nil {
+		return nil, err
+	}
+	return encProof, nil
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	to, from *tss.PartyID,
+	Gamma *crypto.ECPoint,
+	D, F, DHat, FHat *big.Int,
+	affgProof, affgHatProof, logProof []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound2Message{
+		GammaX:       Gamma.X().Bytes(),
+		GammaY:       Gamma.Y().Bytes(),
+		BigD:         D.Bytes(),
+		BigF:         F.Bytes(),
+		BigDHat:      DHat.Bytes(),
+		BigFHat:      FHat.Bytes(),
+		AffgProof:    affgProof,
+		AffgHatProof: affgHatProof,
+		LogProof:     logProof,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetGammaX()) &&
+		common.NonEmptyBytes(m.GetGammaY()) &&
+		common.NonEmptyBytes(m.GetBigD()) &&
+		common.NonEmptyBytes(m.GetBigF()) &&
+		common.NonEmptyBytes(m.GetBigDHat()) &&
+		common.NonEmptyBytes(m.GetBigFHat()) &&
+		common.NonEmptyBytes(m.GetAffgProof()) &&
+		common.NonEmptyBytes(m.GetAffgHatProof()) &&
+		common.NonEmptyBytes(m.GetLogProof())
+}
+
+func (m *SignRound2Message) UnmarshalGamma(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetGammaX()),
+		new(big.Int).SetBytes(m.GetGammaY()),
+	)
+}
+
+func (m *SignRound2Message) UnmarshalD() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigD())
+}
+
+func (m *SignRound2Message) UnmarshalF() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigF())
+}
+
+func (m *SignRound2Message) UnmarshalDHat() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigDHat())
+}
+
+func (m *SignRound2Message) UnmarshalFHat() *big.Int {
+	return new(big.Int).SetBytes(m.GetBigFHat())
+}
+
+func (m *SignRound2Message) UnmarshalAffgProofs() (*affgproof.PaillierAffineGroupMessage, *affgproof.PaillierAffineGroupMessage, error) {
+	affgProof := &affgproof.PaillierAffineGroupMessage{}
+	if err := proto.Unmarshal(m.GetAffgProof(), affgProof); err != nil {
+		return nil, nil, err
+	}
+	affgHatProof := &affgproof.PaillierAffineGroupMessage{}
+	if err := proto.Unmarshal(m.GetAffgHatProof(), affgHatProof); err != nil {
+		return nil, nil, err
+	}
+	return affgProof, affgHatProof, nil
+}
+
+func (m *SignRound2Message) UnmarshalLogProof() (*logproof.LogStarMessage, error) {
+	logProof := &logproof.LogStarMessage{}
+	if err := proto.Unmarshal(m.GetLogProof(), logProof); err != nil {
+		return nil, err
+	}
+	return logProof, nil
+}
+
+// ----- //
+
+func NewSignRound3Message(
+	to, from *tss.PartyID,
+	delta *big.Int,
+	Delta *crypto.ECPoint,
+	logProof []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &SignRound3Message{
+		Delta:     delta.Bytes(),
+		BigDeltaX: Delta.X().Bytes(),
+		BigDeltaY: Delta.Y().Bytes(),
+		LogProof:  logProof,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBigDeltaX()) &&
+		common.NonEmptyBytes(m.GetBigDeltaY()) &&
+		common.NonEmptyBytes(m.GetLogProof())
+}
+
+func (m *SignRound3Message) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *SignRound3Message) UnmarshalBigDelta(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetBigDeltaX()),
+		new(big.Int).SetBytes(m.GetBigDeltaY()),
+	)
+}
+
+func (m *SignRound3Message) UnmarshalLogProof() (*logproof.LogStarMessage, error) {
+	logProof := &logproof.LogStarMessage{}
+	if err := proto.Unmarshal(m.GetLogProof(), logProof); err != nil {
+		return nil, err
+	}
+	return logProof, nil
+}
+
+// ----- //
+
+func NewSignRound4Message(
+	from *tss.PartyID,
+	sigma *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound4Message{
+		Sigma: sigma.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound4Message) ValidateBasic() bool {
+	return m != nil
+}
+
+func (m *SignRound4Message) UnmarshalSigma() *big.Int {
+	return new(big.Int).SetBytes(m.GetSigma())
+}
@@ -0,0 +1,20 @@
+package sign
+
+import (
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cmp-sign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*m.SignRound1Message1)(nil),
+		(*m.SignRound1Message2)(nil),
+		(*m.SignRound2Message)(nil),
+		(*m.SignRound3Message)(nil),
+		(*m.SignRound4Message)(nil),
+	}
+)
@@ -0,0 +1,192 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto/encproof"
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func SignRound1Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 1
+	i := party.PartyID().Index
+	common.Logger.Infof("[ecdsa sign] party: %d, round_1 start", i)
+
+	var err error
+	party.temp.ssid, err = party.getSSID()
+	if err != nil {
+		common.Logger.Errorf("get ssid err: %s", err.Error())
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+
+	// k, γ in F_q
+	q := party.params.EC().Params().N
+	party.temp.k = common.GetRandomPositiveInt(party.params.Rand(), q)
+	party.temp.gamma = common.GetRandomPositiveInt(party.params.Rand(), q)
+
+	// K_i = enc_i(k_i; ρ_i), G_i = enc_i(γ_i; ν_i)
+	paillierPK := party.keys.PaillierPKs[i]
+	K, rho, err := paillierPK.EncryptAndReturnRandomness(party.params.Rand(), party.temp.k)
+	if err != nil {
+		common.Logger.Errorf("encrypt k failed: %s", err)
+		result.Err = fmt.Sprintf("encrypt k failed: %s", err)
+		return
+	}
+	G, nu, err := paillierPK.EncryptAndReturnRandomness(party.params.Rand(), party.temp.gamma)
+	if err != nil {
+		common.Logger.Errorf("encrypt gamma failed: %s", err)
+		result.Err = fmt.Sprintf("encrypt gamma failed: %s", err)
+		return
+	}
+	party.temp.rho, party.temp.nu = rho, nu
+	party.temp.Ks[i], party.temp.Gs[i] = K, G
+
+	// broadcast K_i, G_i
+	r1msg1 := m.NewSignRound1Message1(party.PartyID(), party.temp.ssid, K, G)
+	msgWireBytes, _, err := r1msg1.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound1Message1s[i] = msgWireBytes
+
+	// p2p send enc proof of k_i to Pj, under the ring-Pedersen parameters of Pj
+	contextI := party.proofContext(i)
+	for j, Pj := range party.params.Parties().IDs() {
+		encProof, err := encproof.NewEncryptRangeMessage(ProofParameter, contextI, K,
+			paillierPK.N, party.temp.k, rho, party.keys.RingPedersenPKs[j],
+		)
+		if err != nil {
+			common.Logger.Errorf("create enc proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("create enc proof failed: %s, party: %d", err, j)
+			return
+		}
+		encProofBytes, err := proto.Marshal(encProof)
+		if err != nil {
+			common.Logger.Errorf("marshal enc proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("marshal enc proof failed: %s, party: %d", err, j)
+			return
+		}
+
+		r1msg2 := m.NewSignRound1Message2(Pj, party.PartyID(), encProofBytes)
+		msg2WireBytes, _, err := r1msg2.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.signRound1Message2s[j] = msg2WireBytes
+		if j == i {
+			party.temp.signRound1Message2s[i] = msg2WireBytes
+		}
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// p2p enc proof for party `to`
+func GetRound1Msg2(key string, to int) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.signRound1Message2s) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.signRound1Message2s[to]
+	return
+}
+
+func SignRound1MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound1Message1s) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+
+	switch content := msg.Content().(type) {
+	case *m.SignRound1Message1:
+		if !content.ValidateBasic() {
+			result.Err = "invalid SignRound1Message1"
+			return
+		}
+		party.temp.signRound1Message1s[from] = rMsgBytes
+	case *m.SignRound1Message2:
+		if !content.ValidateBasic() {
+			result.Err = "invalid SignRound1Message2"
+			return
+		}
+		party.temp.signRound1Message2s[from] = rMsgBytes
+	default:
+		result.Err = "not SignRound1Message"
+		return
+	}
+	result.Ok = true
+	return
+}
+
+func SignRound1Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound1Message2s {
+		if len(party.temp.signRound1Message1s[j]) == 0 {
+			result.Err = fmt.Sprintf("msg1 is null: %d", j)
+			return
+		}
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg2 is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
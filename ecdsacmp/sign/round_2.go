@@ -0,0 +1,279 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/affgproof"
+	"tss_sdk/crypto/logproof"
+	"tss_sdk/crypto/paillier"
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+)
+
+// mtaOut is our side of the MtA of x·k_j with Pj: D = K_j^x·enc_j(y) for Pj, F = enc_i(y) and the proof
+type mtaOut struct {
+	D, F  *big.Int
+	proof []byte
+}
+
+func SignRound2Exec(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 2
+	i := party.PartyID().Index
+	common.Logger.Infof("[ecdsa sign] party: %d, round_2 start", i)
+
+	// Verify received K_j, G_j and enc proofs
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound1Message1s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg1 fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg1 fail, err:%s", err.Error())
+			return
+		}
+		r1msg1 := pMsg.Content().(*m.SignRound1Message1)
+		if !bytes.Equal(r1msg1.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+		party.temp.Ks[j] = r1msg1.UnmarshalK()
+		party.temp.Gs[j] = r1msg1.UnmarshalG()
+
+		pMsg, err = tss.ParseWireMsg(party.temp.signRound1Message2s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg2 fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg2 fail, err:%s", err.Error())
+			return
+		}
+		encProof, err := pMsg.Content().(*m.SignRound1Message2).UnmarshalEncProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal enc proof failed, party: %d", j)))
+			return
+		}
+		if err := encProof.Verify(ProofParameter, party.proofContext(j), party.temp.Ks[j],
+			party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i],
+		); err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify enc proof failed, party: %d", j)))
+			return
+		}
+	}
+
+	// Γ_i = γ_i * G
+	ec := party.params.EC()
+	party.temp.bigGamma = crypto.ScalarBaseMult(ec, party.temp.gamma)
+	G, err := crypto.NewECPoint(ec, ec.Params().Gx, ec.Params().Gy)
+	if err != nil {
+		common.Logger.Errorf("create base point failed")
+		result.Err = "create base point failed"
+		return
+	}
+
+	contextI := party.proofContext(i)
+	for j, Pj := range party.params.Parties().IDs() {
+		// logProof that Γ_i = γ_i * G and G_i = enc_i(γ_i): M(prove, Πlog, (sid,i), (Iε,G_i,Γ_i,g); (γ_i,ν_i))
+		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(ProofParameter, contextI, party.temp.gamma,
+			party.temp.nu, party.temp.Gs[i], party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], party.temp.bigGamma, G)
+		if err != nil {
+			common.Logger.Errorf("create log proof failed, party: %d", j)
+			result.Err = fmt.Sprintf("create log proof failed, party: %d", j)
+			return
+		}
+		logProofBytes, err := proto.Marshal(logProof)
+		if err != nil {
+			common.Logger.Errorf("marshal log proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("marshal log proof failed: %s, party: %d", err, j)
+			return
+		}
+
+		var r2msg tss.ParsedMessage
+		if j == i {
+			// our own message only carries Γ_i, there is no MtA with ourselves
+			one := big.NewInt(1)
+			r2msg = m.NewSignRound2Message(Pj, party.PartyID(), party.temp.bigGamma, one, one, one, one, []byte{0}, []byte{0}, logProofBytes)
+		} else {
+			// MtA of γ_i·k_j and w_i·k_j
+			mta, beta, err := party.mta(contextI, j, party.temp.gamma, party.temp.bigGamma)
+			if err != nil {
+				common.Logger.Errorf("mta failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("mta failed: %s, party: %d", err, j)
+				return
+			}
+			mtaHat, betaHat, err := party.mta(contextI, j, party.keys.PrivXi, party.keys.PubXj[i])
+			if err != nil {
+				common.Logger.Errorf("mta failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("mta failed: %s, party: %d", err, j)
+				return
+			}
+			party.temp.betas[j], party.temp.betaHats[j] = beta, betaHat
+			r2msg = m.NewSignRound2Message(Pj, party.PartyID(), party.temp.bigGamma,
+				mta.D, mta.F, mtaHat.D, mtaHat.F, mta.proof, mtaHat.proof, logProofBytes)
+		}
+
+		msgWireBytes, _, err := r2msg.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.signRound2Messages[j] = msgWireBytes
+		if j == i {
+			party.temp.signRound2Messages[i] = msgWireBytes
+		}
+	}
+
+	result.Ok = true
+	return result
+}
+
+// mta runs our side of the MtA of x·k_j with Pj, X = x*G. It returns the message for Pj and our share
+// β = -y of x·k_j, Pj decrypts its share α = x·k_j + y from D.
+func (p *LocalParty) mta(context []byte, j int, x *big.Int, X *crypto.ECPoint) (*mtaOut, *big.Int, error) {
+	i := p.PartyID().Index
+	pkI, pkJ := p.keys.PaillierPKs[i], p.keys.PaillierPKs[j]
+
+	// y in [0, 2^l')
+	y := common.GetRandomPositiveInt(p.params.Rand(), new(big.Int).Lsh(big.NewInt(1), ProofParameter.Lpai))
+	encY, s, err := pkJ.EncryptAndReturnRandomness(p.params.Rand(), y)
+	if err != nil {
+		return nil, nil, err
+	}
+	xK, err := pkJ.HomoMult(x, p.temp.Ks[j])
+	if err != nil {
+		return nil, nil, err
+	}
+	D, err := pkJ.HomoAdd(xK, encY)
+	if err != nil {
+		return nil, nil, err
+	}
+	F, r, err := pkI.EncryptAndReturnRandomness(p.params.Rand(), y)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// M(prove, Πaff-g, (sid,i), (Iε,Jε,D,K_j,F,X); (x,y,s,r))
+	proof, err := affgproof.NewPaillierAffineGroupMessage(ProofParameter, context, x, y, s, r,
+		pkJ.N, pkI.N, p.temp.Ks[j], D, F, p.keys.RingPedersenPKs[j], X)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofBytes, err := proto.Marshal(proof)
+	if err != nil {
+		return nil, nil, err
+	}
+	beta := common.ModInt(p.params.EC().Params().N).Sub(big.NewInt(0), y)
+	return &mtaOut{D: D, F: F, proof: proofBytes}, beta, nil
+}
+
+// alpha verifies the MtA message of Pj for x_j·k_i, X = x_j*G, and decrypts our share α = x_j·k_i + y
+func (p *LocalParty) alpha(j int, D, F *big.Int, X *crypto.ECPoint, proof *affgproof.PaillierAffineGroupMessage) (*big.Int, error) {
+	i := p.PartyID().Index
+	pkI, pkJ := p.keys.PaillierPKs[i], p.keys.PaillierPKs[j]
+	if err := proof.Verify(ProofParameter, p.proofContext(j), pkI.N, pkJ.N, p.temp.Ks[i], D, F,
+		p.keys.RingPedersenPKs[i], X); err != nil {
+		return nil, err
+	}
+	alpha, err := p.keys.PaillierSK.Decrypt(D)
+	if err != nil {
+		return nil, err
+	}
+	return signedMod(alpha, pkI, p.params.EC().Params().N), nil
+}
+
+// signedMod reads a plaintext in (-N/2, N/2] and reduces it mod q
+func signedMod(v *big.Int, pk *paillier.PublicKey, q *big.Int) *big.Int {
+	if v.Cmp(new(big.Int).Rsh(pk.N, 1)) > 0 {
+		v = new(big.Int).Sub(v, pk.N)
+	}
+	return new(big.Int).Mod(v, q)
+}
+
+// p2p MtA and log proof for party `to`
+func GetRound2Msg(key string, to int) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.signRound2Messages[to]
+	return
+}
+
+func SignRound2MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound2Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound2Message"
+		return
+	}
+	party.temp.signRound2Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound2Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound2Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,207 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/logproof"
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignRound3Exec(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 3
+	i := party.PartyID().Index
+	common.Logger.Infof("[ecdsa sign] party: %d, round_3 start", i)
+
+	ec := party.params.EC()
+	q := ec.Params().N
+	modQ := common.ModInt(q)
+	G, err := crypto.NewECPoint(ec, ec.Params().Gx, ec.Params().Gy)
+	if err != nil {
+		common.Logger.Errorf("create base point failed")
+		result.Err = "create base point failed"
+		return
+	}
+
+	// δ_i = γ_i·k_i + Σ(α_{i,j} + β_{i,j}), χ_i = w_i·k_i + Σ(α̂_{i,j} + β̂_{i,j})
+	Gamma := party.temp.bigGamma
+	delta := modQ.Mul(party.temp.gamma, party.temp.k)
+	chi := modQ.Mul(party.keys.PrivXi, party.temp.k)
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound2Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r2msg := pMsg.Content().(*m.SignRound2Message)
+
+		GammaJ, err := r2msg.UnmarshalGamma(ec)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal Gamma failed, party: %d", j)))
+			return
+		}
+		logProof, err := r2msg.UnmarshalLogProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal log proof failed, party: %d", j)))
+			return
+		}
+		if err := logProof.Verify(ProofParameter, party.proofContext(j), party.temp.Gs[j],
+			party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i], GammaJ, G,
+		); err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify log proof failed, party: %d", j)))
+			return
+		}
+
+		affgProof, affgHatProof, err := r2msg.UnmarshalAffgProofs()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal affg proof failed, party: %d", j)))
+			return
+		}
+		alpha, err := party.alpha(j, r2msg.UnmarshalD(), r2msg.UnmarshalF(), GammaJ, affgProof)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify affg proof failed: %s, party: %d", err, j)))
+			return
+		}
+		alphaHat, err := party.alpha(j, r2msg.UnmarshalDHat(), r2msg.UnmarshalFHat(), party.keys.PubXj[j], affgHatProof)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify affg hat proof failed: %s, party: %d", err, j)))
+			return
+		}
+
+		delta = modQ.Add(delta, modQ.Add(alpha, party.temp.betas[j]))
+		chi = modQ.Add(chi, modQ.Add(alphaHat, party.temp.betaHats[j]))
+		if Gamma, err = Gamma.Add(GammaJ); err != nil {
+			common.Logger.Errorf("calc Gamma failed, party: %d", j)
+			result.Err = fmt.Sprintf("calc Gamma failed, party: %d", j)
+			return
+		}
+	}
+	party.temp.Gamma, party.temp.delta, party.temp.chi = Gamma, delta, chi
+
+	// Δ_i = k_i * Γ
+	bigDelta := Gamma.ScalarMult(party.temp.k)
+	contextI := party.proofContext(i)
+	for j, Pj := range party.params.Parties().IDs() {
+		// M(prove, Πlog, (sid,i), (Iε,K_i,Δ_i,Γ); (k_i,ρ_i))
+		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(ProofParameter, contextI, party.temp.k,
+			party.temp.rho, party.temp.Ks[i], party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], bigDelta, Gamma)
+		if err != nil {
+			common.Logger.Errorf("create log proof failed, party: %d", j)
+			result.Err = fmt.Sprintf("create log proof failed, party: %d", j)
+			return
+		}
+		logProofBytes, err := proto.Marshal(logProof)
+		if err != nil {
+			common.Logger.Errorf("marshal log proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("marshal log proof failed: %s, party: %d", err, j)
+			return
+		}
+
+		r3msg := m.NewSignRound3Message(Pj, party.PartyID(), delta, bigDelta, logProofBytes)
+		msgWireBytes, _, err := r3msg.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.signRound3Messages[j] = msgWireBytes
+		if j == i {
+			party.temp.signRound3Messages[i] = msgWireBytes
+		}
+	}
+
+	result.Ok = true
+	return result
+}
+
+// p2p δ_i, Δ_i and log proof for party `to`
+func GetRound3Msg(key string, to int) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.signRound3Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.signRound3Messages[to]
+	return
+}
+
+func SignRound3MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound3Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound3Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound3Message"
+		return
+	}
+	party.temp.signRound3Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound3Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound3Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,160 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignRound4Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 4
+	i := party.PartyID().Index
+	common.Logger.Infof("[ecdsa sign] party: %d, round_4 start", i)
+
+	ec := party.params.EC()
+	q := ec.Params().N
+	modQ := common.ModInt(q)
+
+	// δ = Σδ_j, and δ·G = ΣΔ_j
+	delta := big.NewInt(0)
+	var sumDelta *crypto.ECPoint
+	for j := range party.params.Parties().IDs() {
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound3Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r3msg := pMsg.Content().(*m.SignRound3Message)
+
+		bigDeltaJ, err := r3msg.UnmarshalBigDelta(ec)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal Delta failed, party: %d", j)))
+			return
+		}
+		if j != i {
+			logProof, err := r3msg.UnmarshalLogProof()
+			if err != nil {
+				result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal log proof failed, party: %d", j)))
+				return
+			}
+			if err := logProof.Verify(ProofParameter, party.proofContext(j), party.temp.Ks[j],
+				party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i], bigDeltaJ, party.temp.Gamma,
+			); err != nil {
+				result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify log proof failed, party: %d", j)))
+				return
+			}
+		}
+
+		delta = modQ.Add(delta, r3msg.UnmarshalDelta())
+		if sumDelta == nil {
+			sumDelta = bigDeltaJ
+		} else if sumDelta, err = sumDelta.Add(bigDeltaJ); err != nil {
+			common.Logger.Errorf("calc Delta failed, party: %d", j)
+			result.Err = fmt.Sprintf("calc Delta failed, party: %d", j)
+			return
+		}
+	}
+	if delta.Sign() == 0 || !crypto.ScalarBaseMult(ec, delta).Equals(sumDelta) {
+		common.Logger.Errorf("verify delta failed")
+		result.Err = "verify delta failed"
+		return
+	}
+
+	// R = δ^-1 * Γ, r = R.x mod q
+	R := party.temp.Gamma.ScalarMult(new(big.Int).ModInverse(delta, q))
+	r := new(big.Int).Mod(R.X(), q)
+	if r.Sign() == 0 {
+		common.Logger.Errorf("r is zero")
+		result.Err = "r is zero"
+		return
+	}
+	party.temp.R, party.temp.r = R, r
+
+	// σ_i = k_i·m + r·χ_i
+	party.temp.sigma = modQ.Add(modQ.Mul(party.temp.k, party.temp.m), modQ.Mul(r, party.temp.chi))
+
+	// broadcast σ_i
+	r4msg := m.NewSignRound4Message(party.PartyID(), party.temp.sigma)
+	msgWireBytes, _, err := r4msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound4Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound4MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound4Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound4Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound4Message"
+		return
+	}
+	party.temp.signRound4Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound4Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound4Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,87 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	m "tss_sdk/ecdsacmp/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignFinalExec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 5
+	i := party.PartyID().Index
+	common.Logger.Infof("[ecdsa sign] party: %d, round_final start", i)
+
+	q := party.params.EC().Params().N
+	modQ := common.ModInt(q)
+
+	// s = Σσ_j
+	s := party.temp.sigma
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound4Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		s = modQ.Add(s, pMsg.Content().(*m.SignRound4Message).UnmarshalSigma())
+	}
+
+	// recovery id: bit 0 is the parity of R.y, bit 1 is set when R.x >= q;
+	// s is normalized to the lower half of [1, q), which negates R
+	recovery := byte(party.temp.R.Y().Bit(0))
+	if party.temp.R.X().Cmp(q) >= 0 {
+		recovery |= 2
+	}
+	if s.Cmp(new(big.Int).Rsh(q, 1)) > 0 {
+		s = new(big.Int).Sub(q, s)
+		recovery ^= 1
+	}
+
+	pk := party.temp.walletPub.ToECDSAPubKey()
+	if !ecdsa.Verify(pk, party.temp.mBytes, party.temp.r, s) {
+		common.Logger.Errorf("verify failed")
+		result.Err = "verify failed"
+		return
+	}
+
+	// save the signature for final output
+	rBytes, sBytes := make([]byte, 32), make([]byte, 32)
+	party.temp.r.FillBytes(rBytes)
+	s.FillBytes(sBytes)
+	party.data.Signature = append(rBytes, sBytes...)
+	party.data.SignatureRecovery = []byte{recovery}
+	party.data.R = party.temp.r.Bytes()
+	party.data.S = s.Bytes()
+	party.data.M = party.temp.mBytes
+
+	saveBytes, err := json.Marshal(party.data)
+	if err != nil {
+		common.Logger.Errorf("round_final save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_final save err: %s", err.Error())
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
@@ -0,0 +1,38 @@
+package sign
+
+import (
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/tss"
+)
+
+const (
+	TaskName = "ecdsa-cmp-sign"
+)
+
+type SignExecResult struct {
+	Ok           bool   `json:"ok"`
+	Err          string `json:"error"`
+	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the signers to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+type SignResult struct {
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the signers to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *SignExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *SignResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+var ProofParameter = crypto.NewProofConfig(tss.S256().Params().N)
@@ -0,0 +1,157 @@
+package sign_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/ecdsacmp/sign"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	saves := test.Secp256k1KeygenFixtures(t, 3, 2)
+	data := test.SaveData(t, saves[0])
+	msg := randomHash(t)
+	checkSignature(t, data.PubKey(), msg, runSign(t, saves, []int{0, 2}, 2, msg, ""))
+
+	tweak, err := data.ChildTweak("0/1/5")
+	require.NoError(t, err)
+	child, err := data.PubKey().Add(crypto.ScalarBaseMult(tss.S256(), tweak))
+	require.NoError(t, err)
+	checkSignature(t, child, msg, runSign(t, saves, []int{0, 1, 2}, 2, msg, "0/1/5"))
+}
+
+func TestSignRefreshed(t *testing.T) {
+	saves := test.Secp256k1KeygenFixtures(t, 3, 2)
+	pub := test.SaveData(t, saves[0]).PubKey()
+	newSaves := test.Refresh(t, saves)
+	require.Equal(t, tss.S256(), test.SaveData(t, newSaves[0]).PubKey().Curve())
+	msg := randomHash(t)
+	checkSignature(t, pub, msg, runSign(t, newSaves, []int{1, 2}, 2, msg, ""))
+}
+
+func TestSignReshared(t *testing.T) {
+	saves := test.Secp256k1KeygenFixtures(t, 3, 2)
+	pub := test.SaveData(t, saves[0]).PubKey()
+	newSaves := test.Reshare(t, saves, []int{0, 2}, []string{"9001", "9002", "9003"}, 2)
+	msg := randomHash(t)
+	checkSignature(t, pub, msg, runSign(t, newSaves, []int{0, 1}, 2, msg, ""))
+}
+
+func runSign(t *testing.T, saves [][]byte, signers []int, threshold int, msg []byte, path string) *common.SignatureData {
+	m := len(signers)
+	pIDs := make([]string, m)
+	keys := make([]string, m)
+	for k, s := range signers {
+		pIDs[k] = test.SaveData(t, saves[s]).ShareID.String()
+	}
+	manifest := test.Manifest(t, sign.TaskName, pIDs, nil, threshold)
+	for k, s := range signers {
+		keys[k] = fmt.Sprintf("ecdsa-%d", k)
+		r := sign.NewLocalParty(keys[k], k, m, threshold, pIDs, hex.EncodeToString(msg), test.B64(saves[s]), path)
+		require.True(t, r.Ok, r.Err)
+		defer sign.RemoveSignParty(keys[k])
+		r = sign.SetSessionManifest(keys[k], manifest, test.SignManifest(manifest), test.CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+	}
+	deliver := func(from, to int, bz []byte, accept func(string, int, string) sign.SignResult) {
+		r := accept(keys[to], from, test.B64(bz))
+		require.True(t, r.Ok, r.Err)
+	}
+	finish := func(finish func(string) sign.SignResult) {
+		for i := range keys {
+			r := finish(keys[i])
+			require.True(t, r.Ok, r.Err)
+		}
+	}
+
+	for i := range keys {
+		r := sign.SignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignRound1MsgAccept)
+				deliver(i, j, sign.GetRound1Msg2(keys[i], j).MsgWireBytes, sign.SignRound1MsgAccept)
+			}
+		}
+	}
+	finish(sign.SignRound1Finish)
+	for i := range keys {
+		r := sign.SignEchoExec(keys[i], 1)
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignEchoAccept)
+			}
+		}
+	}
+	finish(func(key string) sign.SignResult { return sign.SignEchoFinish(key, 1) })
+	p2p := func(exec func(string) sign.SignResult, get func(string, int) sign.SignExecResult, accept func(string, int, string) sign.SignResult, fin func(string) sign.SignResult) {
+		finish(exec)
+		for i := range keys {
+			for j := range keys {
+				if i != j {
+					deliver(i, j, get(keys[i], j).MsgWireBytes, accept)
+				}
+			}
+		}
+		finish(fin)
+	}
+	p2p(sign.SignRound2Exec, sign.GetRound2Msg, sign.SignRound2MsgAccept, sign.SignRound2Finish)
+	p2p(sign.SignRound3Exec, sign.GetRound3Msg, sign.SignRound3MsgAccept, sign.SignRound3Finish)
+	for i := range keys {
+		r := sign.SignRound4Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignRound4MsgAccept)
+			}
+		}
+	}
+	finish(sign.SignRound4Finish)
+
+	var sig *common.SignatureData
+	for i := range keys {
+		r := sign.SignFinalExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		data := &common.SignatureData{}
+		require.NoError(t, json.Unmarshal(r.MsgWireBytes, data))
+		if sig != nil {
+			require.Equal(t, sig.Signature, data.Signature)
+		}
+		sig = data
+	}
+	return sig
+}
+
+// checkSignature verifies the low s signature and that its recovery id recovers pub
+func checkSignature(t *testing.T, pub *crypto.ECPoint, msg []byte, sig *common.SignatureData) {
+	require.Len(t, sig.Signature, 64)
+	r := new(big.Int).SetBytes(sig.Signature[:32])
+	s := new(big.Int).SetBytes(sig.Signature[32:])
+	require.True(t, ecdsa.Verify(pub.ToECDSAPubKey(), msg, r, s))
+	require.True(t, s.Cmp(new(big.Int).Rsh(btcec.S256().N, 1)) <= 0)
+	compact := append([]byte{27 + sig.SignatureRecovery[0]}, sig.Signature...)
+	recovered, _, err := btcec.RecoverCompact(btcec.S256(), compact, msg)
+	require.NoError(t, err)
+	require.Equal(t, 0, recovered.X.Cmp(pub.X()))
+	require.Equal(t, 0, recovered.Y.Cmp(pub.Y()))
+}
+
+func randomHash(t *testing.T) []byte {
+	msg := make([]byte, 32)
+	_, err := rand.Read(msg)
+	require.NoError(t, err)
+	return msg
+}
@@ -8,19 +8,26 @@ import "C"
 import (
 	"encoding/json"
 	"strings"
+	"tss_sdk/eddsacmp/export"
 	"tss_sdk/eddsacmp/keygen"
 	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/eddsacmp/refresh"
+	"tss_sdk/eddsacmp/resharing"
 )
 
 type MpcExecResult struct {
 	Ok           bool   `json:"ok"`
 	Err          string `json:"error"`
 	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
 }
 
 type MpcResult struct {
-	Ok  bool   `json:"ok"`
-	Err string `json:"error"`
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
 }
 
 func (result MpcExecResult) ToJson() string {
@@ -37,11 +44,12 @@ func NewKeygenLocalParty(
 	key string,
 	partyIndex int,
 	partyCount int,
+	threshold int, // number of parties required to sign, partyCount for an n-of-n key
 	pIDs string,
 	rootPrivKey string, // hex string
 ) *MpcResult {
 	ids := strings.Split(pIDs, ",")
-	res := keygen.NewLocalParty(key, partyIndex, partyCount, ids, rootPrivKey)
+	res := keygen.NewLocalParty(key, partyIndex, partyCount, threshold, ids, rootPrivKey)
 	return resFromKeygen(res)
 }
 
@@ -49,7 +57,18 @@ func RemoveKeygenParty(key string) bool {
 	return keygen.RemoveParty(key)
 }
 
+// manifest: tss.SessionManifest, base64 string; every party of the session must set the same manifest
+// before round 1, the protocol is "eddsa-cmp-keygen". signature: the Ed25519 signature of the session
+// coordinator over the manifest JSON, coordinatorPub: the coordinator public key the app trusts, hex strings.
+// Every Set*SessionManifest refuses a manifest whose signature does not verify.
+func SetKeygenSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := keygen.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromKeygen(res)
+}
+
 // chainCodes: hex string array
+//
+// Deprecated: keygen generates the chain codes jointly, codes saved before round 1 are replaced.
 func SaveChainCodes(key string, chainCodes string) *MpcResult {
 	res := keygen.SaveChainCodes(key, chainCodes)
 	return resFromKeygen(res)
@@ -75,6 +94,12 @@ func KeygenRound2Exec(key string) *MpcExecResult {
 	return execResFromKeygen(res)
 }
 
+// p2p vss share for party `to`, only for threshold keys; the share is sealed to the key `to` sent in round 1
+func GetKeygenRound2Msg(key string, to int) *MpcExecResult {
+	res := keygen.GetRound2Msg2(key, to)
+	return execResFromKeygen(res)
+}
+
 func KeygenRound2Accept(key string, from int, msgWireBytes string) *MpcResult {
 	res := keygen.KeygenRound2Accept(key, from, msgWireBytes)
 	return resFromKeygen(res)
@@ -100,26 +125,95 @@ func KeygenRound3Finish(key string) *MpcResult {
 	return resFromKeygen(res)
 }
 
-// chainCodes: hex string array
+// the output keyData carries the chain codes generated by the parties
 func KeygenRound4Exec(key string) *MpcExecResult {
 	res := keygen.KeygenRound4Exec(key)
 	return execResFromKeygen(res)
 }
 
+// optional echo of broadcast round 1, 2 or 3, run between KeygenRoundNFinish and KeygenRoundN+1Exec
+// when the transport is not a reliable broadcast; every party must run it for the same rounds
+func KeygenEchoExec(key string, round int) *MpcExecResult {
+	res := keygen.KeygenEchoExec(key, round)
+	return execResFromKeygen(res)
+}
+
+func KeygenEchoAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := keygen.KeygenEchoAccept(key, from, msgWireBytes)
+	return resFromKeygen(res)
+}
+
+func KeygenEchoFinish(key string, round int) *MpcResult {
+	res := keygen.KeygenEchoFinish(key, round)
+	return resFromKeygen(res)
+}
+
+// ---------------------watch-only------------------------
+
+// keyData: keygen.LocalPartySaveData, base64 string; outputs the keygen data as JSON with one group chain code.
+// Child keys of a secp256k1 key then match a plain BIP 32 derivation from the group key; Ed25519 keys have no
+// BIP 32 wallets to match, use SetDerivationScheme "bip32-ed25519" on the converted data for Cardano wallets.
+// Every party converts its own data, before any address is derived: the child keys of every path change.
+func ConvertToSingleChainCode(keyData string) *MpcExecResult {
+	res := keygen.SingleChainCodeKey(keyData)
+	return execResFromKeygen(res)
+}
+
+// keyData: keygen.LocalPartySaveData, base64 string; scheme: "" for the default BIP 32 style derivation or
+// "bip32-ed25519" for BIP32-Ed25519 (Cardano), which needs one chain code, see ConvertToSingleChainCode.
+// The group key and its chain code are then the account extended public key of a Cardano wallet, and
+// paths such as "0/0" (payment) and "2/0" (stake) derive the same keys as a single-key wallet would.
+// Outputs the keygen data as JSON; every party converts its own data before any address is derived.
+func SetDerivationScheme(keyData string, scheme string) *MpcExecResult {
+	res := keygen.SetDerivationScheme(keyData, scheme)
+	return execResFromKeygen(res)
+}
+
+// keyData: keygen.LocalPartySaveData, base64 string; outputs the group extended key, a base58 string
+// with the group key, the public shares and the chain codes, but no share
+func GetWatchOnlyKey(keyData string) *MpcExecResult {
+	res := keygen.WatchOnlyKey(keyData)
+	return execResFromKeygen(res)
+}
+
+// groupKey: the output of GetWatchOnlyKey; walletPath: non-hardened path, "" for the group key.
+// Outputs the child public key, 32 bytes for Ed25519 and 33 bytes compressed for secp256k1 keys.
+func DeriveWatchOnlyPubKey(groupKey string, walletPath string) *MpcExecResult {
+	res := keygen.DeriveWatchOnlyPubKey(groupKey, walletPath)
+	return execResFromKeygen(res)
+}
+
 // ---------------------onsign------------------------
 
 func NewSignLocalParty(
 	key string,
 	partyIndex int,
 	partyCount int,
+	threshold int, // the keygen threshold
 	pIDs string,
-	msg string, // hex string
+	msg string, // hex string; a batch of messages is comma separated and signed in the same rounds
 	keyData string, // keygen.LocalPartySaveData, base64 string
-	refreshData string, // refresh.LocalPartySaveData, base64 string
-	walletPath string,
+	refreshData string, // refresh payload, hex string; empty to use the aux info saved by keygen
+	walletPath string, // non-hardened path such as "m/44/501/0/0", "" signs with the root key; a batch can give one path per message, comma separated, in message order
 ) *MpcResult {
 	ids := strings.Split(pIDs, ",")
-	res := onsign.NewLocalParty(key, partyIndex, partyCount, ids, msg, keyData, refreshData, walletPath)
+	res := onsign.NewLocalParty(key, partyIndex, partyCount, threshold, ids, msg, keyData, refreshData, walletPath)
+	return resFromOnsign(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "eddsa-cmp-onsign",
+// the parties are the signers and the threshold is the keygen threshold
+func SetSignSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := onsign.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromOnsign(res)
+}
+
+// mode: "Ed25519", "Ed25519ctx" or "Ed25519ph" (RFC 8032), pure Ed25519 when not set
+// context: hex string, at most 255 bytes; empty for Ed25519, required for Ed25519ctx
+// Set before round 1, or before OnSignOnlineExec on a party made by NewOnlineSignLocalParty: the signing
+// commitment covers the mode and context, so it cannot change afterwards.
+func SetSignMode(key string, mode string, context string) *MpcResult {
+	res := onsign.SetSignMode(key, mode, context)
 	return resFromOnsign(res)
 }
 
@@ -147,6 +241,7 @@ func OnSignRound1Finish(key string) *MpcResult {
 	return resFromOnsign(res)
 }
 
+// Culprits names a signer whose message, wallet path or child public key differs from ours
 func OnSignRound2Exec(key string) *MpcResult {
 	res := onsign.OnsignRound2Exec(key)
 	return resFromOnsign(res)
@@ -177,28 +272,402 @@ func OnSignRound3MsgAccept(key string, from int, msgWireBytes string) *MpcResult
 	return resFromOnsign(res)
 }
 
+// Culprits names the signers whose signature share does not match their R_j and public share
 func OnSignRound3Finish(key string) *MpcResult {
 	res := onsign.OnSignRound3Finish(key)
 	return resFromOnsign(res)
 }
 
+// the output is common.SignatureData, a JSON list of them in message order for a batch
 func OnSignFinalExec(key string) *MpcExecResult {
 	res := onsign.OnsignFinalExec(key)
 	return execResFromOnsign(res)
 }
 
+// optional echo of broadcast round 1 or 3, run between OnSignRoundNFinish and the next exec
+// when the transport is not a reliable broadcast; every signer must run it for the same rounds
+func OnSignEchoExec(key string, round int) *MpcExecResult {
+	res := onsign.OnSignEchoExec(key, round)
+	return execResFromOnsign(res)
+}
+
+func OnSignEchoAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := onsign.OnSignEchoAccept(key, from, msgWireBytes)
+	return resFromOnsign(res)
+}
+
+func OnSignEchoFinish(key string, round int) *MpcResult {
+	res := onsign.OnSignEchoFinish(key, round)
+	return resFromOnsign(res)
+}
+
+// ---------------------presign------------------------
+
+// rounds 1 and 2 of onsign ahead of the message: run OnSignRound1Exec .. OnSignRound2Finish
+// with key, then PresignFinish; the echo of round 1 is run as for onsign
+func NewPresignLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // the keygen threshold
+	pIDs string,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+	refreshData string, // refresh payload, hex string; empty to use the aux info saved by keygen
+	walletPath string,
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := onsign.NewPresignLocalParty(key, partyIndex, partyCount, threshold, ids, keyData, refreshData, walletPath)
+	return resFromOnsign(res)
+}
+
+// keeps the presignature under presignKey, in memory only, and removes the sign party key
+func PresignFinish(key string, presignKey string) *MpcResult {
+	res := onsign.PresignFinish(key, presignKey)
+	return resFromOnsign(res)
+}
+
+// takes the presignature out of memory, it can be used once only; then run SetSignMode if needed,
+// OnSignOnlineExec .. OnSignOnlineFinish and OnSignRound3Exec .. OnSignFinalExec with key
+func NewOnlineSignLocalParty(key string, presignKey string, msg string) *MpcResult {
+	res := onsign.NewOnlineLocalParty(key, presignKey, msg)
+	return resFromOnsign(res)
+}
+
+// broadcasts the commitment to the message, wallet path, public key and sign mode
+func OnSignOnlineExec(key string) *MpcExecResult {
+	res := onsign.OnsignOnlineExec(key)
+	return execResFromOnsign(res)
+}
+
+// the culprit is the signer whose commitment differs, round 3 must not run then
+func OnSignOnlineMsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := onsign.OnSignOnlineMsgAccept(key, from, msgWireBytes)
+	return resFromOnsign(res)
+}
+
+func OnSignOnlineFinish(key string) *MpcResult {
+	res := onsign.OnSignOnlineFinish(key)
+	return resFromOnsign(res)
+}
+
+func RemovePresignature(presignKey string) bool {
+	return onsign.RemovePresignature(presignKey)
+}
+
+// ---------------------refresh------------------------
+
+// all parties of the key take part; the output replaces keyData and the refresh payload.
+// The curve is that of keyData, so ecdsa keys are refreshed here as well
+func NewRefreshLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	pIDs string,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := refresh.NewLocalParty(key, partyIndex, partyCount, ids, keyData)
+	return resFromRefresh(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "eddsa-cmp-refresh"
+func SetRefreshSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := refresh.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromRefresh(res)
+}
+
+func RemoveRefreshParty(key string) bool {
+	return refresh.RemoveParty(key)
+}
+
+func RefreshRound1Exec(key string) *MpcExecResult {
+	res := refresh.RefreshRound1Exec(key)
+	return execResFromRefresh(res)
+}
+
+func RefreshRound1Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := refresh.RefreshRound1Accept(key, from, msgWireBytes)
+	return resFromRefresh(res)
+}
+
+func RefreshRound1Finish(key string) *MpcResult {
+	res := refresh.RefreshRound1Finish(key)
+	return resFromRefresh(res)
+}
+
+func RefreshRound2Exec(key string) *MpcExecResult {
+	res := refresh.RefreshRound2Exec(key)
+	return execResFromRefresh(res)
+}
+
+// p2p delta for party `to`; the delta is sealed to the key `to` sent in round 1
+func GetRefreshRound2Msg(key string, to int) *MpcExecResult {
+	res := refresh.GetRound2Msg2(key, to)
+	return execResFromRefresh(res)
+}
+
+func RefreshRound2Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := refresh.RefreshRound2Accept(key, from, msgWireBytes)
+	return resFromRefresh(res)
+}
+
+func RefreshRound2Finish(key string) *MpcResult {
+	res := refresh.RefreshRound2Finish(key)
+	return resFromRefresh(res)
+}
+
+func RefreshRound3Exec(key string) *MpcExecResult {
+	res := refresh.RefreshRound3Exec(key)
+	return execResFromRefresh(res)
+}
+
+func RefreshRound3Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := refresh.RefreshRound3Accept(key, from, msgWireBytes)
+	return resFromRefresh(res)
+}
+
+func RefreshRound3Finish(key string) *MpcResult {
+	res := refresh.RefreshRound3Finish(key)
+	return resFromRefresh(res)
+}
+
+func RefreshRound4Exec(key string) *MpcExecResult {
+	res := refresh.RefreshRound4Exec(key)
+	return execResFromRefresh(res)
+}
+
+// ---------------------resharing------------------------
+
+// the new committee first broadcasts its enc keys to the old committee, which accepts them and runs
+// round 1 only; the new committee accepts round 1 and runs rounds 2 and 3, whose output is the new keyData. Old parties must delete their keyData once the new committee is done.
+func NewResharingOldParty(
+	key string,
+	partyIndex int, // index in oldPIDs
+	oldPIDs string,
+	newPIDs string,
+	newThreshold int,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+) *MpcResult {
+	res := resharing.NewOldCommitteeParty(key, partyIndex, strings.Split(oldPIDs, ","), strings.Split(newPIDs, ","), newThreshold, keyData)
+	return resFromResharing(res)
+}
+
+// groupKey: the output of GetWatchOnlyKey for the key being reshared, from a source the new party trusts;
+// round 3 fails unless the new keyData has its group key and chain codes
+func NewResharingNewParty(
+	key string,
+	partyIndex int, // index in newPIDs
+	oldPIDs string,
+	newPIDs string,
+	newThreshold int,
+	groupKey string,
+) *MpcResult {
+	res := resharing.NewNewCommitteeParty(key, partyIndex, strings.Split(oldPIDs, ","), strings.Split(newPIDs, ","), newThreshold, groupKey)
+	return resFromResharing(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "eddsa-cmp-resharing", the parties
+// are the old committee, the new parties the new committee and the threshold the new threshold
+func SetResharingSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := resharing.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromResharing(res)
+}
+
+// generates the Paillier key of a new committee party, which takes minutes, so it can be done ahead of
+// the session; set each result to one new party only
+func GenerateResharingAuxInfo() *MpcExecResult {
+	res := resharing.GenerateAuxInfo()
+	return execResFromResharing(res)
+}
+
+// auxInfo: the data of GenerateResharingAuxInfo, base64 string; set it before round 2
+func SetResharingAuxInfo(key string, auxInfo string) *MpcResult {
+	res := resharing.SetAuxInfo(key, auxInfo)
+	return resFromResharing(res)
+}
+
+func RemoveResharingParty(key string) bool {
+	return resharing.RemoveParty(key)
+}
+
+// run by the new committee before round 1, send the message to the old committee
+func ResharingEncKeyExec(key string) *MpcExecResult {
+	res := resharing.ResharingEncKeyExec(key)
+	return execResFromResharing(res)
+}
+
+// from is the index of the sender in the new committee
+func ResharingEncKeyAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := resharing.ResharingEncKeyAccept(key, from, msgWireBytes)
+	return resFromResharing(res)
+}
+
+func ResharingEncKeyFinish(key string) *MpcResult {
+	res := resharing.ResharingEncKeyFinish(key)
+	return resFromResharing(res)
+}
+
+// Culprits are indexes in the new committee, as are those of ResharingEncKeyAccept
+func ResharingRound1Exec(key string) *MpcExecResult {
+	res := resharing.ResharingRound1Exec(key)
+	return execResFromResharing(res)
+}
+
+// p2p share for new party `to`, sealed to the key it sent in ResharingEncKeyExec
+func GetResharingRound1Msg(key string, to int) *MpcExecResult {
+	res := resharing.GetRound1Msg2(key, to)
+	return execResFromResharing(res)
+}
+
+// from is the index of the sender in the old committee
+func ResharingRound1Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := resharing.ResharingRound1Accept(key, from, msgWireBytes)
+	return resFromResharing(res)
+}
+
+func ResharingRound1Finish(key string) *MpcResult {
+	res := resharing.ResharingRound1Finish(key)
+	return resFromResharing(res)
+}
+
+// Culprits are indexes in the old committee, as are those of ResharingRound1Accept
+func ResharingRound2Exec(key string) *MpcExecResult {
+	res := resharing.ResharingRound2Exec(key)
+	return execResFromResharing(res)
+}
+
+// from is the index of the sender in the new committee
+func ResharingRound2Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := resharing.ResharingRound2Accept(key, from, msgWireBytes)
+	return resFromResharing(res)
+}
+
+func ResharingRound2Finish(key string) *MpcResult {
+	res := resharing.ResharingRound2Finish(key)
+	return resFromResharing(res)
+}
+
+// Culprits are indexes in the new committee
+func ResharingRound3Exec(key string) *MpcExecResult {
+	res := resharing.ResharingRound3Exec(key)
+	return execResFromResharing(res)
+}
+
+// ---------------------import------------------------
+
+// key import runs the resharing rounds, enc key step included, with the importer as the whole old
+// committee; the manifest protocol is "eddsa-cmp-import" and its parties are [importerID]. The importer
+// must delete the imported key once the new committee is done.
+func NewImporterParty(
+	key string,
+	importerID string,
+	newPIDs string, // "," separated
+	newThreshold int,
+	privKey string, // hex string
+	keyFormat string, // "seed": RFC 8032 private key; "scalar": little-endian scalar or expanded key
+	pubKey string, // hex string, the Ed25519 public key of the imported wallet
+	chainCodes string, // optional, hex string array
+) *MpcResult {
+	res := resharing.NewImporterParty(key, importerID, strings.Split(newPIDs, ","), newThreshold, privKey, keyFormat, pubKey, chainCodes)
+	return resFromResharing(res)
+}
+
+// new committee party of a key import, it fails round 3 unless the imported key has public key pubKey
+func NewImportParty(
+	key string,
+	partyIndex int,
+	importerID string,
+	newPIDs string, // "," separated
+	newThreshold int,
+	pubKey string, // hex string
+) *MpcResult {
+	res := resharing.NewImportParty(key, partyIndex, importerID, strings.Split(newPIDs, ","), newThreshold, pubKey)
+	return resFromResharing(res)
+}
+
+// ---------------------export------------------------
+
+// key export for disaster recovery: every party of the key runs round 1 and sends its message to the
+// recipient only; the recipient accepts them and its final exec outputs the private key as JSON
+// {"pubkey", "expanded_key"}. The manifest protocol is "eddsa-cmp-export".
+
+// X25519 key pair of the recipient, JSON {"private", "public"}, hex strings
+func GenerateExportRecipientKey() *MpcExecResult {
+	res := export.GenerateRecipientKey()
+	return execResFromExport(res)
+}
+
+func NewExportLocalParty(
+	key string,
+	partyIndex int,
+	pIDs string, // "," separated, every party of the key
+	keyData string,
+	recipientPub string, // hex string
+	walletPath string, // "" exports the root key
+) *MpcResult {
+	res := export.NewLocalParty(key, partyIndex, strings.Split(pIDs, ","), keyData, recipientPub, walletPath)
+	return resFromExport(res)
+}
+
+// pubKey: hex string, the wallet public key at walletPath; the export fails unless the key matches it
+func NewExportRecipientParty(
+	key string,
+	pIDs string, // "," separated
+	threshold int,
+	recipientPriv string, // hex string
+	walletPath string,
+	pubKey string,
+) *MpcResult {
+	res := export.NewRecipientParty(key, strings.Split(pIDs, ","), threshold, recipientPriv, walletPath, pubKey)
+	return resFromExport(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the threshold is the keygen threshold
+func SetExportSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := export.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromExport(res)
+}
+
+func RemoveExportParty(key string) bool {
+	return export.RemoveParty(key)
+}
+
+func ExportRound1Exec(key string) *MpcExecResult {
+	res := export.ExportRound1Exec(key)
+	return execResFromExport(res)
+}
+
+func ExportRound1Accept(key string, from int, msgWireBytes string) *MpcResult {
+	res := export.ExportRound1Accept(key, from, msgWireBytes)
+	return resFromExport(res)
+}
+
+func ExportRound1Finish(key string) *MpcResult {
+	res := export.ExportRound1Finish(key)
+	return resFromExport(res)
+}
+
+func ExportFinalExec(key string) *MpcExecResult {
+	res := export.ExportFinalExec(key)
+	return execResFromExport(res)
+}
+
 func execResFromKeygen(res keygen.KeygenExecResult) *MpcExecResult {
 	return &MpcExecResult{
 		Ok:           res.Ok,
 		Err:          res.Err,
 		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
 	}
 }
 
 func resFromKeygen(res keygen.KeygenResult) *MpcResult {
 	return &MpcResult{
-		Ok:  res.Ok,
-		Err: res.Err,
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
 	}
 }
 
@@ -207,12 +676,73 @@ func execResFromOnsign(res onsign.OnsignExecResult) *MpcExecResult {
 		Ok:           res.Ok,
 		Err:          res.Err,
 		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
 	}
 }
 
 func resFromOnsign(res onsign.OnsignResult) *MpcResult {
 	return &MpcResult{
-		Ok:  res.Ok,
-		Err: res.Err,
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
+
+func execResFromRefresh(res refresh.RefreshExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromRefresh(res refresh.RefreshResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
+
+func execResFromResharing(res resharing.ResharingExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromResharing(res resharing.ResharingResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
+
+func execResFromExport(res export.ExportExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromExport(res export.ExportResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
 	}
 }
@@ -0,0 +1,202 @@
+package export_test
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/alice/utils"
+	"tss_sdk/eddsacmp/export"
+	"tss_sdk/eddsacmp/resharing"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestExport(t *testing.T) {
+	// the fixture keys have a zero root secret, only their child keys are usable
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.KeygenFixtures(t, c[0], c[1])
+		pub := test.ChildPub(t, saves[0], walletPath)
+		errStr, exported := runExport(t, saves, walletPath, pub)
+		require.Empty(t, errStr)
+		require.Equal(t, hex.EncodeToString(pub), exported.PubKey)
+		require.True(t, ed25519.Verify(pub, []byte("hello"), signExpanded(t, exported.ExpandedKey, []byte("hello"))))
+		other, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		errStr, _ = runExport(t, saves, walletPath, other)
+		require.NotEmpty(t, errStr)
+	}
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	saves, errStr := test.Import(t, hex.EncodeToString(priv.Seed()), resharing.ImportSeed, hex.EncodeToString(pub), hex.EncodeToString(pub), []string{"11", "12", "13"}, 2)
+	require.Empty(t, errStr)
+	errStr, exported := runExport(t, saves, "", pub)
+	require.Empty(t, errStr)
+	require.True(t, ed25519.Verify(pub, []byte("hello"), signExpanded(t, exported.ExpandedKey, []byte("hello"))))
+	// the imported key exports the scalar of the original seed
+	h := sha512.Sum512(priv.Seed())
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	a := new(big.Int).SetBytes(utils.ReverseByte(h[:32]))
+	expanded, err := hex.DecodeString(exported.ExpandedKey)
+	require.NoError(t, err)
+	require.Equal(t, 0, new(big.Int).Mod(a, tss.Edwards().Params().N).Cmp(new(big.Int).SetBytes(utils.ReverseByte(expanded[:32]))))
+}
+
+func TestExportSingleChainCode(t *testing.T) {
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1]))
+		pub := test.ChildPub(t, saves[0], walletPath)
+		errStr, exported := runExport(t, saves, walletPath, pub)
+		require.Empty(t, errStr)
+		require.True(t, ed25519.Verify(pub, []byte("hello"), signExpanded(t, exported.ExpandedKey, []byte("hello"))))
+	}
+}
+
+func TestExportBip32Ed25519(t *testing.T) {
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.Bip32Ed25519(t, test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1])))
+		pub := test.ChildPub(t, saves[0], walletPath)
+		errStr, exported := runExport(t, saves, walletPath, pub)
+		require.Empty(t, errStr)
+		require.True(t, ed25519.Verify(pub, []byte("hello"), signExpanded(t, exported.ExpandedKey, []byte("hello"))))
+	}
+}
+
+func TestExportPathForms(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	r := export.NewLocalParty("path", 0, pIDs, test.B64(saves[0]), hex.EncodeToString(make([]byte, 32)), "44'/501")
+	require.False(t, r.Ok)
+	require.Contains(t, r.Err, "wallet path err")
+
+	// the recipient and the share holders spell the path differently, but it is the same session
+	pub := test.ChildPub(t, saves[0], walletPath)
+	recipientKey := export.GenerateRecipientKey()
+	require.True(t, recipientKey.Ok, recipientKey.Err)
+	keyPair := map[string]string{}
+	require.NoError(t, json.Unmarshal(recipientKey.MsgWireBytes, &keyPair))
+	manifest := test.Manifest(t, export.TaskName, pIDs, nil, 2)
+	require.True(t, export.NewRecipientParty("recipient", pIDs, 2, keyPair["private"], "m/"+walletPath, hex.EncodeToString(pub)).Ok)
+	defer export.RemoveParty("recipient")
+	require.True(t, export.SetSessionManifest("recipient", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	for i := range saves {
+		key := fmt.Sprintf("path-%d", i)
+		require.True(t, export.NewLocalParty(key, i, pIDs, test.B64(saves[i]), keyPair["public"], walletPath).Ok)
+		defer export.RemoveParty(key)
+		require.True(t, export.SetSessionManifest(key, manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+		e := export.ExportRound1Exec(key)
+		require.True(t, e.Ok, e.Err)
+		require.True(t, export.ExportRound1Accept("recipient", i, test.B64(e.MsgWireBytes)).Ok)
+	}
+	f := export.ExportFinalExec("recipient")
+	require.True(t, f.Ok, f.Err)
+}
+
+func TestExportCulprit(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	recipientKey := export.GenerateRecipientKey()
+	require.True(t, recipientKey.Ok, recipientKey.Err)
+	keyPair := map[string]string{}
+	require.NoError(t, json.Unmarshal(recipientKey.MsgWireBytes, &keyPair))
+	pIDs := make([]string, len(saves))
+	for i := range saves {
+		pIDs[i] = test.SaveData(t, saves[i]).ShareID.String()
+	}
+	manifest := test.Manifest(t, export.TaskName, pIDs, nil, 2)
+	require.True(t, export.NewRecipientParty("recipient", pIDs, 2, keyPair["private"], walletPath, hex.EncodeToString(pub)).Ok)
+	defer export.RemoveParty("recipient")
+	require.True(t, export.SetSessionManifest("recipient", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+
+	bad := export.ExportRound1Accept("recipient", 2, test.B64([]byte("garbage")))
+	require.False(t, bad.Ok)
+	require.Equal(t, "2", bad.Culprits)
+	require.Equal(t, tss.ReasonBadMessage, bad.Reason)
+
+	for i := range saves {
+		key := fmt.Sprintf("culprit-%d", i)
+		path := walletPath
+		if i == 1 {
+			// P1 exports another wallet, so its message belongs to another session
+			path = "81/0/0/35/1"
+		}
+		require.True(t, export.NewLocalParty(key, i, pIDs, test.B64(saves[i]), keyPair["public"], path).Ok)
+		defer export.RemoveParty(key)
+		require.True(t, export.SetSessionManifest(key, manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+		e := export.ExportRound1Exec(key)
+		require.True(t, e.Ok, e.Err)
+		require.True(t, export.ExportRound1Accept("recipient", i, test.B64(e.MsgWireBytes)).Ok)
+	}
+	f := export.ExportFinalExec("recipient")
+	require.False(t, f.Ok)
+	require.Equal(t, "1", f.Culprits)
+	require.Equal(t, tss.ReasonSessionMismatch, f.Reason)
+}
+
+func runExport(t *testing.T, saves [][]byte, walletPath string, pub ed25519.PublicKey) (string, *export.ExportedKey) {
+	recipientKey := export.GenerateRecipientKey()
+	require.True(t, recipientKey.Ok, recipientKey.Err)
+	keyPair := map[string]string{}
+	require.NoError(t, json.Unmarshal(recipientKey.MsgWireBytes, &keyPair))
+	pIDs := make([]string, len(saves))
+	for i := range saves {
+		pIDs[i] = test.SaveData(t, saves[i]).ShareID.String()
+	}
+	threshold := test.SaveData(t, saves[0]).SignThreshold()
+	manifest := test.Manifest(t, export.TaskName, pIDs, nil, threshold)
+	r := export.NewRecipientParty("recipient", pIDs, threshold, keyPair["private"], walletPath, hex.EncodeToString(pub))
+	require.True(t, r.Ok, r.Err)
+	defer export.RemoveParty("recipient")
+	require.True(t, export.SetSessionManifest("recipient", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	for i := range saves {
+		key := fmt.Sprintf("export-%d", i)
+		r := export.NewLocalParty(key, i, pIDs, test.B64(saves[i]), keyPair["public"], walletPath)
+		require.True(t, r.Ok, r.Err)
+		defer export.RemoveParty(key)
+		require.True(t, export.SetSessionManifest(key, manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+		e := export.ExportRound1Exec(key)
+		require.True(t, e.Ok, e.Err)
+		require.True(t, export.ExportRound1Accept("recipient", i, test.B64(e.MsgWireBytes)).Ok)
+	}
+	require.True(t, export.ExportRound1Finish("recipient").Ok)
+	f := export.ExportFinalExec("recipient")
+	if !f.Ok {
+		return f.Err, nil
+	}
+	exported := &export.ExportedKey{}
+	require.NoError(t, json.Unmarshal(f.MsgWireBytes, exported))
+	return "", exported
+}
+
+// signExpanded signs msg with an expanded ed25519 key, RFC 8032 5.1.6
+func signExpanded(t *testing.T, expanded string, msg []byte) []byte {
+	bz, err := hex.DecodeString(expanded)
+	require.NoError(t, err)
+	ec := tss.Edwards()
+	N := ec.Params().N
+	x := new(big.Int).SetBytes(utils.ReverseByte(bz[:32]))
+	A := crypto.ScalarBaseMult(ec, x)
+	aBytes := edwards.NewPublicKey(A.X(), A.Y()).Serialize()
+	h := sha512.Sum512(append(append([]byte{}, bz[32:]...), msg...))
+	r := new(big.Int).Mod(new(big.Int).SetBytes(utils.ReverseByte(h[:])), N)
+	R := crypto.ScalarBaseMult(ec, r)
+	rBytes := edwards.NewPublicKey(R.X(), R.Y()).Serialize()
+	kh := sha512.Sum512(append(append(append([]byte{}, rBytes...), aBytes...), msg...))
+	k := new(big.Int).Mod(new(big.Int).SetBytes(utils.ReverseByte(kh[:])), N)
+	s := new(big.Int).Mod(new(big.Int).Add(r, new(big.Int).Mul(k, x)), N)
+	var buf [32]byte
+	return append(rBytes, utils.ReverseByte(s.FillBytes(buf[:]))...)
+}
@@ -0,0 +1,293 @@
+package export
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/ckd"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/tss"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/ipfs/go-log"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// Key export rebuilds the full signing scalar for disaster recovery. Every share holder must consent:
+// each one seals its share of the (derived) key to the X25519 key of the recipient, who adds them up,
+// checks the result against the wallet public key and outputs it as an expanded Ed25519 key.
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		ids       tss.SortedPartyIDs
+		threshold int
+
+		// share holders only
+		partyID *tss.PartyID
+		keys    keygen.LocalPartySaveData
+
+		temp     localTempData
+		manifest *tss.SessionManifest
+		number   int
+
+		recipientPK *[32]byte
+		recipientSK *[32]byte // recipient only
+		walletPath  string
+		path        ckd.Path
+		// recipient: the public key the exported key must have
+		expectedPub *crypto.ECPoint
+	}
+
+	localMessageStore struct {
+		exRound1Messages [][]byte // msg.WireBytes(), received by the recipient
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after export)
+
+		ssid []byte
+	}
+
+	// ExportedKey is the output of the recipient
+	ExportedKey struct {
+		PubKey string `json:"pubkey"` // 32-byte Ed25519 public key, hex string
+		// 64-byte expanded private key, hex string: the little-endian scalar followed by the nonce prefix.
+		// There is no RFC 8032 seed behind an MPC key, so seed based formats such as Solana keypairs
+		// cannot be produced; tools must import the scalar as is, without clamping it again.
+		ExpandedKey string `json:"expanded_key"`
+	}
+)
+
+var Parties = map[string]*LocalParty{}
+
+// GenerateRecipientKey returns a new X25519 key pair for the recipient as JSON {"private", "public"}, hex strings
+func GenerateRecipientKey() (result ExportExecResult) {
+	pk, sk, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		common.Logger.Errorf("generate recipient key err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate recipient key err: %s", err.Error())
+		return
+	}
+	bz, err := json.Marshal(map[string]string{
+		"private": hex.EncodeToString(sk[:]),
+		"public":  hex.EncodeToString(pk[:]),
+	})
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = bz
+	return
+}
+
+// NewLocalParty creates the party of a share holder, every party of the key must take part
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	pIDs []string,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+	recipientPub string, // X25519 public key of the recipient, hex string
+	walletPath string, // "" exports the root key
+) (result ExportResult) {
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := keygen.LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+
+	p, err := newLocalParty(pIDs, keys.SignThreshold(), recipientPub, walletPath)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	// the export needs the consent of every share holder, not just a signing quorum
+	if len(p.ids) != len(keys.Ks) {
+		common.Logger.Errorf("party count err: %d, key party count: %d", len(p.ids), len(keys.Ks))
+		result.Err = fmt.Sprintf("party count err: %d, key party count: %d", len(p.ids), len(keys.Ks))
+		return
+	}
+	for j, id := range p.ids {
+		if id.KeyInt().Cmp(keys.Ks[j]) != 0 {
+			common.Logger.Errorf("party id not in keygen data: %d", j)
+			result.Err = fmt.Sprintf("party id not in keygen data: %d", j)
+			return
+		}
+	}
+	if partyIndex < 0 || partyIndex >= len(p.ids) || p.ids[partyIndex].KeyInt().Cmp(keys.ShareID) != 0 {
+		common.Logger.Errorf("party index err: %d", partyIndex)
+		result.Err = fmt.Sprintf("party index err: %d", partyIndex)
+		return
+	}
+	p.partyID = p.ids[partyIndex]
+	p.keys = keys
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+// NewRecipientParty creates the party receiving the exported key
+func NewRecipientParty(
+	key string,
+	pIDs []string,
+	threshold int, // key threshold
+	recipientPriv string, // X25519 private key of the recipient, hex string
+	walletPath string, // "" exports the root key
+	pubKey string, // Ed25519 public key of the wallet, hex string
+) (result ExportResult) {
+	skBytes, err := hex.DecodeString(recipientPriv)
+	if err != nil || len(skBytes) != 32 {
+		common.Logger.Errorf("recipient private key err")
+		result.Err = "recipient private key err"
+		return
+	}
+	pkBytes, err := curve25519.X25519(skBytes, curve25519.Basepoint)
+	if err != nil {
+		common.Logger.Errorf("recipient public key err: %s", err.Error())
+		result.Err = fmt.Sprintf("recipient public key err: %s", err.Error())
+		return
+	}
+	sk := new([32]byte)
+	copy(sk[:], skBytes)
+
+	p, err := newLocalParty(pIDs, threshold, hex.EncodeToString(pkBytes), walletPath)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	pubBytes, err := hex.DecodeString(pubKey)
+	if err != nil {
+		result.Err = fmt.Sprintf("hex decode pubkey err: %s", err.Error())
+		return
+	}
+	edPub, err := edwards.ParsePubKey(pubBytes)
+	if err != nil {
+		result.Err = fmt.Sprintf("parse pubkey err: %s", err.Error())
+		return
+	}
+	if p.expectedPub, err = crypto.NewECPoint(tss.Edwards(), edPub.GetX(), edPub.GetY()); err != nil {
+		result.Err = fmt.Sprintf("parse pubkey err: %s", err.Error())
+		return
+	}
+	p.recipientSK = sk
+	p.temp.exRound1Messages = make([][]byte, len(p.ids))
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+func newLocalParty(pIDs []string, threshold int, recipientPub string, walletPath string) (*LocalParty, error) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		return nil, fmt.Errorf("set log level, err: %s", err.Error())
+	}
+	tss.SetCurve(tss.Edwards())
+
+	pkBytes, err := hex.DecodeString(recipientPub)
+	if err != nil || len(pkBytes) != 32 {
+		common.Logger.Errorf("recipient public key err")
+		return nil, errors.New("recipient public key err")
+	}
+	pk := new([32]byte)
+	copy(pk[:], pkBytes)
+	path, err := ckd.ParsePath(walletPath)
+	if err != nil {
+		common.Logger.Errorf("wallet path err: %s", err.Error())
+		return nil, fmt.Errorf("wallet path err: %s", err.Error())
+	}
+
+	uIds := make(tss.UnSortedPartyIDs, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		pId, _ := new(big.Int).SetString(pIDs[i], 10)
+		common.Logger.Infof("id: %d", pId)
+		uIds = append(uIds, tss.NewPartyID(fmt.Sprintf("%d", i), fmt.Sprintf("m_%d", i), pId))
+	}
+
+	return &LocalParty{
+		BaseParty:   new(tss.BaseParty),
+		ids:         tss.SortPartyIDs(uIds),
+		threshold:   threshold,
+		temp:        localTempData{},
+		recipientPK: pk,
+		walletPath:  walletPath,
+		path:        path,
+	}, nil
+}
+
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result ExportResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(TaskName, party.ids.Keys(), nil, party.threshold); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
+func RemoveParty(key string) bool {
+	if _, ok := Parties[key]; !ok {
+		return false
+	}
+	delete(Parties, key)
+	return true
+}
+
+// blame returns an identifiable abort error naming share holder j as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.partyID, p.ids[j])
+}
+
+// get ssid from the session manifest, the recipient and the canonical wallet path, so that "m/0/1" and
+// "0/1" are one session
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	return p.manifest.SSID(
+		tss.Edwards(),
+		p.ids.Keys(),
+		new(big.Int).SetBytes(p.recipientPK[:]),
+		new(big.Int).SetBytes([]byte(p.path.String())),
+	), nil
+}
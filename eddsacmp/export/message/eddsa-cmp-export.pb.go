@@ -0,0 +1,199 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protob/eddsa-cmp-export.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a message sent to the recipient during Round 1 of the EDDSA TSS key export protocol.
+type EXRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssid []byte `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	// the exported public key, derived along the wallet path
+	EddsaPubX []byte `protobuf:"bytes,2,opt,name=eddsa_pub_x,json=eddsaPubX,proto3" json:"eddsa_pub_x,omitempty"`
+	EddsaPubY []byte `protobuf:"bytes,3,opt,name=eddsa_pub_y,json=eddsaPubY,proto3" json:"eddsa_pub_y,omitempty"`
+	// the public part of the encrypted share, the shares of all parties add up to the exported key
+	SharePubX []byte `protobuf:"bytes,4,opt,name=share_pub_x,json=sharePubX,proto3" json:"share_pub_x,omitempty"`
+	SharePubY []byte `protobuf:"bytes,5,opt,name=share_pub_y,json=sharePubY,proto3" json:"share_pub_y,omitempty"`
+	// the share, sealed to the X25519 key of the recipient
+	Ciphertext []byte `protobuf:"bytes,6,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+}
+
+func (x *EXRound1Message) Reset() {
+	*x = EXRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_export_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EXRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EXRound1Message) ProtoMessage() {}
+
+func (x *EXRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_export_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EXRound1Message.ProtoReflect.Descriptor instead.
+func (*EXRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EXRound1Message) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *EXRound1Message) GetEddsaPubX() []byte {
+	if x != nil {
+		return x.EddsaPubX
+	}
+	return nil
+}
+
+func (x *EXRound1Message) GetEddsaPubY() []byte {
+	if x != nil {
+		return x.EddsaPubY
+	}
+	return nil
+}
+
+func (x *EXRound1Message) GetSharePubX() []byte {
+	if x != nil {
+		return x.SharePubX
+	}
+	return nil
+}
+
+func (x *EXRound1Message) GetSharePubY() []byte {
+	if x != nil {
+		return x.SharePubY
+	}
+	return nil
+}
+
+func (x *EXRound1Message) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+var File_protob_eddsa_cmp_export_proto protoreflect.FileDescriptor
+
+var file_protob_eddsa_cmp_export_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x6d, 0x70, 0x2d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1d, 0x6c, 0x65, 0x67, 0x65, 0x6e, 0x64, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xc5,
+	0x01, 0x0a, 0x0f, 0x45, 0x58, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x64, 0x64, 0x73, 0x61, 0x5f,
+	0x70, 0x75, 0x62, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65, 0x64, 0x64,
+	0x73, 0x61, 0x50, 0x75, 0x62, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x64, 0x64, 0x73, 0x61, 0x5f,
+	0x70, 0x75, 0x62, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65, 0x64, 0x64,
+	0x73, 0x61, 0x50, 0x75, 0x62, 0x59, 0x12, 0x1e, 0x0a, 0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f,
+	0x70, 0x75, 0x62, 0x5f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x50, 0x75, 0x62, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f,
+	0x70, 0x75, 0x62, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x50, 0x75, 0x62, 0x59, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x69, 0x70, 0x68,
+	0x65, 0x72, 0x74, 0x65, 0x78, 0x74, 0x42, 0x11, 0x5a, 0x0f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x63,
+	0x6d, 0x70, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_protob_eddsa_cmp_export_proto_rawDescOnce sync.Once
+	file_protob_eddsa_cmp_export_proto_rawDescData = file_protob_eddsa_cmp_export_proto_rawDesc
+)
+
+func file_protob_eddsa_cmp_export_proto_rawDescGZIP() []byte {
+	file_protob_eddsa_cmp_export_proto_rawDescOnce.Do(func() {
+		file_protob_eddsa_cmp_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_eddsa_cmp_export_proto_rawDescData)
+	})
+	return file_protob_eddsa_cmp_export_proto_rawDescData
+}
+
+var file_protob_eddsa_cmp_export_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_eddsa_cmp_export_proto_goTypes = []interface{}{
+	(*EXRound1Message)(nil), // 0: legend.tsslib.eddsacmp.export.EXRound1Message
+}
+var file_protob_eddsa_cmp_export_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_eddsa_cmp_export_proto_init() }
+func file_protob_eddsa_cmp_export_proto_init() {
+	if File_protob_eddsa_cmp_export_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_eddsa_cmp_export_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EXRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_eddsa_cmp_export_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_eddsa_cmp_export_proto_goTypes,
+		DependencyIndexes: file_protob_eddsa_cmp_export_proto_depIdxs,
+		MessageInfos:      file_protob_eddsa_cmp_export_proto_msgTypes,
+	}.Build()
+	File_protob_eddsa_cmp_export_proto = out.File
+	file_protob_eddsa_cmp_export_proto_rawDesc = nil
+	file_protob_eddsa_cmp_export_proto_goTypes = nil
+	file_protob_eddsa_cmp_export_proto_depIdxs = nil
+}
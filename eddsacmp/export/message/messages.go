@@ -0,0 +1,61 @@
+package message
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/tss"
+)
+
+// ----- //
+
+func NewEXRound1Message(
+	from *tss.PartyID,
+	ssid []byte,
+	eddsaPub *crypto.ECPoint,
+	sharePub *crypto.ECPoint,
+	ciphertext []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: false,
+	}
+	content := &EXRound1Message{
+		Ssid:       ssid,
+		EddsaPubX:  eddsaPub.X().Bytes(),
+		EddsaPubY:  eddsaPub.Y().Bytes(),
+		SharePubX:  sharePub.X().Bytes(),
+		SharePubY:  sharePub.Y().Bytes(),
+		Ciphertext: ciphertext,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EXRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetEddsaPubX()) &&
+		common.NonEmptyBytes(m.GetEddsaPubY()) &&
+		common.NonEmptyBytes(m.GetSharePubX()) &&
+		common.NonEmptyBytes(m.GetSharePubY()) &&
+		common.NonEmptyBytes(m.GetCiphertext())
+}
+
+func (m *EXRound1Message) UnmarshalEdDSAPub(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetEddsaPubX()),
+		new(big.Int).SetBytes(m.GetEddsaPubY()),
+	)
+}
+
+func (m *EXRound1Message) UnmarshalSharePub(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetSharePubX()),
+		new(big.Int).SetBytes(m.GetSharePubY()),
+	)
+}
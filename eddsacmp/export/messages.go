@@ -0,0 +1,16 @@
+package export
+
+import (
+	msg "tss_sdk/eddsacmp/export/message"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-cmp-export.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that export messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*msg.EXRound1Message)(nil),
+	}
+)
@@ -0,0 +1,193 @@
+package export
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/ckd"
+	"tss_sdk/crypto/vss"
+	m "tss_sdk/eddsacmp/export/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+type ExportExecResult struct {
+	Ok           bool   `json:"ok"`
+	Err          string `json:"error"`
+	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+type ExportResult struct {
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *ExportExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *ExportResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+// ExportRound1Exec is run by every share holder, it seals its share of the exported key to the recipient
+func ExportRound1Exec(key string) (result ExportExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.partyID == nil {
+		common.Logger.Errorf("party is not a share holder: %s", key)
+		result.Err = fmt.Sprintf("party is not a share holder: %s", key)
+		return
+	}
+
+	i := party.partyID.Index
+	party.number = 1
+	common.Logger.Infof("party: %d, export round_1 start", i)
+
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	keys := party.keys
+	ec := keys.EdDSAPub.Curve()
+	modN := common.ModInt(ec.Params().N)
+
+	// The exported key is EdDSAPub + tweak*G, w_i is our additive share of it
+	eddsaPub := keys.EdDSAPub
+	wi := keys.PrivXi
+	if !party.path.IsRoot() {
+		tweak, err := keys.ChildTweak(party.walletPath)
+		if err != nil {
+			common.Logger.Errorf("deriveChildTweak err: %s", err.Error())
+			result.Err = fmt.Sprintf("deriveChildTweak err: %s", err.Error())
+			return
+		}
+		if eddsaPub, err = eddsaPub.Add(crypto.ScalarBaseMult(ec, tweak)); err != nil {
+			result.Err = fmt.Sprintf("calc child pubkey err: %s", err.Error())
+			return
+		}
+		if keys.IsAdditive() && keys.SingleChainCode() {
+			if i == 0 {
+				wi = modN.Add(wi, tweak)
+			}
+		} else if keys.IsAdditive() {
+			childPrivKey, _, err := ckd.DeriveEddsaChildPrivKey(
+				keys.PrivXi, keys.PubXj[i], keys.EdDSAPub, ckd.ChainCodeBytes(keys.ChainCodes[i]), party.walletPath)
+			if err != nil {
+				common.Logger.Errorf("deriveChildPrivateKey err: %s", err.Error())
+				result.Err = fmt.Sprintf("deriveChildPrivateKey err: %s", err.Error())
+				return
+			}
+			wi = new(big.Int).SetBytes(childPrivKey[:])
+		} else {
+			wi = modN.Add(wi, tweak)
+		}
+	}
+	if !keys.IsAdditive() {
+		wi = modN.Mul(vss.LagrangeCoefficient(ec, i, party.ids.Keys()), wi)
+	}
+
+	// seal ssid || w_i, so a share cannot be replayed into another export
+	var buf [32]byte
+	plaintext := append(append([]byte{}, party.temp.ssid...), wi.FillBytes(buf[:])...)
+	ciphertext, err := box.SealAnonymous(nil, plaintext, party.recipientPK, rand.Reader)
+	if err != nil {
+		common.Logger.Errorf("seal share err: %s", err.Error())
+		result.Err = fmt.Sprintf("seal share err: %s", err.Error())
+		return
+	}
+
+	msg := m.NewEXRound1Message(party.partyID, party.temp.ssid, eddsaPub, crypto.ScalarBaseMult(ec, wi), ciphertext)
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// ExportRound1Accept is run by the recipient, from is the index of the share holder
+func ExportRound1Accept(key string, from int, msgWireBytes string) (result ExportResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.recipientSK == nil {
+		result.Err = fmt.Sprintf("party is not the recipient: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.exRound1Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	party.number = 1
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	if _, ok := msg.Content().(*m.EXRound1Message); !ok {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not EXRound1Message")))
+		return
+	}
+	party.temp.exRound1Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func ExportRound1Finish(key string) (result ExportResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.exRound1Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
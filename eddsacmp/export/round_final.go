@@ -0,0 +1,118 @@
+package export
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/alice/utils"
+	m "tss_sdk/eddsacmp/export/message"
+	"tss_sdk/tss"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// ExportFinalExec is run by the recipient, it opens the shares and outputs the ExportedKey JSON
+func ExportFinalExec(key string) (result ExportExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.recipientSK == nil {
+		common.Logger.Errorf("party is not the recipient: %s", key)
+		result.Err = fmt.Sprintf("party is not the recipient: %s", key)
+		return
+	}
+
+	party.number = 2
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	ec := tss.Edwards()
+	modN := common.ModInt(ec.Params().N)
+	x := big.NewInt(0)
+	for j, msgBytes := range party.temp.exRound1Messages {
+		pMsg, err := tss.ParseWireMsg(msgBytes)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)))
+			return
+		}
+		r1Msg, ok := pMsg.Content().(*m.EXRound1Message)
+		if !ok {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("not EXRound1Message, party: %d", j)))
+			return
+		}
+
+		if !bytes.Equal(r1Msg.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+		eddsaPub, err := r1Msg.UnmarshalEdDSAPub(ec)
+		if err != nil || !eddsaPub.Equals(party.expectedPub) {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("eddsa pub mismatch, party: %d", j)))
+			return
+		}
+		sharePub, err := r1Msg.UnmarshalSharePub(ec)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal share pub err: %s, party: %d", err.Error(), j)))
+			return
+		}
+
+		plaintext, ok := box.OpenAnonymous(nil, r1Msg.GetCiphertext(), party.recipientPK, party.recipientSK)
+		if !ok || len(plaintext) != len(party.temp.ssid)+32 || !bytes.Equal(plaintext[:len(party.temp.ssid)], party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("open share failed, party: %d", j)))
+			return
+		}
+		wj := new(big.Int).SetBytes(plaintext[len(party.temp.ssid):])
+		if !crypto.ScalarBaseMult(ec, wj).Equals(sharePub) {
+			result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("share verify failed, party: %d", j)))
+			return
+		}
+		x = modN.Add(x, wj)
+	}
+
+	// The shares must add up to the wallet key; each one matches the public share its holder sent,
+	// but the recipient cannot check those against the key, so no one is to blame
+	if x.Sign() == 0 || !crypto.ScalarBaseMult(ec, x).Equals(party.expectedPub) {
+		result.abort(tss.NewError(errors.New("exported key != wallet pubkey"), TaskName, party.number, party.partyID))
+		return
+	}
+
+	// expanded key: the little-endian scalar, and a nonce prefix derived from it
+	var buf [32]byte
+	scalar := utils.ReverseByte(x.FillBytes(buf[:]))
+	prefix := sha512.Sum512(append([]byte(TaskName), scalar...))
+	exported := ExportedKey{
+		PubKey:      hex.EncodeToString(edwards.NewPublicKey(party.expectedPub.X(), party.expectedPub.Y()).Serialize()),
+		ExpandedKey: hex.EncodeToString(append(scalar, prefix[:32]...)),
+	}
+	out, err := json.Marshal(exported)
+	if err != nil {
+		common.Logger.Errorf("marshal exported key err: %s", err.Error())
+		result.Err = fmt.Sprintf("marshal exported key err: %s", err.Error())
+		return
+	}
+	party.temp.exRound1Messages = nil
+
+	result.Ok = true
+	result.MsgWireBytes = out
+	return result
+}
@@ -0,0 +1,5 @@
+package export
+
+const (
+	TaskName = "eddsa-cmp-export"
+)
@@ -0,0 +1,57 @@
+package keygen_test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeygenAbort(t *testing.T) {
+	n := 3
+	pIDs := test.PartyIDs(n)
+	manifest := test.Manifest(t, keygen.TaskName, pIDs, nil, 2)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("abort-%d", i)
+		require.True(t, keygen.NewLocalParty(keys[i], i, n, 2, pIDs, "").Ok)
+		require.True(t, keygen.SetSessionManifest(keys[i], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	r1 := make([][]byte, n)
+	for i := range keys {
+		r := keygen.KeygenRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r1[i] = r.MsgWireBytes
+	}
+	bad := keygen.KeygenRound1Accept(keys[0], 2, test.B64([]byte("garbage")))
+	require.False(t, bad.Ok)
+	require.Equal(t, "2", bad.Culprits)
+	require.Equal(t, tss.ReasonBadMessage, bad.Reason)
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, keygen.KeygenRound1Accept(keys[i], j, test.B64(r1[j])).Ok)
+			}
+		}
+	}
+	r2 := make([][]byte, n)
+	for i := range keys {
+		r := keygen.KeygenRound2Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r2[i] = r.MsgWireBytes
+	}
+	// P1 relays the round 2 message of P2 as its own
+	require.True(t, keygen.KeygenRound2Accept(keys[0], 1, test.B64(r2[2])).Ok)
+	require.True(t, keygen.KeygenRound2Accept(keys[0], 2, test.B64(r2[2])).Ok)
+	for j := 1; j < n; j++ {
+		require.True(t, keygen.KeygenRound2Accept(keys[0], j, test.B64(keygen.GetRound2Msg2(keys[j], 0).MsgWireBytes)).Ok)
+	}
+	r := keygen.KeygenRound3Exec(keys[0])
+	require.False(t, r.Ok)
+	require.Equal(t, "1", r.Culprits)
+	require.Equal(t, tss.ReasonBadCommitment, r.Reason)
+}
@@ -0,0 +1,139 @@
+package keygen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"tss_sdk/common"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	paillierzkproof "tss_sdk/crypto/alice/zkproof/paillier"
+	"tss_sdk/crypto/modproof"
+	"tss_sdk/crypto/paillier"
+)
+
+const (
+	// PaillierModulusLen is the bit length of the Paillier modulus N, which also serves as the ring-Pedersen modulus
+	PaillierModulusLen = 2048
+
+	// number of challenges of the ring-Pedersen parameter proof, the same as the modproof iterations
+	PrmProofIterations = modproof.Iterations
+
+	auxInfoTimeout = 10 * time.Minute
+)
+
+// GenerateAuxInfo generates a safe-prime Paillier key and ring-Pedersen parameters (s, t) over the same modulus
+func GenerateAuxInfo(rand io.Reader) (*paillier.PrivateKey, *pailliera.PedPrivKey, error) {
+	paillierSK, err := GeneratePaillierKey(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paillierSK, RingPedersen(rand, paillierSK), nil
+}
+
+// GeneratePaillierKey generates the safe-prime Paillier key of GenerateAuxInfo, which takes most of its time
+func GeneratePaillierKey(rand io.Reader) (*paillier.PrivateKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), auxInfoTimeout)
+	defer cancel()
+
+	paillierSK, _, err := paillier.GenerateKeyPair(ctx, rand, PaillierModulusLen)
+	return paillierSK, err
+}
+
+// PaillierKey returns the Paillier key of the safe primes p and q, as GeneratePaillierKey would
+func PaillierKey(p, q *big.Int) (*paillier.PrivateKey, error) {
+	if p == nil || q == nil || p.Cmp(q) == 0 {
+		return nil, errors.New("p and q must be two distinct primes")
+	}
+	for _, prime := range []*big.Int{p, q} {
+		sgp := new(common.GermainSafePrime)
+		sgp.Set(prime, new(big.Int).Rsh(prime, 1))
+		if !sgp.Validate() {
+			return nil, errors.New("p or q is not a safe prime")
+		}
+	}
+	N := new(big.Int).Mul(p, q)
+	if N.BitLen() < PaillierModulusLen {
+		return nil, fmt.Errorf("paillier modulus too small: %d bits", N.BitLen())
+	}
+
+	// phiN = (p-1)(q-1), lambdaN = lcm(p-1, q-1)
+	pMinus1, qMinus1 := new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Sub(q, big.NewInt(1))
+	phiN := new(big.Int).Mul(pMinus1, qMinus1)
+	lambdaN := new(big.Int).Div(phiN, new(big.Int).GCD(nil, nil, pMinus1, qMinus1))
+	return &paillier.PrivateKey{PublicKey: paillier.PublicKey{N: N}, LambdaN: lambdaN, PhiN: phiN, P: p, Q: q}, nil
+}
+
+// RingPedersen returns fresh ring-Pedersen parameters (s, t) over the modulus of paillierSK
+func RingPedersen(rand io.Reader, paillierSK *paillier.PrivateKey) *pailliera.PedPrivKey {
+	// t = tau^2, s = t^lambda mod N
+	N := paillierSK.N
+	lambda := common.GetRandomPositiveInt(rand, paillierSK.PhiN)
+	tau := common.GetRandomPositiveRelativelyPrimeInt(rand, N)
+	t := new(big.Int).Exp(tau, big.NewInt(2), N)
+	s := new(big.Int).Exp(t, lambda, N)
+
+	pedSK := &pailliera.PedPrivKey{
+		PedPubKey: pailliera.PedPubKey{N: N, S: s, T: t},
+		LambdaN:   lambda,
+		Euler:     paillierSK.PhiN,
+	}
+	return pedSK
+}
+
+// ProveAuxInfo proves that N is a Paillier-Blum modulus (Πmod) and that s is in the group generated by t (Πprm)
+func ProveAuxInfo(
+	session []byte,
+	paillierSK *paillier.PrivateKey,
+	pedSK *pailliera.PedPrivKey,
+	rand io.Reader,
+) (*modproof.ProofMod, *paillierzkproof.RingPederssenParameterMessage, error) {
+	modProof, err := modproof.NewProof(session, paillierSK.N, paillierSK.P, paillierSK.Q, rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	prmProof, err := paillierzkproof.NewRingPederssenParameterMessage(
+		session, pedSK.Euler, pedSK.N, pedSK.S, pedSK.T, pedSK.LambdaN, PrmProofIterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	return modProof, prmProof, nil
+}
+
+// VerifyAuxInfo checks the Paillier key and ring-Pedersen parameters of another party against its proofs
+func VerifyAuxInfo(
+	session []byte,
+	paillierPK *paillier.PublicKey,
+	pedPK *pailliera.PedPubKey,
+	modProof *modproof.ProofMod,
+	prmProof *paillierzkproof.RingPederssenParameterMessage,
+) error {
+	N := paillierPK.N
+	if N.BitLen() < PaillierModulusLen {
+		return fmt.Errorf("paillier modulus too small: %d bits", N.BitLen())
+	}
+	if pedPK.N.Cmp(N) != 0 {
+		return errors.New("ring-pedersen modulus != paillier modulus")
+	}
+	if !common.IsNumberInMultiplicativeGroup(N, pedPK.S) || !common.IsNumberInMultiplicativeGroup(N, pedPK.T) {
+		return errors.New("ring-pedersen s or t not in Z_N^*")
+	}
+	if pedPK.S.Cmp(pedPK.T) == 0 {
+		return errors.New("ring-pedersen s == t")
+	}
+	if !modProof.Verify(session, N) {
+		return errors.New("mod proof verify failed")
+	}
+	if N.Cmp(new(big.Int).SetBytes(prmProof.GetN())) != 0 ||
+		pedPK.S.Cmp(new(big.Int).SetBytes(prmProof.GetS())) != 0 ||
+		pedPK.T.Cmp(new(big.Int).SetBytes(prmProof.GetT())) != 0 {
+		return errors.New("prm proof is not about the broadcast ring-pedersen parameters")
+	}
+	if err := prmProof.Verify(session); err != nil {
+		return fmt.Errorf("prm proof verify failed: %s", err.Error())
+	}
+	return nil
+}
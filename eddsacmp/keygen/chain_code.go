@@ -0,0 +1,56 @@
+package keygen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+)
+
+// SingleChainCodeKey replaces the chain code of every keygen party by one group chain code,
+// SHA-512/256 of all of them, so a secp256k1 key derives like a plain BIP 32 wallet from EdDSAPub and that code;
+// an Ed25519 key still hashes its points as secp256k1 ones and matches no other wallet unless it switches to
+// the BIP32-Ed25519 scheme. Every party converts its own keygen data and gets the same code. The child keys of every path change,
+// so a wallet converts before deriving any address. Outputs the keygen data as JSON.
+func SingleChainCodeKey(keyData string) (result KeygenExecResult) {
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if len(keys.ChainCodes) == 0 || keys.SingleChainCode() {
+		common.Logger.Errorf("key has %d chain codes, nothing to combine", len(keys.ChainCodes))
+		result.Err = fmt.Sprintf("key has %d chain codes, nothing to combine", len(keys.ChainCodes))
+		return
+	}
+
+	codes := make([][]byte, len(keys.ChainCodes))
+	for j, code := range keys.ChainCodes {
+		if code.BitLen() > 8*ChainCodeLen {
+			common.Logger.Errorf("chain code too long, party: %d", j)
+			result.Err = fmt.Sprintf("chain code too long, party: %d", j)
+			return
+		}
+		codes[j] = code.FillBytes(make([]byte, ChainCodeLen))
+	}
+	keys.ChainCodes = []*big.Int{new(big.Int).SetBytes(common.SHA512_256(codes...))}
+
+	saveBytes, err := json.Marshal(keys)
+	if err != nil {
+		common.Logger.Errorf("marshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("marshal keygen save data err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return
+}
@@ -0,0 +1,54 @@
+package keygen_test
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"tss_sdk/crypto/ckd"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/test"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJointChainCodes(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	data := test.SaveData(t, saves[0])
+	require.Len(t, data.ChainCodes, 3)
+	for _, s := range saves[1:] {
+		require.Equal(t, data.ChainCodes, test.SaveData(t, s).ChainCodes)
+	}
+	// the joint codes replace those of SaveChainCodes
+	require.NotEqual(t, int64(0xabc0), data.ChainCodes[0].Int64())
+	test.CheckSig(t, test.ChildPub(t, saves[0], walletPath), test.Sign(t, saves, []int{0, 2}, 2, "deadbeef", walletPath, ""))
+	additive := test.KeygenFixtures(t, 2, 2)
+	test.CheckSig(t, test.ChildPub(t, additive[0], walletPath), test.Sign(t, additive, []int{0, 1}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestSingleChainCode(t *testing.T) {
+	path := "44/501/0/0"
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1]))
+		require.False(t, keygen.SingleChainCodeKey(test.B64(saves[0])).Ok)
+		pub := plainChildPub(t, saves[0], path)
+		require.Equal(t, pub, test.ChildPub(t, saves[0], path))
+		signers := []int{0, 1}
+		if c[0] == 3 {
+			signers = []int{1, 2}
+		}
+		test.CheckSig(t, pub, test.Sign(t, saves, signers, c[1], "deadbeef", path, ""))
+		w := keygen.WatchOnlyKey(test.B64(saves[0]))
+		require.True(t, w.Ok, w.Err)
+		require.Equal(t, []byte(pub), keygen.DeriveWatchOnlyPubKey(string(w.MsgWireBytes), path).MsgWireBytes)
+	}
+}
+
+// plainChildPub derives like a BIP 32 wallet from the group key and its single chain code
+func plainChildPub(t *testing.T, save []byte, path string) ed25519.PublicKey {
+	data := test.SaveData(t, save)
+	require.Len(t, data.ChainCodes, 1)
+	pub, err := ckd.DeriveEddsaChildPubKey(data.PubKey(), data.PubKey(), data.ChainCodes[0].Bytes(), path)
+	require.NoError(t, err)
+	return edwards.NewPublicKey(pub.X(), pub.Y()).Serialize()
+}
@@ -0,0 +1,50 @@
+package keygen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto/ckd"
+)
+
+// SetDerivationScheme sets the child key derivation scheme of the keygen data, ckd.SchemeBip32 ("") or
+// ckd.SchemeBip32Ed25519 ("bip32-ed25519"). BIP32-Ed25519 needs an Ed25519 key with a single chain code,
+// see SingleChainCodeKey. Every party sets the same scheme on its own keygen data, before any address is
+// derived: the child keys of every path change. Outputs the keygen data as JSON.
+func SetDerivationScheme(keyData string, scheme string) (result KeygenExecResult) {
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.EdDSAPub == nil {
+		common.Logger.Errorf("no public key in keygen save data")
+		result.Err = "no public key in keygen save data"
+		return
+	}
+	if err := ckd.CheckScheme(scheme, keys.EdDSAPub, keys.ChainCodes); err != nil {
+		common.Logger.Errorf("derivation scheme err: %s", err.Error())
+		result.Err = fmt.Sprintf("derivation scheme err: %s", err.Error())
+		return
+	}
+	keys.Derivation = scheme
+
+	saveBytes, err := json.Marshal(keys)
+	if err != nil {
+		common.Logger.Errorf("marshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("marshal keygen save data err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return
+}
@@ -0,0 +1,39 @@
+package keygen_test
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/ckd"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/test"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBip32Ed25519(t *testing.T) {
+	path := "0/0"
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		// the scheme needs a single chain code
+		require.False(t, keygen.SetDerivationScheme(test.B64(test.KeygenFixtures(t, c[0], c[1])[0]), ckd.SchemeBip32Ed25519).Ok)
+		saves := test.Bip32Ed25519(t, test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1])))
+		data := test.SaveData(t, saves[0])
+		tweak, err := ckd.DeriveBip32Ed25519ChildTweak(data.PubKey(), data.ChainCodes[0].FillBytes(make([]byte, 32)), path)
+		require.NoError(t, err)
+		child, err := data.PubKey().Add(crypto.ScalarBaseMult(data.PubKey().Curve(), tweak))
+		require.NoError(t, err)
+		pub := ed25519.PublicKey(edwards.NewPublicKey(child.X(), child.Y()).Serialize())
+		require.Equal(t, pub, test.ChildPub(t, saves[0], path))
+		require.NotEqual(t, pub, plainChildPub(t, saves[0], path))
+		signers := []int{0, 1}
+		if c[0] == 3 {
+			signers = []int{1, 2}
+		}
+		test.CheckSig(t, pub, test.Sign(t, saves, signers, c[1], "deadbeef", path, ""))
+		w := keygen.WatchOnlyKey(test.B64(saves[0]))
+		require.True(t, w.Ok, w.Err)
+		require.Equal(t, []byte(pub), keygen.DeriveWatchOnlyPubKey(string(w.MsgWireBytes), path).MsgWireBytes)
+	}
+}
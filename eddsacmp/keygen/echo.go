@@ -0,0 +1,51 @@
+package keygen
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"fmt"
+
+	"tss_sdk/tss"
+)
+
+// The echo of a broadcast round is optional, it is run between KeygenRoundNFinish and
+// KeygenRoundN+1Exec when the transport does not guarantee every party gets the same broadcast.
+
+// broadcastMessages returns the broadcast messages of keygen round 1-3, by sender
+func (p *LocalParty) broadcastMessages(round int) ([][]byte, error) {
+	switch round {
+	case 1:
+		return p.temp.kgRound1Messages, nil
+	case 2:
+		return p.temp.kgRound2Messages, nil
+	case 3:
+		return p.temp.kgRound3Messages, nil
+	}
+	return nil, fmt.Errorf("not a broadcast round: %d", round)
+}
+
+// echoOf looks up the echo state and the ssid of the party of key
+func echoOf(key string) (*tss.Echo, []byte, bool) {
+	party, ok := Parties[key]
+	if !ok {
+		return nil, nil, false
+	}
+	return party.temp.echo, party.temp.ssid, true
+}
+
+// KeygenEchoExec broadcasts the hash of every message received in the broadcast round
+func KeygenEchoExec(key string, round int) KeygenExecResult {
+	return KeygenExecResult(tss.EchoExec(echoOf, key, round))
+}
+
+func KeygenEchoAccept(key string, from int, msgWireBytes string) KeygenResult {
+	return KeygenResult(tss.EchoAccept(echoOf, key, from, msgWireBytes))
+}
+
+// KeygenEchoFinish checks every party echoed the same broadcast messages as we received
+func KeygenEchoFinish(key string, round int) KeygenResult {
+	return KeygenResult(tss.EchoFinish(echoOf, key, round))
+}
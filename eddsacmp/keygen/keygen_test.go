@@ -0,0 +1,120 @@
+package keygen_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestKeygenThreshold(t *testing.T) {
+	saves := test.Keygen(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	for _, s := range saves[1:] {
+		require.Equal(t, pub, test.ChildPub(t, s, walletPath))
+	}
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{0, 2}, 2, "deadbeef", walletPath, ""))
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{1, 2}, 2, "deadbeef", walletPath, ""))
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{0, 1, 2}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestKeygenAdditive(t *testing.T) {
+	saves := test.Keygen(t, 2, 2)
+	// additive keys still sign with the aux info of the legacy refresh payload
+	test.CheckSig(t, test.ChildPub(t, saves[0], walletPath), test.Sign(t, saves, []int{0, 1}, 2, "deadbeef", walletPath, refreshPayload(2)))
+}
+
+func TestKeygenMisroutedShare(t *testing.T) {
+	n, threshold := 3, 2
+	pIDs := test.PartyIDs(n)
+	keys := make([]string, n)
+	manifest := test.Manifest(t, keygen.TaskName, pIDs, nil, threshold)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("misrouted-%d", i)
+		rootPrivKey, err := common.GetRandomBytes(rand.Reader, 32)
+		require.NoError(t, err)
+		require.True(t, keygen.NewLocalParty(keys[i], i, n, threshold, pIDs, hex.EncodeToString(rootPrivKey)).Ok)
+		require.True(t, keygen.SetSessionManifest(keys[i], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	round := func(exec func(string) keygen.KeygenExecResult, accept func(string, int, string) keygen.KeygenResult) {
+		out := make([][]byte, n)
+		for i := range keys {
+			r := exec(keys[i])
+			require.True(t, r.Ok, r.Err)
+			out[i] = r.MsgWireBytes
+		}
+		for i := range keys {
+			for j := range keys {
+				if i != j {
+					r := accept(keys[i], j, test.B64(out[j]))
+					require.True(t, r.Ok, r.Err)
+				}
+			}
+		}
+	}
+	round(keygen.KeygenRound1Exec, keygen.KeygenRound1Accept)
+	round(keygen.KeygenRound2Exec, keygen.KeygenRound2Accept)
+	require.False(t, keygen.GetRound2Msg2(keys[0], n).Ok)
+	require.False(t, keygen.GetRound2Msg2(keys[0], -1).Ok)
+	// P0 gets the share P1 dealt to P2
+	for i := range keys {
+		for j := range keys {
+			if i == j {
+				continue
+			}
+			to := i
+			if i == 0 && j == 1 {
+				to = 2
+			}
+			r := keygen.GetRound2Msg2(keys[j], to)
+			require.True(t, r.Ok, r.Err)
+			require.True(t, keygen.KeygenRound2Accept(keys[i], j, test.B64(r.MsgWireBytes)).Ok)
+		}
+	}
+	r := keygen.KeygenRound3Exec(keys[0])
+	require.False(t, r.Ok)
+	require.Equal(t, "1", r.Culprits)
+	require.True(t, keygen.KeygenRound3Exec(keys[2]).Ok)
+}
+
+var (
+	p0, _ = new(big.Int).SetString("104975615121222854384410219330480259027041155688835759631647658735069527864919393410352284436544267374160206678331198777612866309766581999589789442827625308608614590850591998897357449886061863686453412019330757447743487422636807387508460941025550338019105820406950462187693188000168607236389735877001362796259", 10)
+	q0, _ = new(big.Int).SetString("102755306389915984635356782597494195047102560555160692696207839728487252530690043689166546890155633162017964085393843240989395317546293846694693801865924045225783240995686020308553449158438908412088178393717793204697268707791329981413862246773904710409946848630083569401668855899757371993960961231481357354607", 10)
+)
+
+// refreshPayload is aux info of n parties in the layout onsign slices the refresh argument
+func refreshPayload(n int) string {
+	N := new(big.Int).Mul(p0, q0)
+	primes, _ := common.GetRandomSafePrimesConcurrent(context.Background(), 512, 2, 4, rand.Reader)
+	pedN := new(big.Int).Mul(primes[0].SafePrime(), primes[1].SafePrime())
+	tau := common.GetRandomPositiveRelativelyPrimeInt(rand.Reader, pedN)
+	t := new(big.Int).Exp(tau, big.NewInt(2), pedN)
+	lambda := common.GetRandomPositiveInt(rand.Reader, pedN)
+	s := new(big.Int).Exp(t, lambda, pedN)
+	out := make([]byte, 1376)
+	for i := 0; i < n; i++ {
+		entry := make([]byte, 33)
+		entry = append(entry, pad(N.Bytes(), 256)...)
+		entry = append(entry, pad(pedN.Bytes(), 128)...)
+		entry = append(entry, pad(s.Bytes(), 128)...)
+		entry = append(entry, pad(t.Bytes(), 128)...)
+		out = append(out, entry...)
+	}
+	return hex.EncodeToString(out)
+}
+
+func pad(bz []byte, n int) []byte {
+	out := make([]byte, n)
+	copy(out[n-len(bz):], bz)
+	return out
+}
@@ -6,13 +6,18 @@ package keygen
 import "C"
 
 import (
+	"crypto/elliptic"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 
 	"tss_sdk/common"
 	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/vss"
 	m "tss_sdk/eddsacmp/keygen/message"
 	"tss_sdk/tss"
 
@@ -29,20 +34,29 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		temp   LocalTempData
-		save   LocalPartySaveData
-		number int
-		ok     []bool
+		temp     LocalTempData
+		save     LocalPartySaveData
+		manifest *tss.SessionManifest
+		taskName string
+		number   int
+		ok       []bool
 	}
 
 	localMessageStore struct {
-		kgRound1Messages [][]byte // msg.WireBytes()
-		kgRound2Messages [][]byte
-		kgRound3Messages [][]byte
+		kgRound1Messages  [][]byte // msg.WireBytes()
+		kgRound2Messages  [][]byte
+		kgRound2Message2s [][]byte
+		kgRound3Messages  [][]byte
+		echo              *tss.Echo // echoes of the broadcast rounds
+	}
+
+	sendMessageStore struct {
+		kgRound2Message2s [][]byte // msg.WireBytes()
 	}
 
 	LocalTempData struct {
 		localMessageStore
+		send sendMessageStore
 
 		// temp data (thrown away after keygen)
 
@@ -54,13 +68,28 @@ type (
 		srid []byte
 		u    []byte
 
+		// our chain code share, committed to in V_i
+		chainCode []byte
+
 		payload []*m.CmpKeyGenerationPayload
 
-		ssid      []byte
-		ssidNonce *big.Int
+		ssid []byte
 
 		srids [][]byte
 		V     [][]byte
+
+		// Feldman VSS: vs[j] are the commitments dealt by Pj, shares are dealt by us,
+		// receivedShares[j] is the share Pj dealt to us
+		vs             []vss.Vs
+		shares         vss.Shares
+		receivedShares []*big.Int
+
+		// aux info: ring-Pedersen secrets, the Paillier key is kept in the save data
+		pedSK *pailliera.PedPrivKey
+
+		// X25519 keys the vss shares are sealed to: ours, and the one of every Pj from round 1
+		encPK, encSK *[32]byte
+		encPKs       []*[32]byte
 	}
 )
 
@@ -71,6 +100,22 @@ func NewLocalParty(
 	key string,
 	partyIndex int,
 	partyCount int,
+	threshold int, // number of parties required to sign
+	pIDs []string,
+	rootPrivKey string,
+) (result KeygenResult) {
+	return NewCurveLocalParty(key, tss.Edwards(), TaskName, partyIndex, partyCount, threshold, pIDs, rootPrivKey)
+}
+
+// NewCurveLocalParty creates a keygen party over another curve, the rounds do not depend on the curve.
+// taskName is the protocol of the session manifest and separates the sessions of each curve.
+func NewCurveLocalParty(
+	key string,
+	ec elliptic.Curve,
+	taskName string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // number of parties required to sign
 	pIDs []string,
 	rootPrivKey string,
 ) (result KeygenResult) {
@@ -79,7 +124,13 @@ func NewLocalParty(
 		result.Err = fmt.Sprintf("set log level, err: %s", err.Error())
 		return
 	}
-	tss.SetCurve(tss.Edwards())
+	tss.SetCurve(ec)
+
+	if threshold < 1 || threshold > partyCount {
+		common.Logger.Errorf("threshold err: %d, party count: %d", threshold, partyCount)
+		result.Err = fmt.Sprintf("threshold err: %d, party count: %d", threshold, partyCount)
+		return
+	}
 
 	uIds := make(tss.UnSortedPartyIDs, 0, partyCount)
 	for i := 0; i < partyCount; i++ {
@@ -90,8 +141,10 @@ func NewLocalParty(
 	ids := tss.SortPartyIDs(uIds)
 
 	p2pCtx := tss.NewPeerContext(ids)
-	params := tss.NewParameters(tss.Edwards(), p2pCtx, ids[partyIndex], partyCount, partyCount)
+	params := tss.NewParameters(ec, p2pCtx, ids[partyIndex], partyCount, threshold)
 	data := NewLocalPartySaveData(partyCount)
+	data.Threshold = threshold
+	data.Shamir = threshold < partyCount
 
 	privkey, err := hex.DecodeString(rootPrivKey)
 	if err != nil {
@@ -105,18 +158,25 @@ func NewLocalParty(
 		params:    params,
 		temp:      LocalTempData{},
 		save:      data,
+		taskName:  taskName,
 		ok:        make([]bool, partyCount),
 	}
 
 	// msgs init
 	p.temp.kgRound1Messages = make([][]byte, partyCount)
 	p.temp.kgRound2Messages = make([][]byte, partyCount)
+	p.temp.kgRound2Message2s = make([][]byte, partyCount)
 	p.temp.kgRound3Messages = make([][]byte, partyCount)
+	p.temp.send.kgRound2Message2s = make([][]byte, partyCount)
+	p.temp.echo = tss.NewEcho(taskName, p.PartyID(), p.params.Parties().IDs(), p.broadcastMessages)
 
 	// temp data init
 	p.temp.payload = make([]*m.CmpKeyGenerationPayload, partyCount)
 	p.temp.srids = make([][]byte, partyCount)
 	p.temp.V = make([][]byte, partyCount)
+	p.temp.vs = make([]vss.Vs, partyCount)
+	p.temp.receivedShares = make([]*big.Int, partyCount)
+	p.temp.encPKs = make([]*[32]byte, partyCount)
 
 	Parties[key] = p
 	result.Ok = true
@@ -124,6 +184,8 @@ func NewLocalParty(
 }
 
 // chainCodes: hex string array
+//
+// Deprecated: keygen generates the chain codes jointly, codes saved before round 1 are replaced.
 func SaveChainCodes(key string, chainCodes string) (result KeygenResult) {
 	party, ok := Parties[key]
 	if !ok {
@@ -155,6 +217,32 @@ func SaveChainCodes(key string, chainCodes string) (result KeygenResult) {
 	return
 }
 
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result KeygenResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(party.taskName, party.params.Parties().IDs().Keys(), nil, party.params.Threshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
 func RemoveParty(key string) bool {
 	if _, ok := Parties[key]; !ok {
 		return false
@@ -169,6 +257,16 @@ func (p *LocalParty) resetOK() {
 	}
 }
 
+// additive reports whether this keygen produces n-of-n additive shares instead of Shamir shares
+func (p *LocalParty) additive() bool {
+	return p.params.Threshold() == p.params.PartyCount()
+}
+
+// blame returns an identifiable abort error naming Pj as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, p.taskName, p.number, p.PartyID(), p.params.Parties().IDs()[j])
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -177,13 +275,15 @@ func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
 
-// get ssid from local params
-func (p *LocalParty) getSSID() ([]byte, error) {
-	// ssidList := []*big.Int{p.params.EC().Params().P, p.params.EC().Params().N, p.params.EC().Params().Gx, p.params.EC().Params().Gy} // ec curve
-	// ssidList = append(ssidList, p.params.Parties().IDs().Keys()...)
-	// ssidList = append(ssidList, big.NewInt(int64(p.number))) // round number
-	// ssidList = append(ssidList, p.temp.ssidNonce)
-	// ssid := common.SHA512_256i(ssidList...).Bytes()
+// auxContext binds the aux info proofs of party j to this session and the final srid
+func (p *LocalParty) auxContext(j int) []byte {
+	return common.SHA512_256(p.temp.ssid, p.temp.srid, []byte(strconv.Itoa(j)))
+}
 
-	return []byte("eddsacmp-keygen"), nil
+// get ssid from the session manifest and local params
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	return p.manifest.SSID(p.params.EC(), p.params.Parties().IDs().Keys()), nil
 }
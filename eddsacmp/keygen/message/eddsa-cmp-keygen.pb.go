@@ -27,6 +27,8 @@ type KGRound1Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// X25519 key of the sender, the vss shares dealt to it are sealed to this key
+	EncPub []byte `protobuf:"bytes,2,opt,name=enc_pub,json=encPub,proto3" json:"enc_pub,omitempty"`
 }
 
 func (x *KGRound1Message) Reset() {
@@ -68,6 +70,13 @@ func (x *KGRound1Message) GetCommitment() []byte {
 	return nil
 }
 
+func (x *KGRound1Message) GetEncPub() []byte {
+	if x != nil {
+		return x.EncPub
+	}
+	return nil
+}
+
 // Represents a BROADCAST message sent to each party during Round 2 of the EDDSA TSS keygen protocol.
 type KGRound2Message struct {
 	state         protoimpl.MessageState
@@ -85,6 +94,10 @@ type KGRound2Message struct {
 	PaillierN   []byte `protobuf:"bytes,9,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
 	PedersenS   []byte `protobuf:"bytes,10,opt,name=pedersen_s,json=pedersenS,proto3" json:"pedersen_s,omitempty"`
 	PedersenT   []byte `protobuf:"bytes,11,opt,name=pedersen_t,json=pedersenT,proto3" json:"pedersen_t,omitempty"`
+	// Feldman commitments to the coefficients 1..t-1 of the dealt polynomial, flattened as x, y
+	Vs [][]byte `protobuf:"bytes,12,rep,name=vs,proto3" json:"vs,omitempty"`
+	// the chain code share of the sender, committed to in round 1
+	ChainCode []byte `protobuf:"bytes,13,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`
 }
 
 func (x *KGRound2Message) Reset() {
@@ -196,6 +209,78 @@ func (x *KGRound2Message) GetPedersenT() []byte {
 	return nil
 }
 
+func (x *KGRound2Message) GetVs() [][]byte {
+	if x != nil {
+		return x.Vs
+	}
+	return nil
+}
+
+func (x *KGRound2Message) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the EDDSA TSS keygen protocol.
+type KGRound2Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the vss share, sealed to the X25519 key the recipient sent in round 1
+	SealedShare []byte `protobuf:"bytes,1,opt,name=sealed_share,json=sealedShare,proto3" json:"sealed_share,omitempty"`
+	// index of the recipient
+	To int32 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *KGRound2Message2) Reset() {
+	*x = KGRound2Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KGRound2Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KGRound2Message2) ProtoMessage() {}
+
+func (x *KGRound2Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KGRound2Message2.ProtoReflect.Descriptor instead.
+func (*KGRound2Message2) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_keygen_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KGRound2Message2) GetSealedShare() []byte {
+	if x != nil {
+		return x.SealedShare
+	}
+	return nil
+}
+
+func (x *KGRound2Message2) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
 // Represents a BROADCAST message sent during Round 3 of the EDDSA TSS keygen protocol.
 type KGRound3Message struct {
 	state         protoimpl.MessageState
@@ -210,7 +295,7 @@ type KGRound3Message struct {
 func (x *KGRound3Message) Reset() {
 	*x = KGRound3Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[2]
+		mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -223,7 +308,7 @@ func (x *KGRound3Message) String() string {
 func (*KGRound3Message) ProtoMessage() {}
 
 func (x *KGRound3Message) ProtoReflect() protoreflect.Message {
-	mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[2]
+	mi := &file_protob_eddsa_cmp_keygen_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -236,7 +321,7 @@ func (x *KGRound3Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KGRound3Message.ProtoReflect.Descriptor instead.
 func (*KGRound3Message) Descriptor() ([]byte, []int) {
-	return file_protob_eddsa_cmp_keygen_proto_rawDescGZIP(), []int{2}
+	return file_protob_eddsa_cmp_keygen_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *KGRound3Message) GetSchProof() []byte {
@@ -266,39 +351,48 @@ var file_protob_eddsa_cmp_keygen_proto_rawDesc = []byte{
 	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x63,
 	0x6d, 0x70, 0x2d, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x1d, 0x6c, 0x65, 0x67, 0x65, 0x6e, 0x64, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
-	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0x31,
+	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0x4a,
 	0x0a, 0x0f, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0xc7, 0x02, 0x0a, 0x0f, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72,
-	0x74, 0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
-	0x70, 0x61, 0x72, 0x74, 0x79, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x72,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x72, 0x69, 0x64, 0x12, 0x1c,
-	0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x78, 0x5f, 0x78, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x58, 0x58, 0x12, 0x1c, 0x0a, 0x0a,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x78, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x08, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x58, 0x59, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x58, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x79, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x59,
-	0x12, 0x0c, 0x0a, 0x01, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x75, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x5f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x09, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x53, 0x12, 0x1d, 0x0a, 0x0a,
-	0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x5f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x54, 0x22, 0x68, 0x0a, 0x0f, 0x4b,
-	0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x63, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x73, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
-	0x6f, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
-	0x6d, 0x6f, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6d, 0x5f,
-	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6d,
-	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x11, 0x5a, 0x0f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x63, 0x6d,
-	0x70, 0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x63, 0x50, 0x75, 0x62, 0x22, 0xf6, 0x02, 0x0a, 0x0f, 0x4b,
+	0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73,
+	0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x79, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x79, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x72, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x73, 0x72, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x5f, 0x78, 0x5f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x58, 0x58, 0x12, 0x1c, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x78, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x58, 0x59, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x58, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x59, 0x12, 0x0c, 0x0a, 0x01, 0x75, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x75, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x5f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69,
+	0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73,
+	0x65, 0x6e, 0x5f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x65, 0x64, 0x65,
+	0x72, 0x73, 0x65, 0x6e, 0x53, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65,
+	0x6e, 0x5f, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x65, 0x64, 0x65, 0x72,
+	0x73, 0x65, 0x6e, 0x54, 0x12, 0x0e, 0x0a, 0x02, 0x76, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x02, 0x76, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43,
+	0x6f, 0x64, 0x65, 0x22, 0x45, 0x0a, 0x10, 0x4b, 0x47, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x61, 0x6c, 0x65,
+	0x64, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73,
+	0x65, 0x61, 0x6c, 0x65, 0x64, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x68, 0x0a, 0x0f, 0x4b, 0x47,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x63, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x73, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f,
+	0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d,
+	0x6f, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6d, 0x5f, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6d, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x42, 0x11, 0x5a, 0x0f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70,
+	0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -313,11 +407,12 @@ func file_protob_eddsa_cmp_keygen_proto_rawDescGZIP() []byte {
 	return file_protob_eddsa_cmp_keygen_proto_rawDescData
 }
 
-var file_protob_eddsa_cmp_keygen_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_protob_eddsa_cmp_keygen_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_protob_eddsa_cmp_keygen_proto_goTypes = []interface{}{
-	(*KGRound1Message)(nil), // 0: legend.tsslib.eddsacmp.keygen.KGRound1Message
-	(*KGRound2Message)(nil), // 1: legend.tsslib.eddsacmp.keygen.KGRound2Message
-	(*KGRound3Message)(nil), // 2: legend.tsslib.eddsacmp.keygen.KGRound3Message
+	(*KGRound1Message)(nil),  // 0: legend.tsslib.eddsacmp.keygen.KGRound1Message
+	(*KGRound2Message)(nil),  // 1: legend.tsslib.eddsacmp.keygen.KGRound2Message
+	(*KGRound2Message2)(nil), // 2: legend.tsslib.eddsacmp.keygen.KGRound2Message2
+	(*KGRound3Message)(nil),  // 3: legend.tsslib.eddsacmp.keygen.KGRound3Message
 }
 var file_protob_eddsa_cmp_keygen_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -358,6 +453,18 @@ func file_protob_eddsa_cmp_keygen_proto_init() {
 			}
 		}
 		file_protob_eddsa_cmp_keygen_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KGRound2Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_cmp_keygen_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KGRound3Message); i {
 			case 0:
 				return &v.state
@@ -376,7 +483,7 @@ func file_protob_eddsa_cmp_keygen_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_eddsa_cmp_keygen_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -12,25 +12,33 @@ import (
 	paillierzkproof "tss_sdk/crypto/alice/zkproof/paillier"
 	"tss_sdk/crypto/modproof"
 	"tss_sdk/crypto/paillier"
+	"tss_sdk/crypto/vss"
 	"tss_sdk/tss"
 )
 
 // ----- //
 
-func NewKGRound1Message(from *tss.PartyID, hash []byte) tss.ParsedMessage {
+func NewKGRound1Message(from *tss.PartyID, hash []byte, encPub *[32]byte) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &KGRound1Message{
 		Commitment: hash,
+		EncPub:     encPub[:],
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *KGRound1Message) ValidateBasic() bool {
-	return m != nil && common.NonEmptyBytes(m.GetCommitment())
+	return m != nil && common.NonEmptyBytes(m.GetCommitment()) && len(m.GetEncPub()) == 32
+}
+
+func (m *KGRound1Message) UnmarshalEncPub() *[32]byte {
+	encPub := new([32]byte)
+	copy(encPub[:], m.GetEncPub())
+	return encPub
 }
 
 // ----- //
@@ -42,11 +50,20 @@ func NewKGRound2Message(
 	pubX *crypto.ECPoint,
 	commitmentA *crypto.ECPoint,
 	u []byte,
-) tss.ParsedMessage {
+	vs vss.Vs,
+	paillierPK *paillier.PublicKey,
+	pedersenPK *pailliera.PedPubKey,
+	chainCode []byte,
+) (tss.ParsedMessage, error) {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
+	// vs[0] is pubX, which is carried on its own
+	vsFlat, err := crypto.FlattenECPoints(vs[1:])
+	if err != nil {
+		return nil, err
+	}
 	content := &KGRound2Message{
 		Ssid:        ssid,
 		Srid:        srid,
@@ -55,9 +72,14 @@ func NewKGRound2Message(
 		CommitmentX: commitmentA.X().Bytes(),
 		CommitmentY: commitmentA.Y().Bytes(),
 		U:           u,
+		Vs:          common.BigIntsToBytes(vsFlat),
+		PaillierN:   paillierPK.N.Bytes(),
+		PedersenS:   pedersenPK.S.Bytes(),
+		PedersenT:   pedersenPK.T.Bytes(),
+		ChainCode:   chainCode,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
-	return tss.NewMessage(meta, content, msg)
+	return tss.NewMessage(meta, content, msg), nil
 }
 
 func (m *KGRound2Message) ValidateBasic() bool {
@@ -68,15 +90,21 @@ func (m *KGRound2Message) ValidateBasic() bool {
 		common.NonEmptyBytes(m.GetCommitmentY()) &&
 		common.NonEmptyBytes(m.GetSrid()) &&
 		common.NonEmptyBytes(m.GetSsid()) &&
-		common.NonEmptyBytes(m.GetU())
+		common.NonEmptyBytes(m.GetU()) &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetPedersenS()) &&
+		common.NonEmptyBytes(m.GetPedersenT()) &&
+		common.NonEmptyBytes(m.GetChainCode())
 }
 
 func (m *KGRound2Message) UnmarshalPaillierPK() *paillier.PublicKey {
 	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
 }
 
+// UnmarshalPedersenPK returns the ring-Pedersen parameters of the sender, which share the Paillier modulus
 func (m *KGRound2Message) UnmarshalPedersenPK() *pailliera.PedPubKey {
 	return &pailliera.PedPubKey{
+		N: new(big.Int).SetBytes(m.GetPaillierN()),
 		S: new(big.Int).SetBytes(m.GetPedersenS()),
 		T: new(big.Int).SetBytes(m.GetPedersenT()),
 	}
@@ -94,6 +122,22 @@ func (m *KGRound2Message) UnmarshalPubXj(ec elliptic.Curve) (*crypto.ECPoint, er
 	return publicX, nil
 }
 
+// UnmarshalVs returns the Feldman commitments of the sender, starting with its public share pubX
+func (m *KGRound2Message) UnmarshalVs(ec elliptic.Curve) (vss.Vs, error) {
+	pubX, err := m.UnmarshalPubXj(ec)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.GetVs()) == 0 {
+		return vss.Vs{pubX}, nil
+	}
+	points, err := crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetVs()))
+	if err != nil {
+		return nil, err
+	}
+	return append(vss.Vs{pubX}, points...), nil
+}
+
 type CmpKeyGenerationPayload struct {
 	// Schnorr ZKP
 	CommitedA *crypto.ECPoint
@@ -102,6 +146,9 @@ type CmpKeyGenerationPayload struct {
 	Ssid []byte
 	Srid []byte
 	U    []byte
+
+	// chain code share, the chain codes of every party make up the derivation tree of the key
+	ChainCode []byte
 }
 
 func (m *KGRound2Message) UnmarshalPayload(ec elliptic.Curve) (*CmpKeyGenerationPayload, error) {
@@ -119,28 +166,64 @@ func (m *KGRound2Message) UnmarshalPayload(ec elliptic.Curve) (*CmpKeyGeneration
 		Ssid:      m.GetSsid(),
 		Srid:      m.GetSrid(),
 		U:         m.GetU(),
+		ChainCode: m.GetChainCode(),
 	}, nil
 }
 
 // ----- //
 
+func NewKGRound2Message2(
+	to, from *tss.PartyID,
+	sealedShare []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &KGRound2Message2{
+		SealedShare: sealedShare,
+		To:          int32(to.Index),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *KGRound2Message2) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetSealedShare())
+}
+
+// ----- //
+
 func NewKGRound3Message(
 	from *tss.PartyID,
 	schProof []byte,
-) tss.ParsedMessage {
+	modProof *modproof.ProofMod,
+	prmProof *paillierzkproof.RingPederssenParameterMessage,
+) (tss.ParsedMessage, error) {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
+	modPfBzs := modProof.Bytes()
+	prmPfBzs, err := proto.Marshal(prmProof)
+	if err != nil {
+		return nil, err
+	}
 	content := &KGRound3Message{
 		SchProof: schProof,
+		ModProof: modPfBzs[:],
+		PrmProof: prmPfBzs,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
-	return tss.NewMessage(meta, content, msg)
+	return tss.NewMessage(meta, content, msg), nil
 }
 
 func (m *KGRound3Message) ValidateBasic() bool {
-	return m != nil && common.NonEmptyBytes(m.GetSchProof())
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSchProof()) &&
+		common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+		common.NonEmptyBytes(m.GetPrmProof())
 }
 
 func (m *KGRound3Message) UnmarshalSchProof() *big.Int {
@@ -13,6 +13,7 @@ var (
 	_ = []tss.MessageContent{
 		(*msg.KGRound1Message)(nil),
 		(*msg.KGRound2Message)(nil),
+		(*msg.KGRound2Message2)(nil),
 		(*msg.KGRound3Message)(nil),
 	}
 )
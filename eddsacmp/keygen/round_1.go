@@ -7,25 +7,44 @@ import "C"
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 
 	"tss_sdk/common"
 	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/vss"
 	m "tss_sdk/eddsacmp/keygen/message"
 	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
 )
 
 type KeygenExecResult struct {
 	Ok           bool   `json:"ok"`
 	Err          string `json:"error"`
 	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
 }
 
 type KeygenResult struct {
-	Ok  bool   `json:"ok"`
-	Err string `json:"error"`
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *KeygenExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *KeygenResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
 }
 
 func KeygenRound1Exec(key string) (result KeygenExecResult) {
@@ -43,7 +62,6 @@ func KeygenRound1Exec(key string) (result KeygenExecResult) {
 	i := Pi.Index
 	common.Logger.Infof("party: %d, party_1 start", i)
 
-	party.temp.ssidNonce = new(big.Int).SetUint64(0)
 	ssid, err := party.getSSID()
 	if err != nil {
 		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
@@ -54,31 +72,58 @@ func KeygenRound1Exec(key string) (result KeygenExecResult) {
 	if party.save.PrivXi == nil {
 		party.save.PrivXi = common.GetRandomPositiveInt(party.params.PartialKeyRand(), party.params.EC().Params().N)
 	}
-	party.save.PubXj[i] = crypto.ScalarBaseMult(party.params.EC(), party.save.PrivXi)
+
+	ids := party.params.Parties().IDs().Keys()
+	party.save.Ks = ids
+	party.save.ShareID = ids[i]
+
+	// Deal Feldman shares of u_i, n-of-n keys keep u_i as an additive share (a constant polynomial)
+	vssThreshold := party.params.Threshold()
+	if party.additive() {
+		vssThreshold = 1
+	}
+	vs, shares, err := vss.Create(party.params.EC(), vssThreshold, party.save.PrivXi, ids, party.params.PartialKeyRand())
+	if err != nil {
+		common.Logger.Errorf("vss create err: %s", err.Error())
+		result.Err = fmt.Sprintf("vss create err: %s", err.Error())
+		return
+	}
+	party.temp.vs[i] = vs
+	party.temp.shares = shares
+
+	// Generate the Paillier key and ring-Pedersen parameters used by signing
+	paillierSK, pedSK, err := GenerateAuxInfo(party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("generate aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate aux info err: %s", err.Error())
+		return
+	}
+	party.save.PaillierSK = paillierSK
+	party.save.PaillierPKs[i] = &paillierSK.PublicKey
+	party.save.RingPedersenPKs[i] = &pedSK.PedPubKey
+	party.temp.pedSK = pedSK
 
 	party.temp.tau = common.GetRandomPositiveInt(party.params.PartialKeyRand(), party.params.EC().Params().N)
 	party.temp.commitedA = crypto.ScalarBaseMult(party.params.EC(), party.temp.tau)
 
 	party.temp.u, _ = common.GetRandomBytes(party.params.Rand(), 32)
 	party.temp.srid, _ = common.GetRandomBytes(party.params.Rand(), 32)
-
-	ids := party.params.Parties().IDs().Keys()
-	party.save.Ks = ids
-	party.save.ShareID = ids[i]
+	party.temp.chainCode, _ = common.GetRandomBytes(party.params.Rand(), ChainCodeLen)
+	party.save.ChainCodes = make([]*big.Int, len(ids))
+	party.save.ChainCodes[i] = new(big.Int).SetBytes(party.temp.chainCode)
 
 	// Compute V_i
-	hash := common.SHA512_256(
-		ssid,
-		[]byte(strconv.Itoa(i)),
-		party.temp.srid,
-		party.save.PubXj[i].X().Bytes(),
-		party.save.PubXj[i].Y().Bytes(),
-		party.temp.commitedA.X().Bytes(),
-		party.temp.commitedA.Y().Bytes(),
-		party.temp.u,
-	)
-
-	msg := m.NewKGRound1Message(party.PartyID(), hash)
+	hash := party.hashV(i, party.temp.srid, vs, party.temp.commitedA, party.temp.u, &pedSK.PedPubKey, party.temp.chainCode)
+
+	// the shares dealt to us in round 2 are sealed to this key
+	party.temp.encPK, party.temp.encSK, err = box.GenerateKey(party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("generate enc key err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate enc key err: %s", err.Error())
+		return
+	}
+
+	msg := m.NewKGRound1Message(party.PartyID(), hash, party.temp.encPK)
 	msgWireBytes, _, err := msg.WireBytes()
 	if err != nil {
 		common.Logger.Errorf("get msg wire bytes error: %s", key)
@@ -92,6 +137,25 @@ func KeygenRound1Exec(key string) (result KeygenExecResult) {
 	return result
 }
 
+// hashV computes the round 1 commitment V_j of party j
+func (p *LocalParty) hashV(
+	j int,
+	srid []byte,
+	vs vss.Vs,
+	commitedA *crypto.ECPoint,
+	u []byte,
+	pedPK *pailliera.PedPubKey,
+	chainCode []byte,
+) []byte {
+	in := [][]byte{p.temp.ssid, []byte(strconv.Itoa(j)), srid}
+	for _, v := range vs {
+		in = append(in, v.X().Bytes(), v.Y().Bytes())
+	}
+	in = append(in, commitedA.X().Bytes(), commitedA.Y().Bytes(), u)
+	in = append(in, pedPK.N.Bytes(), pedPK.S.Bytes(), pedPK.T.Bytes(), chainCode)
+	return common.SHA512_256(in...)
+}
+
 func KeygenRound1Accept(key string, from int, msgWireBytes string) (result KeygenResult) {
 	party, ok := Parties[key]
 	if !ok {
@@ -106,18 +170,17 @@ func KeygenRound1Accept(key string, from int, msgWireBytes string) (result Keyge
 		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
 		return
 	}
-	party.temp.kgRound1Messages[from] = rMsgBytes
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
-	if _, ok := msg.Content().(*m.KGRound1Message); !ok {
-		result.Err = fmt.Sprintf("not KGRound1Message, err:%s", err.Error())
+	if r1Msg, ok := msg.Content().(*m.KGRound1Message); !ok || !r1Msg.ValidateBasic() {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not KGRound1Message")))
 		return
 	}
+	party.temp.kgRound1Messages[from] = rMsgBytes
 
 	result.Ok = true
 	return
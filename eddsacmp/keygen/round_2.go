@@ -7,10 +7,13 @@ import "C"
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"tss_sdk/common"
 	m "tss_sdk/eddsacmp/keygen/message"
 	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
 )
 
 func KeygenRound2Exec(key string) (result KeygenExecResult) {
@@ -35,17 +38,26 @@ func KeygenRound2Exec(key string) (result KeygenExecResult) {
 		}
 		r1Msg := pMsg.Content().(*m.KGRound1Message)
 		party.temp.V[j] = r1Msg.Commitment
+		party.temp.encPKs[j] = r1Msg.UnmarshalEncPub()
 	}
 
-	msg := m.NewKGRound2Message(
+	msg, err := m.NewKGRound2Message(
 		party.PartyID(),
 		party.temp.ssid,
 		party.temp.srid,
-		party.save.PubXj[i],
+		party.temp.vs[i][0],
 		party.temp.commitedA,
 		party.temp.u,
+		party.temp.vs[i],
+		party.save.PaillierPKs[i],
+		party.save.RingPedersenPKs[i],
+		party.temp.chainCode,
 	)
-	var err error
+	if err != nil {
+		common.Logger.Errorf("new round_2 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_2 msg err: %s", err.Error())
+		return
+	}
 	msgWireBytes, _, err := msg.WireBytes()
 	if err != nil {
 		common.Logger.Errorf("get msg wire bytes error: %s", key)
@@ -54,12 +66,52 @@ func KeygenRound2Exec(key string) (result KeygenExecResult) {
 	}
 	party.temp.kgRound2Messages[i] = msgWireBytes
 
+	// p2p send the vss share to Pj, sealed to its key of round 1 so that only Pj can read it
+	if !party.additive() {
+		for j, Pj := range party.params.Parties().IDs() {
+			sealedShare, err := box.SealAnonymous(nil, party.temp.shares[j].Share.Bytes(), party.temp.encPKs[j], party.params.Rand())
+			if err != nil {
+				common.Logger.Errorf("seal share err: %s, party: %d", err.Error(), j)
+				result.Err = fmt.Sprintf("seal share err: %s, party: %d", err.Error(), j)
+				return
+			}
+			r2msg2 := m.NewKGRound2Message2(Pj, party.PartyID(), sealedShare)
+			msg2WireBytes, _, err := r2msg2.WireBytes()
+			if err != nil {
+				common.Logger.Errorf("get msg wire bytes error: %s", key)
+				result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+				return
+			}
+			party.temp.send.kgRound2Message2s[j] = msg2WireBytes
+			if j == i {
+				party.temp.kgRound2Message2s[i] = msg2WireBytes
+			}
+		}
+	}
+
 	result.Ok = true
 	result.MsgWireBytes = msgWireBytes
 	return result
 
 }
 
+// GetRound2Msg2 returns the p2p share message for Pj, the share is sealed to the key Pj sent in round 1
+func GetRound2Msg2(key string, to int) (result KeygenExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.kgRound2Message2s) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.kgRound2Message2s[to]
+	return
+}
+
 func KeygenRound2Accept(key string, from int, msgWireBytes string) (result KeygenResult) {
 	party, ok := Parties[key]
 	if !ok {
@@ -74,16 +126,19 @@ func KeygenRound2Accept(key string, from int, msgWireBytes string) (result Keyge
 		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
 		return
 	}
-	party.temp.kgRound2Messages[from] = rMsgBytes
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
-	if _, ok := msg.Content().(*m.KGRound2Message); !ok {
-		result.Err = fmt.Sprintf("not KGRound2Message, err:%s", err.Error())
+
+	if _, ok := msg.Content().(*m.KGRound2Message); ok {
+		party.temp.kgRound2Messages[from] = rMsgBytes
+	} else if _, ok := msg.Content().(*m.KGRound2Message2); ok {
+		party.temp.kgRound2Message2s[from] = rMsgBytes
+	} else {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not KGRound2Message")))
 		return
 	}
 
@@ -107,6 +162,10 @@ func KeygenRound2Finish(key string) (result KeygenResult) {
 			result.Err = fmt.Sprintf("msg is null: %d", j)
 			return
 		}
+		if !party.additive() && len(party.temp.kgRound2Message2s[j]) == 0 {
+			result.Err = fmt.Sprintf("msg2 is null: %d", j)
+			return
+		}
 	}
 	result.Ok = true
 	return
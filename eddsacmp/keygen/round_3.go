@@ -8,15 +8,18 @@ import "C"
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"tss_sdk/common"
 	"tss_sdk/crypto/alice/utils"
 	"tss_sdk/crypto/schnorr"
+	"tss_sdk/crypto/vss"
 	m "tss_sdk/eddsacmp/keygen/message"
 	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
 )
 
 func KeygenRound3Exec(key string) (result KeygenExecResult) {
@@ -39,8 +42,7 @@ func KeygenRound3Exec(key string) (result KeygenExecResult) {
 
 		pMsg, err := tss.ParseWireMsg(party.temp.kgRound2Messages[j])
 		if err != nil {
-			common.Logger.Errorf("msg error, parse wire msg fail, err: %s, j: %d, bytes: %v", err.Error(), j, party.temp.kgRound2Messages[j])
-			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err: %s, j: %d, bytes: %v", err.Error(), j, party.temp.kgRound2Messages[j])
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)))
 			return
 		}
 
@@ -48,50 +50,88 @@ func KeygenRound3Exec(key string) (result KeygenExecResult) {
 
 		party.temp.payload[j], err = r2Msg.UnmarshalPayload(party.params.EC())
 		if err != nil {
-			result.Err = fmt.Sprintf("unmarshal r2msg payload err:%s", err.Error())
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal r2msg payload err:%s", err.Error())))
 			return
 		}
-		party.save.PubXj[j], err = r2Msg.UnmarshalPubXj(party.params.EC())
+		party.temp.vs[j], err = r2Msg.UnmarshalVs(party.params.EC())
 		if err != nil {
-			result.Err = fmt.Sprintf("unmarshal r2msg pubxj err:%s", err.Error())
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal r2msg vs err:%s", err.Error())))
+			return
+		}
+		if len(party.temp.vs[j]) != len(party.temp.vs[i]) {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("vs length err: %d, party: %d", len(party.temp.vs[j]), j)))
 			return
 		}
 
 		if !bytes.Equal(party.temp.payload[j].Ssid, party.temp.ssid) {
-			common.Logger.Errorf("payload.ssid != round.temp.ssid, party: %d", j)
-			result.Err = fmt.Sprintf("payload.ssid != round.temp.ssid, party: %d", j)
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
 			return
 		}
 
-		v := common.SHA512_256(
-			party.temp.ssid,
-			[]byte(strconv.Itoa(j)),
-			party.temp.payload[j].Srid,
-			party.save.PubXj[j].X().Bytes(),
-			party.save.PubXj[j].Y().Bytes(),
-			party.temp.payload[j].CommitedA.X().Bytes(),
-			party.temp.payload[j].CommitedA.Y().Bytes(),
-			party.temp.payload[j].U,
-		)
+		paillierPK, pedPK := r2Msg.UnmarshalPaillierPK(), r2Msg.UnmarshalPedersenPK()
+		v := party.hashV(j, party.temp.payload[j].Srid, party.temp.vs[j], party.temp.payload[j].CommitedA, party.temp.payload[j].U, pedPK, party.temp.payload[j].ChainCode)
 
 		// Verify commited V_i
 		if !bytes.Equal(v, party.temp.V[j]) {
-			common.Logger.Errorf("hash != V, party: %d", j)
-			result.Err = fmt.Sprintf("hash != V, party: %d", j)
+			result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("hash != V, party: %d", j)))
+			return
+		}
+		if len(party.temp.payload[j].ChainCode) != ChainCodeLen {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("chain code length err: %d, party: %d", len(party.temp.payload[j].ChainCode), j)))
 			return
 		}
+		party.save.ChainCodes[j] = new(big.Int).SetBytes(party.temp.payload[j].ChainCode)
+
+		// verified against the proofs in round 4
+		party.save.PaillierPKs[j] = paillierPK
+		party.save.RingPedersenPKs[j] = pedPK
+
+		// Verify the vss share Pj dealt to us
+		if !party.additive() {
+			pMsg, err := tss.ParseWireMsg(party.temp.kgRound2Message2s[j])
+			if err != nil {
+				result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("msg error, parse wire msg2 fail, err: %s, j: %d", err.Error(), j)))
+				return
+			}
+			r2msg2, ok := pMsg.Content().(*m.KGRound2Message2)
+			if !ok || !r2msg2.ValidateBasic() {
+				result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("not KGRound2Message2, party: %d", j)))
+				return
+			}
+			if int(r2msg2.GetTo()) != i {
+				result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("KGRound2Message2 to party %d, party: %d", r2msg2.GetTo(), j)))
+				return
+			}
+			plaintext, ok := box.OpenAnonymous(nil, r2msg2.GetSealedShare(), party.temp.encPK, party.temp.encSK)
+			if !ok {
+				result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("open sealed share failed, party: %d", j)))
+				return
+			}
+			share := &vss.Share{
+				Threshold: party.params.Threshold(),
+				ID:        party.save.ShareID,
+				Share:     new(big.Int).SetBytes(plaintext),
+			}
+			if !share.Verify(party.params.EC(), party.params.Threshold(), party.temp.vs[j]) {
+				result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("vss share verify failed, party: %d", j)))
+				return
+			}
+			party.temp.receivedShares[j] = share.Share
+		}
 
 		// Set srid as xor of all party's srid_i
 		party.temp.srid = utils.Xor(party.temp.srid, party.temp.payload[j].Srid)
 	}
 
+	party.temp.receivedShares[i] = party.temp.shares[i].Share
+
 	challenge := common.RejectionSample(
 		party.params.EC().Params().N,
 		common.SHA512_256i_TAGGED(
 			append(party.temp.ssid, party.temp.srid...),
 			big.NewInt(int64(i)),
-			party.save.PubXj[i].X(),
-			party.save.PubXj[i].Y(),
+			party.temp.vs[i][0].X(),
+			party.temp.vs[i][0].Y(),
 			party.temp.commitedA.X(),
 			party.temp.commitedA.Y(),
 		),
@@ -100,8 +140,21 @@ func KeygenRound3Exec(key string) (result KeygenExecResult) {
 	// Generate schnorr proof
 	schProof := schnorr.Prove(party.params.EC().Params().N, party.temp.tau, challenge, party.save.PrivXi)
 
+	// Prove the Paillier key and ring-Pedersen parameters
+	modProof, prmProof, err := ProveAuxInfo(party.auxContext(i), party.save.PaillierSK, party.temp.pedSK, party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("prove aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("prove aux info err: %s", err.Error())
+		return
+	}
+
 	// BROADCAST proofs
-	bmsg := m.NewKGRound3Message(party.PartyID(), schProof.Proof.Bytes())
+	bmsg, err := m.NewKGRound3Message(party.PartyID(), schProof.Proof.Bytes(), modProof, prmProof)
+	if err != nil {
+		common.Logger.Errorf("new round_3 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_3 msg err: %s", err.Error())
+		return
+	}
 
 	msgWireBytes, _, err := bmsg.WireBytes()
 	if err != nil {
@@ -130,18 +183,17 @@ func KeygenRound3Accept(key string, from int, msgWireBytes string) (result Keyge
 		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
 		return
 	}
-	party.temp.kgRound3Messages[from] = rMsgBytes
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
 	if _, ok := msg.Content().(*m.KGRound3Message); !ok {
-		result.Err = fmt.Sprintf("not KGRound3Message, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not KGRound3Message")))
 		return
 	}
+	party.temp.kgRound3Messages[from] = rMsgBytes
 
 	result.Ok = true
 	return
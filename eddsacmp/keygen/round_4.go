@@ -11,6 +11,7 @@ import (
 	"math/big"
 
 	"tss_sdk/common"
+	"tss_sdk/crypto"
 	"tss_sdk/crypto/schnorr"
 	m "tss_sdk/eddsacmp/keygen/message"
 	"tss_sdk/tss"
@@ -37,8 +38,7 @@ func KeygenRound4Exec(key string) (result KeygenExecResult) {
 
 		pMsg, err := tss.ParseWireMsg(party.temp.kgRound3Messages[j])
 		if err != nil {
-			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 			return
 		}
 
@@ -47,39 +47,92 @@ func KeygenRound4Exec(key string) (result KeygenExecResult) {
 			common.SHA512_256i_TAGGED(
 				append(party.temp.ssid, party.temp.srid...),
 				big.NewInt(int64(j)),
-				party.save.PubXj[j].X(),
-				party.save.PubXj[j].Y(),
+				party.temp.vs[j][0].X(),
+				party.temp.vs[j][0].Y(),
 				party.temp.payload[j].CommitedA.X(),
 				party.temp.payload[j].CommitedA.Y(),
 			),
 		)
 
-		schProof := schnorr.Proof{Proof: pMsg.Content().(*m.KGRound3Message).UnmarshalSchProof()}
+		r3Msg := pMsg.Content().(*m.KGRound3Message)
+		schProof := schnorr.Proof{Proof: r3Msg.UnmarshalSchProof()}
 
-		if !schProof.Verify(party.temp.payload[j].CommitedA, party.save.PubXj[j], challenge) {
-			common.Logger.Errorf("schnorr proof verify failed, party: %d", j)
-			result.Err = fmt.Sprintf("schnorr proof verify failed, party: %d", j)
+		if !schProof.Verify(party.temp.payload[j].CommitedA, party.temp.vs[j][0], challenge) {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("schnorr proof verify failed, party: %d", j)))
+			return
+		}
+
+		modProof, err := r3Msg.UnmarshalModProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal mod proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		prmProof, err := r3Msg.UnmarshalPrmProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal prm proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		if err := VerifyAuxInfo(party.auxContext(j), party.save.PaillierPKs[j], party.save.RingPedersenPKs[j], modProof, prmProof); err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("aux info verify failed: %s, party: %d", err.Error(), j)))
 			return
 		}
 	}
 
 	// Compute and SAVE the EdDSA public key
-	eddsaPubKey := party.save.PubXj[0]
+	eddsaPubKey := party.temp.vs[0][0]
 	var err error
-	for j, pubx := range party.save.PubXj {
-		common.Logger.Infof("%d, pubkey: (%d, %d)", j, pubx.X(), pubx.Y())
+	for j, vs := range party.temp.vs {
+		common.Logger.Infof("%d, pubkey: (%d, %d)", j, vs[0].X(), vs[0].Y())
 		if j == 0 {
 			continue
 		}
-		eddsaPubKey, err = eddsaPubKey.Add(pubx)
+		eddsaPubKey, err = eddsaPubKey.Add(vs[0])
 		if err != nil {
-			common.Logger.Errorf("calc pubkey failed, party: %d", j)
-			result.Err = fmt.Sprintf("calc pubkey failed, party: %d", j)
+			result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc pubkey failed, party: %d", j)))
 			return
 		}
 	}
 	party.save.EdDSAPub = eddsaPubKey
 
+	if party.additive() {
+		for j, vs := range party.temp.vs {
+			party.save.PubXj[j] = vs[0]
+		}
+	} else {
+		// x_i = sum of the shares dealt to us, X_j = sum of the commitments evaluated at k_j
+		modN := common.ModInt(party.params.EC().Params().N)
+		xi := big.NewInt(0)
+		for _, share := range party.temp.receivedShares {
+			xi = modN.Add(xi, share)
+		}
+		party.save.PrivXi = xi
+
+		for k, kk := range party.save.Ks {
+			var Xk *crypto.ECPoint
+			for j, vs := range party.temp.vs {
+				v, err := vs.Evaluate(kk)
+				if err != nil {
+					result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("evaluate vs failed, party: %d", j)))
+					return
+				}
+				if Xk == nil {
+					Xk = v
+					continue
+				}
+				if Xk, err = Xk.Add(v); err != nil {
+					result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc pubxj failed, party: %d", j)))
+					return
+				}
+			}
+			party.save.PubXj[k] = Xk
+		}
+		if !party.save.PubXj[i].Equals(crypto.ScalarBaseMult(party.params.EC(), xi)) {
+			// every share was checked against its dealer's commitments in round 3, so no one is to blame
+			result.abort(tss.NewError(fmt.Errorf("x_i * G != X_i, party: %d", i), party.taskName, party.number, party.PartyID()))
+			return
+		}
+	}
+
 	saveBytes, err := json.Marshal(party.save)
 	if err != nil {
 		common.Logger.Errorf("round_4 save err: %s", err.Error())
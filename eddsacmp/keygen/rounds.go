@@ -6,6 +6,9 @@ import (
 
 const (
 	TaskName = "eddsa-cmp-keygen"
+
+	// ChainCodeLen is the length in bytes of the chain code share of each party
+	ChainCodeLen = 32
 )
 
 type (
@@ -8,6 +8,7 @@ import (
 	"tss_sdk/common"
 	"tss_sdk/crypto"
 	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/ckd"
 	"tss_sdk/crypto/paillier"
 	"tss_sdk/tss"
 )
@@ -28,10 +29,22 @@ type (
 		PubXj []*crypto.ECPoint // Xj
 
 		// used for assertions and derive child
-		EdDSAPub *crypto.ECPoint // y
+		EdDSAPub *crypto.ECPoint // y, the group public key whatever the curve
+
+		// number of parties required to sign; 0 for keys saved before threshold keygen
+		Threshold int
+
+		// PrivXi is a Shamir share, set by threshold keygen and resharing even when Threshold == len(Ks)
+		Shamir bool `json:",omitempty"`
+
+		// child key derivation scheme, ckd.SchemeBip32 unless converted by SetDerivationScheme
+		Derivation string `json:",omitempty"`
 	}
 
 	LocalRefreshSaveData struct {
+		// our own Paillier key, its modulus is also our ring-Pedersen modulus
+		PaillierSK *paillier.PrivateKey
+
 		PaillierPKs     []*paillier.PublicKey
 		RingPedersenPKs []*pailliera.PedPubKey
 	}
@@ -43,6 +56,83 @@ type (
 	}
 )
 
+// PubKey returns the group public key whatever the curve: EdDSAPub also holds the ECDSA and Schnorr
+// public keys of secp256k1 keys
+func (save LocalKeygenSavaData) PubKey() *crypto.ECPoint {
+	return save.EdDSAPub
+}
+
+// SignThreshold returns the number of parties required to sign with this key
+func (save LocalKeygenSavaData) SignThreshold() int {
+	if save.Threshold == 0 {
+		return len(save.Ks)
+	}
+	return save.Threshold
+}
+
+// IsAdditive reports whether PrivXi is an n-of-n additive share rather than a Shamir share
+func (save LocalKeygenSavaData) IsAdditive() bool {
+	return !save.Shamir && save.SignThreshold() == len(save.Ks)
+}
+
+// SingleChainCode reports whether the key derives from EdDSAPub and the one group chain code in ChainCodes,
+// like a plain BIP 32 wallet for secp256k1 keys, rather than with a chain code per keygen party
+func (save LocalKeygenSavaData) SingleChainCode() bool {
+	return len(save.ChainCodes) == 1
+}
+
+// ChildTweak returns the scalar the group key is tweaked by for a non-hardened path: the sum of the
+// tweaks of every chain code, so child EdDSAPub = EdDSAPub + tweak*G however the key is shared.
+// n-of-n keygen keys apply the tweak of ChainCodes[i] to share i, or the whole tweak to share 0 with a
+// single chain code; Shamir shares all apply the whole tweak. Keys over secp256k1 use BIP 32 tweaks,
+// keys with the BIP32-Ed25519 scheme use its tweaks from the single chain code.
+func (save LocalKeygenSavaData) ChildTweak(path string) (*big.Int, error) {
+	return ckd.GroupChildTweak(save.EdDSAPub, save.ChainCodes, save.Derivation, path)
+}
+
+// AddTweak adds tweak to the share of party 0 only and tweak*G to PubXj[0], so additive shares, or Shamir
+// shares weighted for signing, add up to the key plus tweak. i is the index of this party among the signers.
+func (save *LocalKeygenSavaData) AddTweak(i int, tweak *big.Int) error {
+	ec := save.EdDSAPub.Curve()
+	if i == 0 {
+		save.PrivXi = common.ModInt(ec.Params().N).Add(save.PrivXi, tweak)
+	}
+	childPubXj, err := save.PubXj[0].Add(crypto.ScalarBaseMult(ec, tweak))
+	if err != nil {
+		common.Logger.Errorf("deriveChildPubKey err: %s", err.Error())
+		return fmt.Errorf("deriveChildPubKey err: %s", err.Error())
+	}
+	save.PubXj[0] = childPubXj
+	return nil
+}
+
+// DeriveChildKeys adds the child tweak of walletPath by AddTweak and returns the child public key;
+// "" keeps the root key. The shares must be additive or weighted for signing.
+func (save *LocalKeygenSavaData) DeriveChildKeys(i int, walletPath string) (*crypto.ECPoint, error) {
+	if walletPath == "" {
+		return save.PubKey(), nil
+	}
+	tweak, err := save.ChildTweak(walletPath)
+	if err != nil {
+		common.Logger.Errorf("deriveChildTweak err: %s", err.Error())
+		return nil, fmt.Errorf("deriveChildTweak err: %s", err.Error())
+	}
+	if err := save.AddTweak(i, tweak); err != nil {
+		return nil, err
+	}
+	childPub, err := save.PubKey().Add(crypto.ScalarBaseMult(save.PubKey().Curve(), tweak))
+	if err != nil {
+		common.Logger.Errorf("deriveChildPubKey err: %s", err.Error())
+		return nil, fmt.Errorf("deriveChildPubKey err: %s", err.Error())
+	}
+	return childPub, nil
+}
+
+// GroupExtendedKey is the watch-only public derivation material of the key
+func (save LocalKeygenSavaData) GroupExtendedKey() (*ckd.GroupExtendedKey, error) {
+	return ckd.NewGroupExtendedKey(save.EdDSAPub, save.PubXj, save.ChainCodes, save.Derivation)
+}
+
 func NewLocalPartySaveData(partyCount int) (saveData LocalPartySaveData) {
 	saveData.Ks = make([]*big.Int, partyCount)
 	saveData.PubXj = make([]*crypto.ECPoint, partyCount)
@@ -57,6 +147,19 @@ func NewRefreshSaveData(partyCount int) (saveData LocalRefreshSaveData) {
 	return
 }
 
+// HasAuxInfo reports whether the Paillier and ring-Pedersen keys of every party were produced by keygen
+func (save LocalRefreshSaveData) HasAuxInfo() bool {
+	if len(save.PaillierPKs) == 0 || len(save.PaillierPKs) != len(save.RingPedersenPKs) {
+		return false
+	}
+	for j := range save.PaillierPKs {
+		if save.PaillierPKs[j] == nil || save.RingPedersenPKs[j] == nil {
+			return false
+		}
+	}
+	return true
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) (newData LocalPartySaveData, err error) {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -64,9 +167,11 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 		common.Logger.Infof("id.Key: %d", kj)
 		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
 	}
-	newData = NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalKeygenSavaData = sourceData.LocalKeygenSavaData
-	newData.EdDSAPub = sourceData.EdDSAPub
+	subset := NewLocalPartySaveData(sortedIDs.Len())
+	newData.Ks, newData.PubXj = subset.Ks, subset.PubXj
+	newData.LocalRefreshSaveData = subset.LocalRefreshSaveData
+	newData.PaillierSK = sourceData.PaillierSK
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
@@ -76,8 +181,10 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 		}
 		newData.Ks[j] = sourceData.Ks[savedIdx]
 		newData.PubXj[j] = sourceData.PubXj[savedIdx]
-		newData.PaillierPKs[j] = sourceData.PaillierPKs[savedIdx]
-		newData.RingPedersenPKs[j] = sourceData.RingPedersenPKs[savedIdx]
+		if sourceData.HasAuxInfo() { // signers without Paillier proofs accept keys without aux info
+			newData.PaillierPKs[j] = sourceData.PaillierPKs[savedIdx]
+			newData.RingPedersenPKs[j] = sourceData.RingPedersenPKs[savedIdx]
+		}
 	}
 	return newData, nil
 }
@@ -0,0 +1,62 @@
+package keygen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto/ckd"
+)
+
+// WatchOnlyKey outputs the group extended key of the keygen data, a base58 string. It holds no share:
+// a backend derives the child public keys of the wallet with DeriveWatchOnlyPubKey and nothing else.
+func WatchOnlyKey(keyData string) (result KeygenExecResult) {
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := LocalKeygenSavaData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.EdDSAPub == nil {
+		common.Logger.Errorf("no public key in keygen save data")
+		result.Err = "no public key in keygen save data"
+		return
+	}
+
+	groupKey, err := keys.GroupExtendedKey()
+	if err != nil {
+		common.Logger.Errorf("group extended key err: %s", err.Error())
+		result.Err = fmt.Sprintf("group extended key err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = []byte(groupKey.String())
+	return
+}
+
+// DeriveWatchOnlyPubKey outputs the child group key at the non-hardened walletPath from the group extended
+// key alone: 32 bytes for Ed25519, 33 bytes compressed for secp256k1
+func DeriveWatchOnlyPubKey(groupKey string, walletPath string) (result KeygenExecResult) {
+	k, err := ckd.NewGroupExtendedKeyFromString(groupKey)
+	if err != nil {
+		common.Logger.Errorf("parse group extended key err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse group extended key err: %s", err.Error())
+		return
+	}
+	childPub, err := k.DeriveChildPubKey(walletPath)
+	if err != nil {
+		common.Logger.Errorf("derive child public key err: %s", err.Error())
+		result.Err = fmt.Sprintf("derive child public key err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = k.SerializePubKey(childPub)
+	return
+}
@@ -0,0 +1,29 @@
+package keygen_test
+
+import (
+	"testing"
+
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/test"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchOnly(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	r := keygen.WatchOnlyKey(test.B64(saves[1]))
+	require.True(t, r.Ok, r.Err)
+	watchOnly := string(r.MsgWireBytes)
+	for _, path := range []string{walletPath, "m/44/501/0/0"} {
+		d := keygen.DeriveWatchOnlyPubKey(watchOnly, path)
+		require.True(t, d.Ok, d.Err)
+		require.Equal(t, []byte(test.ChildPub(t, saves[0], path)), d.MsgWireBytes)
+	}
+	root := test.SaveData(t, saves[0]).PubKey()
+	d := keygen.DeriveWatchOnlyPubKey(watchOnly, "")
+	require.True(t, d.Ok, d.Err)
+	require.Equal(t, edwards.NewPublicKey(root.X(), root.Y()).Serialize(), d.MsgWireBytes)
+	test.CheckSig(t, keygen.DeriveWatchOnlyPubKey(watchOnly, "m/44/501/0/0").MsgWireBytes,
+		test.Sign(t, saves, []int{0, 2}, 2, "deadbeef", "44/501/0/0", ""))
+}
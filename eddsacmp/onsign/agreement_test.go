@@ -0,0 +1,82 @@
+package onsign_test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentMismatch(t *testing.T) {
+	r := runDisagreement(t, [2]string{"deadbeef", "deadbeee"}, [2][2]string{})
+	for i := range r {
+		require.False(t, r[i].Ok)
+		require.Equal(t, fmt.Sprint(1-i), r[i].Culprits, r[i].Err)
+	}
+}
+
+func TestSignModeMismatch(t *testing.T) {
+	r := runDisagreement(t, [2]string{"deadbeef", "deadbeef"}, [2][2]string{{onsign.ModeEd25519ctx, "666f6f"}, {onsign.ModeEd25519ctx, "626172"}})
+	for i := range r {
+		require.False(t, r[i].Ok)
+		require.Equal(t, fmt.Sprint(1-i), r[i].Culprits, r[i].Err)
+	}
+}
+
+func TestOnlineCommitmentMismatch(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	runPresign(t, saves, []int{0, 2}, 2, walletPath, "mismatch")
+	keys := []string{"mismatch-online-0", "mismatch-online-1"}
+	for i := range keys {
+		require.True(t, onsign.NewOnlineLocalParty(keys[i], fmt.Sprintf("mismatch-%d", i), "cafe").Ok)
+		defer onsign.RemoveSignParty(keys[i])
+	}
+	require.True(t, onsign.SetSignMode(keys[1], onsign.ModeEd25519ctx, "01").Ok)
+	for i, r := range runOnlineRound(t, keys) {
+		require.False(t, r.Ok)
+		require.Equal(t, fmt.Sprint(1-i), r.Culprits, r.Err)
+		require.Equal(t, tss.ReasonSessionMismatch, r.Reason)
+		require.False(t, onsign.OnsignRound3Exec(keys[i]).Ok)
+	}
+}
+
+// runDisagreement runs round 1 of two signers given their own message and sign mode, and returns their round 2
+func runDisagreement(t *testing.T, msgs [2]string, modes [2][2]string) [2]onsign.OnsignResult {
+	saves := test.KeygenFixtures(t, 3, 2)
+	signers := []int{0, 2}
+	pIDs := make([]string, 2)
+	keys := []string{"disagree-0", "disagree-1"}
+	for k, s := range signers {
+		pIDs[k] = test.SaveData(t, saves[s]).ShareID.String()
+	}
+	manifest := test.Manifest(t, onsign.TaskName, pIDs, nil, 2)
+	for k, s := range signers {
+		require.True(t, onsign.NewLocalParty(keys[k], k, 2, 2, pIDs, msgs[k], test.B64(saves[s]), "", walletPath).Ok)
+		defer onsign.RemoveSignParty(keys[k])
+		require.True(t, onsign.SetSessionManifest(keys[k], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+		if modes[k][0] != "" {
+			require.True(t, onsign.SetSignMode(keys[k], modes[k][0], modes[k][1]).Ok)
+		}
+	}
+	r1 := make([][]byte, 2)
+	for i := range keys {
+		r := onsign.OnSignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r1[i] = r.MsgWireBytes
+	}
+	// the mode is committed in round 1
+	require.False(t, onsign.SetSignMode(keys[0], onsign.ModeEd25519, "").Ok)
+	var results [2]onsign.OnsignResult
+	for i := range keys {
+		j := 1 - i
+		require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(r1[j])).Ok)
+		require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(onsign.GetRound1Msg2(keys[j], i).MsgWireBytes)).Ok)
+		require.True(t, onsign.OnSignRound1Finish(keys[i]).Ok)
+		results[i] = onsign.OnsignRound2Exec(keys[i])
+	}
+	return results
+}
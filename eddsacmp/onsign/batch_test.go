@@ -0,0 +1,27 @@
+package onsign_test
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSign(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	var sigs []*common.SignatureData
+	require.NoError(t, json.Unmarshal(test.Sign(t, saves, []int{0, 2}, 2, "deadbeef,cafe,00ff01", walletPath, ""), &sigs))
+	require.Len(t, sigs, 3)
+	for _, sig := range sigs {
+		require.True(t, ed25519.Verify(pub, sig.M, sig.Signature))
+	}
+	require.Equal(t, []byte{0xca, 0xfe}, sigs[1].M)
+	require.Equal(t, []byte{0x00, 0xff, 0x01}, sigs[2].M)
+	// a single message still gives a single signature
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{1, 2}, 2, "deadbeef", walletPath, ""))
+}
@@ -0,0 +1,47 @@
+package onsign_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/eddsacmp/onsign/message"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignShareCulprit(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	runPresign(t, saves, []int{0, 1, 2}, 2, walletPath, "culprit")
+	keys := []string{"culprit-0", "culprit-1", "culprit-2"}
+	for i := range keys {
+		require.True(t, onsign.NewOnlineLocalParty(keys[i], fmt.Sprintf("culprit-%d", i), "cafe").Ok)
+		defer onsign.RemoveSignParty(keys[i])
+	}
+	for _, r := range runOnlineRound(t, keys) {
+		require.True(t, r.Ok, r.Err)
+	}
+	r3 := make([][]byte, len(keys))
+	for i := range keys {
+		r := onsign.OnsignRound3Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r3[i] = r.MsgWireBytes
+	}
+	// P2 sends a signature share that does not match its nonce and key commitments
+	bad, _, err := message.NewSignRound3Message(tss.NewPartyID("2", "m_2", big.NewInt(3)), []*big.Int{big.NewInt(12345)}).WireBytes()
+	require.NoError(t, err)
+	require.True(t, onsign.OnSignRound3MsgAccept(keys[0], 1, test.B64(r3[1])).Ok)
+	r := onsign.OnSignRound3MsgAccept(keys[0], 2, test.B64(bad))
+	require.False(t, r.Ok)
+	require.Equal(t, "2", r.Culprits, r.Err)
+	require.Equal(t, tss.ReasonBadShare, r.Reason)
+	require.False(t, onsign.OnSignRound3Finish(keys[0]).Ok)
+	// the root API passes the culprits on
+	res := tss_sdk.OnSignRound3MsgAccept(keys[0], 2, test.B64(bad))
+	require.Equal(t, "2", res.Culprits)
+	require.Equal(t, tss.ReasonBadShare, res.Reason)
+}
@@ -0,0 +1,71 @@
+package onsign_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignEcho(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	m := len(saves)
+	pIDs := make([]string, m)
+	keys := make([]string, m)
+	for i := range saves {
+		pIDs[i] = test.SaveData(t, saves[i]).ShareID.String()
+	}
+	manifest := test.Manifest(t, onsign.TaskName, pIDs, nil, 2)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("echo-%d", i)
+		require.True(t, onsign.NewLocalParty(keys[i], i, m, 2, pIDs, "deadbeef", test.B64(saves[i]), "", walletPath).Ok)
+		defer onsign.RemoveSignParty(keys[i])
+		require.True(t, onsign.SetSessionManifest(keys[i], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	r1 := make([][]byte, m)
+	for i := range keys {
+		r := onsign.OnSignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r1[i] = r.MsgWireBytes
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(r1[j])).Ok)
+				require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(onsign.GetRound1Msg2(keys[j], i).MsgWireBytes)).Ok)
+			}
+		}
+		require.True(t, onsign.OnSignRound1Finish(keys[i]).Ok)
+	}
+	echoes := make([][]byte, m)
+	for i := range keys {
+		r := onsign.OnSignEchoExec(keys[i], 1)
+		require.True(t, r.Ok, r.Err)
+		echoes[i] = r.MsgWireBytes
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, onsign.OnSignEchoAccept(keys[i], j, test.B64(echoes[j])).Ok)
+			}
+		}
+		r := onsign.OnSignEchoFinish(keys[i], 1)
+		require.True(t, r.Ok, r.Err)
+	}
+
+	// P0 echoes a different hash to P1
+	forged := tss.NewEchoMessage(tss.NewPartyID("0", "0", big.NewInt(1)), []byte("ssid"), 1, []byte("other"))
+	bz, _, err := forged.WireBytes()
+	require.NoError(t, err)
+	require.True(t, onsign.OnSignEchoAccept(keys[1], 0, test.B64(bz)).Ok)
+	r := onsign.OnSignEchoFinish(keys[1], 1)
+	require.False(t, r.Ok)
+	require.Equal(t, "0", r.Culprits)
+	require.Equal(t, tss.ReasonEchoMismatch, r.Reason)
+	require.False(t, onsign.OnSignEchoFinish(keys[1], 2).Ok)
+}
@@ -9,11 +9,13 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
 	"tss_sdk/common"
+	"tss_sdk/crypto"
 	pailliera "tss_sdk/crypto/alice/paillier"
 	"tss_sdk/crypto/ckd"
 	"tss_sdk/crypto/paillier"
@@ -33,18 +35,23 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		keys   keygen.LocalPartySaveData
-		temp   localTempData
-		data   *common.SignatureData
-		number int
-		ok     []bool
+		keys        keygen.LocalPartySaveData   // keys of the first wallet path
+		pathKeys    []keygen.LocalPartySaveData // keys of every wallet path, one for all messages or one per message
+		walletPaths []string
+		temp        localTempData
+		data        []*common.SignatureData // one per message
+		manifest    *tss.SessionManifest
+		number      int
+		ok          []bool
 	}
 
 	localMessageStore struct {
 		signRound1Message1s,
 		signRound1Message2s,
 		signRound2Messages,
-		signRound3Messages [][]byte // msg.WireBytes()
+		signRound3Messages,
+		signOnlineMessages [][]byte // msg.WireBytes()
+		echo *tss.Echo // echoes of the broadcast rounds
 	}
 
 	sendMessageStore struct {
@@ -56,21 +63,30 @@ type (
 		localMessageStore
 		send sendMessageStore
 
-		// temp data (thrown away after sign) / round 1
-		k            *big.Int
-		rho          *big.Int
-		kCiphertexts []*big.Int
-		m            *big.Int
-		fullBytesLen int
+		// temp data (thrown away after sign) / round 1, one nonce per message of the batch; two with binding,
+		// the d nonces of every message then the e nonces
+		k            []*big.Int
+		rho          []*big.Int
+		kCiphertexts [][]*big.Int // by party, then by message
+		m            [][]byte     // the messages as hex decoded, leading zero bytes included
 
 		// round 2
-		si *[32]byte
+		si []*[32]byte
 
 		// round 3
-		r *big.Int
-
-		ssid      []byte
-		ssidNonce *big.Int
+		encodedR []*[32]byte
+		bigRj    [][]*crypto.ECPoint // by party, then by nonce; checked by the log proofs, ahead of round 3 by a presignature
+		lambda   []*big.Int
+		r        []*big.Int
+		mode     string // set by SetSignMode, "" for pure Ed25519
+		context  []byte
+
+		presign bool // rounds 1 and 2 only, the party ends in Presignatures
+		online  bool // signs with a presignature, round 3 waits for the commitments of the online round
+		binding bool // two nonces per message until bindNonces binds them to the message, see bindNonces
+
+		ssid       []byte
+		commitment []byte // to what every signer signs, checked in round 2 before si is released
 	}
 )
 
@@ -80,11 +96,12 @@ func NewLocalParty(
 	key string,
 	partyIndex int,
 	partyCount int,
+	threshold int, // number of parties required to sign, as given to keygen
 	pIDs []string,
-	msg string, // hex string
+	msg string, // hex string; a batch of messages is comma separated, signed with one nonce each
 	keyData string, // keygen.LocalPartySaveData, base64 string
-	refreshPayload string, // refresh.Payload, hex string
-	walletPath string,
+	refreshPayload string, // refresh.Payload, hex string; empty to use the aux info saved by keygen
+	walletPath string, // comma separated to sign each message of a batch for its own path
 ) (result OnsignResult) {
 	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
 		common.Logger.Errorf("set log level, err: %s", err.Error())
@@ -101,7 +118,7 @@ func NewLocalParty(
 	}
 	ids := tss.SortPartyIDs(uIds)
 	p2pCtx := tss.NewPeerContext(ids)
-	params := tss.NewParameters(tss.Edwards(), p2pCtx, ids[partyIndex], partyCount, partyCount)
+	params := tss.NewParameters(tss.Edwards(), p2pCtx, ids[partyIndex], partyCount, threshold)
 
 	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
 	if err != nil {
@@ -116,52 +133,255 @@ func NewLocalParty(
 		return
 	}
 
+	if threshold != keys.SignThreshold() {
+		common.Logger.Errorf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		result.Err = fmt.Sprintf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		return
+	}
+	if partyCount < threshold || (keys.IsAdditive() && partyCount != len(keys.Ks)) {
+		common.Logger.Errorf("party count err: %d, threshold: %d", partyCount, threshold)
+		result.Err = fmt.Sprintf("party count err: %d, threshold: %d", partyCount, threshold)
+		return
+	}
+
+	// Keys made by an aux info keygen carry their own Paillier and ring-Pedersen keys,
+	// older keys take them from the refresh payload
+	if refreshPayload != "" {
+		if err := parseRefreshPayload(keys, refreshPayload); err != nil {
+			result.Err = err.Error()
+			return
+		}
+	} else if !keys.HasAuxInfo() {
+		common.Logger.Errorf("no aux info in keygen data and no refresh payload")
+		result.Err = "no aux info in keygen data and no refresh payload"
+		return
+	}
+
+	ms, err := parseMessages(msg)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	walletPaths := strings.Split(walletPath, ",")
+	if len(walletPaths) != 1 && len(walletPaths) != len(ms) {
+		common.Logger.Errorf("wallet path count err: %d, msg count: %d", len(walletPaths), len(ms))
+		result.Err = fmt.Sprintf("wallet path count err: %d, msg count: %d", len(walletPaths), len(ms))
+		return
+	}
+	pathKeys := make([]keygen.LocalPartySaveData, len(walletPaths))
+	for l, path := range walletPaths {
+		if pathKeys[l], err = childSigningKeys(*keys, partyIndex, path, params); err != nil {
+			result.Err = err.Error()
+			return
+		}
+		// "m/0/1" and "0/1" are the same key, the commitment of round 1 takes the canonical form
+		indexes, _ := ckd.ParsePath(path)
+		walletPaths[l] = indexes.String()
+	}
+
+	p := &LocalParty{
+		BaseParty:   new(tss.BaseParty),
+		params:      params,
+		keys:        pathKeys[0],
+		pathKeys:    pathKeys,
+		walletPaths: walletPaths,
+		temp:        localTempData{},
+		ok:          make([]bool, partyCount),
+	}
+	// msgs init
+	p.temp.signRound1Message1s = make([][]byte, partyCount)
+	p.temp.signRound1Message2s = make([][]byte, partyCount)
+	p.temp.signRound2Messages = make([][]byte, partyCount)
+	p.temp.signRound3Messages = make([][]byte, partyCount)
+	p.temp.send.signRound1Message2s = make([][]byte, partyCount)
+	p.temp.send.signRound2Messages = make([][]byte, partyCount)
+	p.temp.echo = tss.NewEcho(TaskName, p.PartyID(), p.params.Parties().IDs(), p.broadcastMessages)
+
+	// temp data init
+	p.temp.m = ms
+	p.data = make([]*common.SignatureData, len(p.temp.m))
+	for l := range p.data {
+		p.data[l] = &common.SignatureData{}
+	}
+	p.temp.kCiphertexts = make([][]*big.Int, partyCount)
+
+	SignParties[key] = p
+	result.Ok = true
+	return
+}
+
+// parseMessages reads the comma separated hex messages of a batch
+func parseMessages(msg string) ([][]byte, error) {
+	msgs := strings.Split(msg, ",")
+	ms := make([][]byte, len(msgs))
+	for l, msgHex := range msgs {
+		mBytes, err := hex.DecodeString(msgHex)
+		if err != nil {
+			common.Logger.Errorf("hex decode msg err: %s, msg: %d", err.Error(), l)
+			return nil, fmt.Errorf("hex decode msg err: %s, msg: %d", err.Error(), l)
+		}
+		ms[l] = mBytes
+	}
+	return ms, nil
+}
+
+// childSigningKeys derives the keys at walletPath and reduces them to additive shares of the
+// signing parties; EdDSAPub is set to the child public key
+func childSigningKeys(keys keygen.LocalPartySaveData, partyIndex int, walletPath string, params *tss.Parameters) (keygen.LocalPartySaveData, error) {
+	// the derivation replaces the public shares, the caller's keys are kept
+	keys.PubXj = append([]*crypto.ECPoint{}, keys.PubXj...)
+	if err := DeriveChildKeys(&keys, partyIndex, walletPath); err != nil {
+		return keys, err
+	}
+
+	keyParty, err := keygen.BuildLocalSaveDataSubset(keys, params.Parties().IDs())
+	if err != nil {
+		return keyParty, err
+	}
+	if !keys.IsAdditive() {
+		PrepareForSigning(params.EC(), partyIndex, &keyParty)
+	}
+
+	pkSum := keyParty.PubXj[0]
+	for j, pubx := range keyParty.PubXj {
+		common.Logger.Infof("%d, pubkey: (%d, %d)", j, pubx.X(), pubx.Y())
+		if j == 0 {
+			continue
+		}
+		if pkSum, err = pkSum.Add(pubx); err != nil {
+			common.Logger.Errorf("calc pubkey failed, party: %d", j)
+			return keyParty, fmt.Errorf("calc pubkey failed, party: %d", j)
+		}
+	}
+	keyParty.EdDSAPub = pkSum
+	return keyParty, nil
+}
+
+// nonceCount is the number of nonces of round 1 and 2, two per message when they are bound to it later
+func (p *LocalParty) nonceCount() int {
+	if p.temp.binding {
+		return 2 * len(p.temp.m)
+	}
+	return len(p.temp.m)
+}
+
+// keysOf is the keys of the wallet path of message l
+func (p *LocalParty) keysOf(l int) *keygen.LocalPartySaveData {
+	if len(p.pathKeys) == 1 {
+		return &p.pathKeys[0]
+	}
+	return &p.pathKeys[l]
+}
+
+// signingCommitment commits to the sign mode and context, and to the message hash, wallet path and child
+// public key of every message. A presign party commits before the message is known, so only to the wallet
+// paths and keys, and commits again in the online round once the message is known.
+func (p *LocalParty) signingCommitment() []byte {
+	mode := p.temp.mode
+	if mode == "" {
+		mode = ModeEd25519
+	}
+	in := make([][]byte, 0, 2+4*len(p.temp.m))
+	in = append(in, []byte(mode), p.temp.context)
+	for l := range p.temp.m {
+		path := p.walletPaths[0]
+		if len(p.walletPaths) > 1 {
+			path = p.walletPaths[l]
+		}
+		pub := p.keysOf(l).EdDSAPub
+		in = append(in, common.SHA512_256(p.temp.m[l]), []byte(path), pub.X().Bytes(), pub.Y().Bytes())
+	}
+	return common.SHA512_256(in...)
+}
+
+// DeriveChildKeys replaces the share of party partyIndex and the public shares of every party by
+// those of the child key at walletPath, before the keys are reduced to the signing parties.
+// walletPath is a non-hardened path of any depth, "m/" prefixed or bare; "" keeps the root key.
+func DeriveChildKeys(keys *keygen.LocalPartySaveData, partyIndex int, walletPath string) error {
 	common.Logger.Infof("wallet path: %s", walletPath)
 	common.Logger.Infof("chaincode count: %d", len(keys.ChainCodes))
 	common.Logger.Infof("keys.PubXj count: %d", len(keys.PubXj))
-	parts := strings.Split(walletPath, "/")
-	if len(parts) != 5 {
-		common.Logger.Errorf("wallet path err: %s", walletPath)
-		result.Err = fmt.Sprintf("wallet path err: %s", walletPath)
-		return
+	path, err := ckd.ParsePath(walletPath)
+	if err != nil {
+		common.Logger.Errorf("wallet path err: %s", err.Error())
+		return fmt.Errorf("wallet path err: %s", err.Error())
+	}
+	if path.IsRoot() {
+		return nil
 	}
 
-	// 推导子私钥分片
-	chainCode := keys.ChainCodes[partyIndex].Bytes()
-	deducePubKey := keys.EdDSAPub // 签名权限下发前后不变
-	childPrivKey, _, err := ckd.DeriveEddsaChildPrivKey(
-		keys.PrivXi, keys.PubXj[partyIndex], deducePubKey, chainCode, walletPath)
+	if keys.IsAdditive() && keys.SingleChainCode() {
+		// the whole tweak of the group chain code goes to party 0, as for a plain BIP 32 child of EdDSAPub
+		_, err := keys.DeriveChildKeys(partyIndex, walletPath)
+		return err
+	}
+	if keys.IsAdditive() {
+		// 推导子私钥分片
+		chainCode := ckd.ChainCodeBytes(keys.ChainCodes[partyIndex])
+		deducePubKey := keys.EdDSAPub // 签名权限下发前后不变
+		childPrivKey, _, err := ckd.DeriveEddsaChildPrivKey(
+			keys.PrivXi, keys.PubXj[partyIndex], deducePubKey, chainCode, walletPath)
+		if err != nil {
+			common.Logger.Errorf("deriveChildPrivateKey err: %s", err.Error())
+			return fmt.Errorf("deriveChildPrivateKey err: %s", err.Error())
+		}
+		keys.PrivXi = new(big.Int).SetBytes(childPrivKey[:]) // 替换
+
+		// 推导所有子公钥分片
+		partyLen := len(keys.PubXj)
+		for i := 0; i < partyLen; i++ {
+			childPubkey, err1 := ckd.DeriveEddsaChildPubKey(
+				keys.PubXj[i], deducePubKey, ckd.ChainCodeBytes(keys.ChainCodes[i]), walletPath,
+			)
+			if err1 != nil {
+				common.Logger.Errorf("deriveChildPubKey err: %s", err1.Error())
+				return fmt.Errorf("deriveChildPubKey err: %s", err1.Error())
+			}
+			keys.PubXj[i] = childPubkey // 替换
+		}
+		return nil
+	}
+	return deriveShamirChildKeys(keys, walletPath)
+}
+
+// deriveShamirChildKeys tweaks every Shamir share by the whole child tweak of the key,
+// so that any subset of signers interpolates to the same child key
+func deriveShamirChildKeys(keys *keygen.LocalPartySaveData, walletPath string) error {
+	tweak, err := keys.ChildTweak(walletPath)
 	if err != nil {
-		common.Logger.Errorf("deriveChildPrivateKey err: %s", err.Error())
-		result.Err = fmt.Sprintf("deriveChildPrivateKey err: %s", err.Error())
-		return
+		common.Logger.Errorf("deriveChildTweak err: %s", err.Error())
+		return fmt.Errorf("deriveChildTweak err: %s", err.Error())
 	}
-	keys.PrivXi = new(big.Int).SetBytes(childPrivKey[:]) // 替换
+	modN := common.ModInt(keys.EdDSAPub.Curve().Params().N)
+	keys.PrivXi = modN.Add(keys.PrivXi, tweak)
 
-	// 推导所有子公钥分片
-	partyLen := len(keys.PubXj)
-	for i := 0; i < partyLen; i++ {
-		childPubkey, err1 := ckd.DeriveEddsaChildPubKey(
-			keys.PubXj[i], deducePubKey, keys.ChainCodes[i].Bytes(), walletPath,
-		)
-		if err1 != nil {
+	tweakG := crypto.ScalarBaseMult(keys.EdDSAPub.Curve(), tweak)
+	for j := range keys.PubXj {
+		childPubkey, err := keys.PubXj[j].Add(tweakG)
+		if err != nil {
 			common.Logger.Errorf("deriveChildPubKey err: %s", err.Error())
-			result.Err = fmt.Sprintf("deriveChildPubKey err: %s", err.Error())
-			return
+			return fmt.Errorf("deriveChildPubKey err: %s", err.Error())
 		}
-		keys.PubXj[i] = childPubkey // 替换
+		keys.PubXj[j] = childPubkey
 	}
+	return nil
+}
 
+// parseRefreshPayload reads the Paillier and ring-Pedersen keys of every party from a legacy refresh payload
+func parseRefreshPayload(keys *keygen.LocalPartySaveData, refreshPayload string) error {
 	rfPayload, err := hex.DecodeString(refreshPayload)
 	if err != nil {
 		common.Logger.Errorf("hex decode refresh data fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("hex decode refresh data fail, err:%s", err.Error())
-		return
+		return fmt.Errorf("hex decode refresh data fail, err:%s", err.Error())
 	}
-
-	keys.LocalRefreshSaveData = NewRefreshSaveData(partyCount)
 	j := 1376
-	for i := 0; i < partyCount; i++ {
+	if len(rfPayload) < j+673*len(keys.Ks) {
+		common.Logger.Errorf("refresh data too short: %d", len(rfPayload))
+		return fmt.Errorf("refresh data too short: %d", len(rfPayload))
+	}
+
+	keys.LocalRefreshSaveData = NewRefreshSaveData(len(keys.Ks))
+	for i := 0; i < len(keys.Ks); i++ {
 		keys.LocalRefreshSaveData.PaillierPKs[i] = &paillier.PublicKey{
 			N: new(big.Int).SetBytes(rfPayload[j+33 : j+289]),
 		}
@@ -172,40 +392,31 @@ func NewLocalParty(
 		}
 		j += 673
 	}
+	return nil
+}
 
-	keyParty, err := keygen.BuildLocalSaveDataSubset(*keys, params.Parties().IDs())
-	if err != nil {
-		result.Err = err.Error()
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result OnsignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
 
-	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		keys:      keyParty,
-		temp:      localTempData{},
-		data:      &common.SignatureData{},
-		ok:        make([]bool, partyCount),
-	}
-	// msgs init
-	p.temp.signRound1Message1s = make([][]byte, partyCount)
-	p.temp.signRound1Message2s = make([][]byte, partyCount)
-	p.temp.signRound2Messages = make([][]byte, partyCount)
-	p.temp.signRound3Messages = make([][]byte, partyCount)
-	p.temp.send.signRound1Message2s = make([][]byte, partyCount)
-	p.temp.send.signRound2Messages = make([][]byte, partyCount)
-
-	// temp data init
-	m, err := hex.DecodeString(msg)
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
 	if err != nil {
-		common.Logger.Errorf("hex decode msg err: %s", err.Error())
-		result.Err = fmt.Sprintf("hex decode msg err: %s", err.Error())
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
 		return
 	}
-	p.temp.m = new(big.Int).SetBytes(m)
-	p.temp.kCiphertexts = make([]*big.Int, partyCount)
-
-	SignParties[key] = p
+	if err := sm.Check(TaskName, party.params.Parties().IDs().Keys(), nil, party.params.Threshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
 	result.Ok = true
 	return
 }
@@ -230,6 +441,11 @@ func (p *LocalParty) resetOK() {
 	}
 }
 
+// blame returns an identifiable abort error naming Pj as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.PartyID(), p.params.Parties().IDs()[j])
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -238,26 +454,28 @@ func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
 
-// get ssid from local params
+// get ssid from the session manifest and local params
 func (round *LocalParty) getSSID() ([]byte, error) {
-	// ssidList := []*big.Int{round.params.EC().Params().P, round.params.EC().Params().N, round.params.EC().Params().Gx, round.params.EC().Params().Gy} // ec curve
-	// ssidList = append(ssidList, round.params.Parties().IDs().Keys()...)                                                                              // parties
-	// BigXjList, err := crypto.FlattenECPoints(round.keys.PubXj)
-	// if err != nil {
-	// 	return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
-	// }
-	// ssidList = append(ssidList, BigXjList...)                    // BigXj
-	// ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
-	// ssidList = append(ssidList, round.temp.ssidNonce)
-	// for _, pk := range round.keys.RingPedersenPKs {
-	// 	if pk == nil {
-	// 		return nil, errors.New("found nil pedersen pk")
-	// 	}
-	// 	ssidList = append(ssidList, pk.N)
-	// 	ssidList = append(ssidList, pk.S)
-	// 	ssidList = append(ssidList, pk.T)
-	// }
-	// ssid := common.SHA512_256i(ssidList...).Bytes()
-
-	return []byte("eddsacmp-onsign"), nil
+	if round.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	BigXjList, err := crypto.FlattenECPoints(round.keys.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList := BigXjList // BigXj
+	for _, keys := range round.pathKeys[1:] {
+		childXjList, err := crypto.FlattenECPoints(keys.PubXj)
+		if err != nil {
+			return nil, errors.New("read BigXj failed")
+		}
+		ssidList = append(ssidList, childXjList...)
+	}
+	for _, pk := range round.keys.RingPedersenPKs {
+		if pk == nil {
+			return nil, errors.New("found nil pedersen pk")
+		}
+		ssidList = append(ssidList, pk.N, pk.S, pk.T)
+	}
+	return round.manifest.SSID(round.params.EC(), round.params.Parties().IDs().Keys(), ssidList...), nil
 }
@@ -0,0 +1,60 @@
+package onsign_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestManifestMismatch(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	r := onsign.NewLocalParty("manifest-0", 0, 2, 2, pIDs, "deadbeef", test.B64(saves[0]), "", walletPath)
+	require.True(t, r.Ok, r.Err)
+	defer onsign.RemoveSignParty("manifest-0")
+	wrongTask := test.Manifest(t, keygen.TaskName, pIDs, nil, 2)
+	require.False(t, onsign.SetSessionManifest("manifest-0", wrongTask, test.SignManifest(wrongTask), test.CoordinatorPub).Ok)
+	wrongParties := test.Manifest(t, onsign.TaskName, pIDs[:1], nil, 2)
+	require.False(t, onsign.SetSessionManifest("manifest-0", wrongParties, test.SignManifest(wrongParties), test.CoordinatorPub).Ok)
+	require.False(t, onsign.OnSignRound1Exec("manifest-0").Ok)
+	manifest := test.Manifest(t, onsign.TaskName, pIDs, nil, 2)
+	require.True(t, onsign.SetSessionManifest("manifest-0", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+
+	// a different session nonce gives a different ssid, so the peer message is rejected
+	r = onsign.NewLocalParty("manifest-1", 1, 2, 2, pIDs, "deadbeef", test.B64(saves[1]), "", walletPath)
+	require.True(t, r.Ok, r.Err)
+	defer onsign.RemoveSignParty("manifest-1")
+	other := test.Manifest(t, onsign.TaskName, pIDs, nil, 2)
+	require.True(t, onsign.SetSessionManifest("manifest-1", other, test.SignManifest(other), test.CoordinatorPub).Ok)
+	r0, r1 := onsign.OnSignRound1Exec("manifest-0"), onsign.OnSignRound1Exec("manifest-1")
+	require.True(t, r0.Ok, r0.Err)
+	require.True(t, r1.Ok, r1.Err)
+	require.True(t, onsign.OnSignRound1MsgAccept("manifest-0", 1, test.B64(r1.MsgWireBytes)).Ok)
+	require.True(t, onsign.OnSignRound1MsgAccept("manifest-0", 1, test.B64(onsign.GetRound1Msg2("manifest-1", 0).MsgWireBytes)).Ok)
+	res := onsign.OnsignRound2Exec("manifest-0")
+	require.False(t, res.Ok)
+	require.Contains(t, res.Err, "ssid")
+}
+
+func TestManifestSignature(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	require.True(t, onsign.NewLocalParty("signature-0", 0, 2, 2, pIDs, "deadbeef", test.B64(saves[0]), "", walletPath).Ok)
+	defer onsign.RemoveSignParty("signature-0")
+	manifest, other := test.Manifest(t, onsign.TaskName, pIDs, nil, 2), test.Manifest(t, onsign.TaskName, pIDs, nil, 2)
+	require.False(t, onsign.SetSessionManifest("signature-0", manifest, test.SignManifest(other), test.CoordinatorPub).Ok)
+	require.False(t, onsign.SetSessionManifest("signature-0", manifest, "", test.CoordinatorPub).Ok)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	require.False(t, onsign.SetSessionManifest("signature-0", manifest, test.SignManifest(manifest), hex.EncodeToString(otherPub)).Ok)
+	require.True(t, onsign.SetSessionManifest("signature-0", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+}
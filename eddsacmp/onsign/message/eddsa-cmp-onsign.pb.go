@@ -26,7 +26,9 @@ type SignRound1Message1 struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BigK []byte `protobuf:"bytes,1,opt,name=big_k,json=bigK,proto3" json:"big_k,omitempty"`
+	BigK       [][]byte `protobuf:"bytes,1,rep,name=big_k,json=bigK,proto3" json:"big_k,omitempty"` // one per message of the batch
+	Ssid       []byte   `protobuf:"bytes,2,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Commitment []byte   `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"` // to the sign mode and context, the message hash, wallet path and child public key of every message
 }
 
 func (x *SignRound1Message1) Reset() {
@@ -61,20 +63,34 @@ func (*SignRound1Message1) Descriptor() ([]byte, []int) {
 	return file_protob_eddsa_cmp_onsign_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *SignRound1Message1) GetBigK() []byte {
+func (x *SignRound1Message1) GetBigK() [][]byte {
 	if x != nil {
 		return x.BigK
 	}
 	return nil
 }
 
+func (x *SignRound1Message1) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *SignRound1Message1) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
 // Represents a P2P message sent to all parties during Round 1 of the EDDSA TSS signing protocol.
 type SignRound1Message2 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EncProof []byte `protobuf:"bytes,1,opt,name=enc_proof,json=encProof,proto3" json:"enc_proof,omitempty"`
+	EncProof [][]byte `protobuf:"bytes,1,rep,name=enc_proof,json=encProof,proto3" json:"enc_proof,omitempty"`
 }
 
 func (x *SignRound1Message2) Reset() {
@@ -109,7 +125,7 @@ func (*SignRound1Message2) Descriptor() ([]byte, []int) {
 	return file_protob_eddsa_cmp_onsign_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *SignRound1Message2) GetEncProof() []byte {
+func (x *SignRound1Message2) GetEncProof() [][]byte {
 	if x != nil {
 		return x.EncProof
 	}
@@ -122,9 +138,9 @@ type SignRound2Message struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RX       []byte `protobuf:"bytes,1,opt,name=r_x,json=rX,proto3" json:"r_x,omitempty"`
-	RY       []byte `protobuf:"bytes,2,opt,name=r_y,json=rY,proto3" json:"r_y,omitempty"`
-	LogProof []byte `protobuf:"bytes,3,opt,name=log_proof,json=logProof,proto3" json:"log_proof,omitempty"`
+	RX       [][]byte `protobuf:"bytes,1,rep,name=r_x,json=rX,proto3" json:"r_x,omitempty"`
+	RY       [][]byte `protobuf:"bytes,2,rep,name=r_y,json=rY,proto3" json:"r_y,omitempty"`
+	LogProof [][]byte `protobuf:"bytes,3,rep,name=log_proof,json=logProof,proto3" json:"log_proof,omitempty"`
 }
 
 func (x *SignRound2Message) Reset() {
@@ -159,21 +175,21 @@ func (*SignRound2Message) Descriptor() ([]byte, []int) {
 	return file_protob_eddsa_cmp_onsign_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *SignRound2Message) GetRX() []byte {
+func (x *SignRound2Message) GetRX() [][]byte {
 	if x != nil {
 		return x.RX
 	}
 	return nil
 }
 
-func (x *SignRound2Message) GetRY() []byte {
+func (x *SignRound2Message) GetRY() [][]byte {
 	if x != nil {
 		return x.RY
 	}
 	return nil
 }
 
-func (x *SignRound2Message) GetLogProof() []byte {
+func (x *SignRound2Message) GetLogProof() [][]byte {
 	if x != nil {
 		return x.LogProof
 	}
@@ -186,7 +202,7 @@ type SignRound3Message struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sigma []byte `protobuf:"bytes,1,opt,name=sigma,proto3" json:"sigma,omitempty"`
+	Sigma [][]byte `protobuf:"bytes,1,rep,name=sigma,proto3" json:"sigma,omitempty"`
 }
 
 func (x *SignRound3Message) Reset() {
@@ -221,36 +237,100 @@ func (*SignRound3Message) Descriptor() ([]byte, []int) {
 	return file_protob_eddsa_cmp_onsign_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *SignRound3Message) GetSigma() []byte {
+func (x *SignRound3Message) GetSigma() [][]byte {
 	if x != nil {
 		return x.Sigma
 	}
 	return nil
 }
 
+// Represents a BROADCAST message sent to all parties before Round 3 when signing with a presignature.
+type SignOnlineMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssid       []byte `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Commitment []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"` // the commitment of Round 1, to the message now known
+}
+
+func (x *SignOnlineMessage) Reset() {
+	*x = SignOnlineMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_onsign_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOnlineMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOnlineMessage) ProtoMessage() {}
+
+func (x *SignOnlineMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_onsign_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOnlineMessage.ProtoReflect.Descriptor instead.
+func (*SignOnlineMessage) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_onsign_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignOnlineMessage) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *SignOnlineMessage) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
 var File_protob_eddsa_cmp_onsign_proto protoreflect.FileDescriptor
 
 var file_protob_eddsa_cmp_onsign_proto_rawDesc = []byte{
 	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x63,
 	0x6d, 0x70, 0x2d, 0x6f, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x1d, 0x6c, 0x65, 0x67, 0x65, 0x6e, 0x64, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
-	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x6f, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x29,
+	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x6f, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x22, 0x5d,
 	0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x31, 0x12, 0x13, 0x0a, 0x05, 0x62, 0x69, 0x67, 0x5f, 0x6b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x67, 0x4b, 0x22, 0x31, 0x0a, 0x12, 0x53, 0x69, 0x67,
-	0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0x12,
-	0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x52, 0x0a, 0x11,
-	0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
-	0x72, 0x58, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x02, 0x72, 0x59, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x42, 0x11, 0x5a, 0x0f, 0x65,
-	0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2f, 0x6f, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x69, 0x67, 0x4b, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x31, 0x0a,
+	0x12, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x32, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x52, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x02, 0x72, 0x58, 0x12, 0x0f, 0x0a, 0x03, 0x72, 0x5f, 0x79, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x02, 0x72, 0x59, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x22, 0x29, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x67,
+	0x6d, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x69, 0x67, 0x6d, 0x61, 0x22,
+	0x47, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x11, 0x5a, 0x0f, 0x65, 0x64, 0x64, 0x73,
+	0x61, 0x63, 0x6d, 0x70, 0x2f, 0x6f, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -265,12 +345,13 @@ func file_protob_eddsa_cmp_onsign_proto_rawDescGZIP() []byte {
 	return file_protob_eddsa_cmp_onsign_proto_rawDescData
 }
 
-var file_protob_eddsa_cmp_onsign_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_eddsa_cmp_onsign_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_protob_eddsa_cmp_onsign_proto_goTypes = []interface{}{
 	(*SignRound1Message1)(nil), // 0: legend.tsslib.eddsacmp.onsign.SignRound1Message1
 	(*SignRound1Message2)(nil), // 1: legend.tsslib.eddsacmp.onsign.SignRound1Message2
 	(*SignRound2Message)(nil),  // 2: legend.tsslib.eddsacmp.onsign.SignRound2Message
 	(*SignRound3Message)(nil),  // 3: legend.tsslib.eddsacmp.onsign.SignRound3Message
+	(*SignOnlineMessage)(nil),  // 4: legend.tsslib.eddsacmp.onsign.SignOnlineMessage
 }
 var file_protob_eddsa_cmp_onsign_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -334,6 +415,18 @@ func file_protob_eddsa_cmp_onsign_proto_init() {
 				return nil
 			}
 		}
+		file_protob_eddsa_cmp_onsign_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOnlineMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -341,7 +434,7 @@ func file_protob_eddsa_cmp_onsign_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_eddsa_cmp_onsign_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
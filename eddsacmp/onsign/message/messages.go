@@ -2,6 +2,7 @@ package message
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"math/big"
 
 	"tss_sdk/common"
@@ -15,6 +16,8 @@ import (
 
 // These messages were generated from Protocol Buffers definitions into eddsa-signing.pb.go
 // The following messages are registered on the Protocol Buffers "wire"
+//
+// Every repeated field has one entry per message of a batch, a single signature has one entry.
 
 var (
 	// Ensure that signing messages implement ValidateBasic
@@ -23,37 +26,43 @@ var (
 		(*SignRound1Message2)(nil),
 		(*SignRound2Message)(nil),
 		(*SignRound3Message)(nil),
+		(*SignOnlineMessage)(nil),
 	}
 )
 
 func NewSignRound1Message1(
 	from *tss.PartyID,
-	kCiphertext *big.Int,
+	ssid []byte,
+	kCiphertexts []*big.Int,
+	commitment []byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &SignRound1Message1{
-		BigK: kCiphertext.Bytes(),
+		BigK:       common.BigIntsToBytes(kCiphertexts),
+		Ssid:       ssid,
+		Commitment: commitment,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *SignRound1Message1) ValidateBasic() bool {
-	return common.NonEmptyBytes(m.GetBigK())
+	return common.NonEmptyMultiBytes(m.GetBigK()) &&
+		common.NonEmptyBytes(m.GetSsid())
 }
 
-func (m *SignRound1Message1) UnmarshalK() *big.Int {
-	return (new(big.Int).SetBytes(m.GetBigK()))
+func (m *SignRound1Message1) UnmarshalK() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBigK())
 }
 
 // ----- //
 
 func NewSignRound1Message2(
 	to, from *tss.PartyID,
-	encProof []byte,
+	encProofs [][]byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -61,30 +70,33 @@ func NewSignRound1Message2(
 		IsBroadcast: false,
 	}
 	content := &SignRound1Message2{
-		EncProof: encProof,
+		EncProof: encProofs,
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
 }
 
 func (m *SignRound1Message2) ValidateBasic() bool {
-	return common.NonEmptyBytes(m.GetEncProof())
+	return common.NonEmptyMultiBytes(m.GetEncProof())
 }
 
-func (m *SignRound1Message2) UnmarshalEncProof() (*encproof.EncryptRangeMessage, error) {
-	encProof := &encproof.EncryptRangeMessage{}
-	if err := proto.Unmarshal(m.GetEncProof(), encProof); err != nil {
-		return nil, err
+func (m *SignRound1Message2) UnmarshalEncProof() ([]*encproof.EncryptRangeMessage, error) {
+	encProofs := make([]*encproof.EncryptRangeMessage, len(m.GetEncProof()))
+	for l, bz := range m.GetEncProof() {
+		encProofs[l] = &encproof.EncryptRangeMessage{}
+		if err := proto.Unmarshal(bz, encProofs[l]); err != nil {
+			return nil, err
+		}
 	}
-	return encProof, nil
+	return encProofs, nil
 }
 
 // ----- //
 
 func NewSignRound2Message(
 	to, from *tss.PartyID,
-	R *crypto.ECPoint,
-	logProof []byte,
+	Rs []*crypto.ECPoint,
+	logProofs [][]byte,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -92,9 +104,12 @@ func NewSignRound2Message(
 		IsBroadcast: false,
 	}
 	content := &SignRound2Message{
-		RX:       R.X().Bytes(),
-		RY:       R.Y().Bytes(),
-		LogProof: logProof,
+		RX:       make([][]byte, len(Rs)),
+		RY:       make([][]byte, len(Rs)),
+		LogProof: logProofs,
+	}
+	for l, R := range Rs {
+		content.RX[l], content.RY[l] = R.X().Bytes(), R.Y().Bytes()
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -102,43 +117,53 @@ func NewSignRound2Message(
 
 func (m *SignRound2Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.LogProof) &&
-		common.NonEmptyBytes(m.RX) &&
-		common.NonEmptyBytes(m.RY)
+		common.NonEmptyMultiBytes(m.LogProof) &&
+		common.NonEmptyMultiBytes(m.RX, len(m.LogProof)) &&
+		common.NonEmptyMultiBytes(m.RY, len(m.LogProof))
 }
 
-func (m *SignRound2Message) UnmarshalR(ec elliptic.Curve) (*crypto.ECPoint, error) {
-	R, err := crypto.NewECPoint(
-		ec,
-		new(big.Int).SetBytes(m.GetRX()),
-		new(big.Int).SetBytes(m.GetRY()),
-	)
-	if err != nil {
-		return nil, err
+func (m *SignRound2Message) UnmarshalR(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	if len(m.GetRX()) != len(m.GetRY()) {
+		return nil, errors.New("R coordinates count mismatch")
+	}
+	Rs := make([]*crypto.ECPoint, len(m.GetRX()))
+	for l := range Rs {
+		R, err := crypto.NewECPoint(
+			ec,
+			new(big.Int).SetBytes(m.GetRX()[l]),
+			new(big.Int).SetBytes(m.GetRY()[l]),
+		)
+		if err != nil {
+			return nil, err
+		}
+		Rs[l] = R
 	}
-	return R, nil
+	return Rs, nil
 }
 
-func (m *SignRound2Message) UnmarshalLogProof(ec elliptic.Curve) (*logproof.LogStarMessage, error) {
-	logProof := &logproof.LogStarMessage{}
-	if err := proto.Unmarshal(m.GetLogProof(), logProof); err != nil {
-		return nil, err
+func (m *SignRound2Message) UnmarshalLogProof(ec elliptic.Curve) ([]*logproof.LogStarMessage, error) {
+	logProofs := make([]*logproof.LogStarMessage, len(m.GetLogProof()))
+	for l, bz := range m.GetLogProof() {
+		logProofs[l] = &logproof.LogStarMessage{}
+		if err := proto.Unmarshal(bz, logProofs[l]); err != nil {
+			return nil, err
+		}
 	}
-	return logProof, nil
+	return logProofs, nil
 }
 
 // ----- //
 
 func NewSignRound3Message(
 	from *tss.PartyID,
-	si *big.Int,
+	sis []*big.Int,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
 		IsBroadcast: true,
 	}
 	content := &SignRound3Message{
-		Sigma: si.Bytes(),
+		Sigma: common.BigIntsToBytes(sis),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -146,9 +171,34 @@ func NewSignRound3Message(
 
 func (m *SignRound3Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.Sigma)
+		common.NonEmptyMultiBytes(m.Sigma)
+}
+
+func (m *SignRound3Message) UnmarshalS() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetSigma())
+}
+
+// ----- //
+
+func NewSignOnlineMessage(
+	from *tss.PartyID,
+	ssid []byte,
+	commitment []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignOnlineMessage{
+		Ssid:       ssid,
+		Commitment: commitment,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
 }
 
-func (m *SignRound3Message) UnmarshalS() *big.Int {
-	return new(big.Int).SetBytes(m.GetSigma())
+func (m *SignOnlineMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetCommitment())
 }
@@ -0,0 +1,104 @@
+package onsign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+
+	"tss_sdk/common"
+)
+
+// RFC 8032 signing modes; the mode only changes the challenge hash of round 3
+const (
+	ModeEd25519    = "Ed25519"    // pure Ed25519, SHA512(R || A || M)
+	ModeEd25519ctx = "Ed25519ctx" // SHA512(dom2(0, ctx) || R || A || M)
+	ModeEd25519ph  = "Ed25519ph"  // SHA512(dom2(1, ctx) || R || A || SHA512(M))
+)
+
+// SetSignMode sets the RFC 8032 mode of every message of the party, pure Ed25519 when never called.
+// mode: ModeEd25519, ModeEd25519ctx or ModeEd25519ph
+// context: hex string, at most 255 bytes; it must not be empty for Ed25519ctx
+// It must be set before round 1, or before OnsignOnlineExec with a presignature, to the same mode and context
+// for every signer: the signing commitment covers them, so signers that disagree abort before round 3.
+func SetSignMode(key string, mode string, context string) (result OnsignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.temp.commitment != nil {
+		common.Logger.Errorf("sign mode must be set before the signing commitment is sent")
+		result.Err = "sign mode must be set before the signing commitment is sent"
+		return
+	}
+
+	ctx, err := hex.DecodeString(context)
+	if err != nil || len(ctx) > 255 {
+		common.Logger.Errorf("context must be at most 255 bytes, hex string")
+		result.Err = "context must be at most 255 bytes, hex string"
+		return
+	}
+	switch mode {
+	case ModeEd25519:
+		if len(ctx) != 0 {
+			common.Logger.Errorf("no context in mode %s", mode)
+			result.Err = fmt.Sprintf("no context in mode %s", mode)
+			return
+		}
+	case ModeEd25519ctx:
+		if len(ctx) == 0 {
+			common.Logger.Errorf("empty context in mode %s", mode)
+			result.Err = fmt.Sprintf("empty context in mode %s", mode)
+			return
+		}
+	case ModeEd25519ph:
+	default:
+		common.Logger.Errorf("unknown sign mode: %s", mode)
+		result.Err = fmt.Sprintf("unknown sign mode: %s", mode)
+		return
+	}
+
+	party.temp.mode = mode
+	party.temp.context = ctx
+	result.Ok = true
+	return
+}
+
+// dom2 is the prefix of the challenge hash, empty for pure Ed25519
+func (p *LocalParty) dom2() []byte {
+	if p.temp.mode == "" || p.temp.mode == ModeEd25519 {
+		return nil
+	}
+	var phflag byte
+	if p.temp.mode == ModeEd25519ph {
+		phflag = 1
+	}
+	dom := append([]byte("SigEd25519 no Ed25519 collisions"), phflag, byte(len(p.temp.context)))
+	return append(dom, p.temp.context...)
+}
+
+// signedMsg is message l as it goes into the challenge hash, SHA512(M) for Ed25519ph
+func (p *LocalParty) signedMsg(l int) []byte {
+	if p.temp.mode == ModeEd25519ph {
+		digest := sha512.Sum512(p.temp.m[l])
+		return digest[:]
+	}
+	return p.temp.m[l]
+}
+
+// verifyOptions is the RFC 8032 verifier matching the sign mode
+func (p *LocalParty) verifyOptions() *ed25519.Options {
+	opts := &ed25519.Options{Context: string(p.temp.context)}
+	if p.temp.mode == ModeEd25519ph {
+		opts.Hash = crypto.SHA512
+	}
+	return opts
+}
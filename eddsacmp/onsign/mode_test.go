@@ -0,0 +1,46 @@
+package onsign_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignModes(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	for _, tc := range []struct{ mode, context string }{{onsign.ModeEd25519ctx, "666f6f"}, {onsign.ModeEd25519ph, ""}, {onsign.ModeEd25519ph, "0102"}} {
+		var sigs []*common.SignatureData
+		require.NoError(t, json.Unmarshal(test.SignWithMode(t, saves, []int{0, 2}, 2, "deadbeef,0001", walletPath, "", tc.mode, tc.context), &sigs))
+		context, err := hex.DecodeString(tc.context)
+		require.NoError(t, err)
+		for _, sig := range sigs {
+			opts := &ed25519.Options{Context: string(context)}
+			msg := sig.M
+			if tc.mode == onsign.ModeEd25519ph {
+				opts.Hash = crypto.SHA512
+				digest := sha512.Sum512(msg)
+				msg = digest[:]
+			}
+			require.NoError(t, ed25519.VerifyWithOptions(pub, msg, sig.Signature, opts), tc.mode)
+			require.False(t, ed25519.Verify(pub, sig.M, sig.Signature))
+		}
+	}
+
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	r := onsign.NewLocalParty("mode", 0, 2, 2, pIDs, "aa", test.B64(saves[0]), "", walletPath)
+	require.True(t, r.Ok, r.Err)
+	defer onsign.RemoveSignParty("mode")
+	require.False(t, onsign.SetSignMode("mode", onsign.ModeEd25519ctx, "").Ok)
+	require.False(t, onsign.SetSignMode("mode", onsign.ModeEd25519, "01").Ok)
+	require.False(t, onsign.SetSignMode("mode", "foo", "").Ok)
+}
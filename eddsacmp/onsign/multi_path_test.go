@@ -0,0 +1,30 @@
+package onsign_test
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiPathSign(t *testing.T) {
+	paths := []string{"81/0/0/35/0", "81/0/0/35/1", "81/0/1/35/7"}
+	saves := test.KeygenFixtures(t, 3, 2)
+	var sigs []*common.SignatureData
+	require.NoError(t, json.Unmarshal(test.Sign(t, saves, []int{0, 2}, 2, "deadbeef,cafe,00ff01", strings.Join(paths, ","), ""), &sigs))
+	require.Len(t, sigs, 3)
+	for l, sig := range sigs {
+		require.True(t, ed25519.Verify(test.ChildPub(t, saves[0], paths[l]), sig.M, sig.Signature))
+	}
+	// one path per message
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	r := onsign.NewLocalParty("multi-path", 0, 2, 2, pIDs, "aa,bb,cc", test.B64(saves[0]), "", paths[0]+","+paths[1])
+	require.False(t, r.Ok)
+	require.Contains(t, r.Err, "wallet path count")
+}
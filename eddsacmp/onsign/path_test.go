@@ -0,0 +1,24 @@
+package onsign_test
+
+import (
+	"testing"
+
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlexiblePaths(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	root := test.SaveData(t, saves[0]).PubKey()
+	test.CheckSig(t, edwards.NewPublicKey(root.X(), root.Y()).Serialize(), test.Sign(t, saves, []int{0, 2}, 2, "deadbeef", "", ""))
+	test.CheckSig(t, test.ChildPub(t, saves[0], "44/501/0/0"), test.Sign(t, saves, []int{1, 2}, 2, "deadbeef", "m/44/501/0/0", ""))
+	test.CheckSig(t, test.ChildPub(t, saves[0], "1/2/3/4/5/6/7"), test.Sign(t, saves, []int{0, 1}, 2, "deadbeef", "1/2/3/4/5/6/7", ""))
+	// hardened levels are refused
+	pIDs := []string{test.SaveData(t, saves[0]).ShareID.String(), test.SaveData(t, saves[1]).ShareID.String()}
+	r := onsign.NewLocalParty("hardened", 0, 2, 2, pIDs, "aa", test.B64(saves[0]), "", "m/44'/501'")
+	require.False(t, r.Ok)
+	require.Contains(t, r.Err, "hardened")
+}
@@ -0,0 +1,23 @@
+package onsign
+
+import (
+	"crypto/elliptic"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto/vss"
+	"tss_sdk/eddsacmp/keygen"
+)
+
+// PrepareForSigning turns the Shamir shares of the signing parties into additive shares:
+// w_i = λ_i * x_i and W_j = λ_j * X_j, where λ are the Lagrange coefficients over keys.Ks.
+// keys must already be reduced to the signing parties with keygen.BuildLocalSaveDataSubset.
+func PrepareForSigning(ec elliptic.Curve, i int, keys *keygen.LocalPartySaveData) {
+	modQ := common.ModInt(ec.Params().N)
+	for j := range keys.Ks {
+		lambda := vss.LagrangeCoefficient(ec, j, keys.Ks)
+		if j == i {
+			keys.PrivXi = modQ.Mul(keys.PrivXi, lambda)
+		}
+		keys.PubXj[j] = keys.PubXj[j].ScalarMult(lambda)
+	}
+}
@@ -0,0 +1,248 @@
+package onsign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"tss_sdk/common"
+	m "tss_sdk/eddsacmp/onsign/message"
+	"tss_sdk/tss"
+)
+
+// Presigning runs rounds 1 and 2, which do not depend on the message, ahead of time: a party made by
+// NewPresignLocalParty goes through OnSignRound1Exec .. OnSignRound2Finish as usual, PresignFinish then
+// checks the log proofs and keeps the two nonce shares (d_i, e_i) of every message with the points
+// (D_j, E_j) of every signer as a presignature. Signing takes the presignature out with
+// NewOnlineLocalParty, then runs the online round: every signer broadcasts its commitment to the message
+// hash, wallet path, child public key, sign mode and context, and checks the commitments of the others in
+// OnSignOnlineMsgAccept, blaming a signer whose commitment differs. Round 3 refuses to compute s_i before
+// every commitment is checked, then binds the nonces to the commitment (see bindNonces), so R is not
+// fixed before the message and concurrent open presignatures do not open the ROS attack. A presignature
+// is one-time: it is deleted from Presignatures when it is taken, and k_i is cleared once s_i is computed.
+
+// Presignatures holds the parties that finished round 2, by presignature key. Presignatures are
+// process-local: they are kept in memory only, are never serialized and are lost when the process exits,
+// so a presignature can not be used twice by restoring it. All signers must make them in the same process
+// they sign in.
+var Presignatures = map[string]*LocalParty{}
+
+// NewPresignLocalParty is NewLocalParty without a message; every signer must use the same walletPath
+func NewPresignLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int,
+	pIDs []string,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+	refreshPayload string, // refresh.Payload, hex string; empty to use the aux info saved by keygen
+	walletPath string,
+) (result OnsignResult) {
+	if result = NewLocalParty(key, partyIndex, partyCount, threshold, pIDs, "", keyData, refreshPayload, walletPath); !result.Ok {
+		return
+	}
+	SignParties[key].temp.presign = true
+	SignParties[key].temp.binding = true
+	return
+}
+
+// PresignFinish runs after OnSignRound2Finish: it moves the party from SignParties to Presignatures
+func PresignFinish(key string, presignKey string) (result OnsignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.temp.presign {
+		common.Logger.Errorf("not a presign party: %s", key)
+		result.Err = fmt.Sprintf("not a presign party: %s", key)
+		return
+	}
+	if _, ok := Presignatures[presignKey]; ok {
+		common.Logger.Errorf("presignature already exists: %s", presignKey)
+		result.Err = fmt.Sprintf("presignature already exists: %s", presignKey)
+		return
+	}
+	if party.temp.k == nil || party.temp.bigRj != nil {
+		common.Logger.Errorf("round_2 not executed or presignature already made")
+		result.Err = "round_2 not executed or presignature already made"
+		return
+	}
+
+	common.Logger.Infof("[presign] party: %d, presign finish", party.PartyID().Index)
+	if err := party.verifyR(); err != nil {
+		result.abort(err)
+		return
+	}
+
+	delete(SignParties, key)
+	Presignatures[presignKey] = party
+	result.Ok = true
+	return
+}
+
+// NewOnlineLocalParty takes the presignature out of Presignatures and makes a sign party for the online
+// round, then round 3. Every signer of the presignature must sign the same message with it.
+func NewOnlineLocalParty(
+	key string,
+	presignKey string,
+	msg string, // hex string
+) (result OnsignResult) {
+	party, ok := Presignatures[presignKey]
+	if !ok {
+		common.Logger.Errorf("presignature not found: %s", presignKey)
+		result.Err = fmt.Sprintf("presignature not found: %s", presignKey)
+		return
+	}
+	if _, ok := SignParties[key]; ok {
+		common.Logger.Errorf("party already exists: %s", key)
+		result.Err = fmt.Sprintf("party already exists: %s", key)
+		return
+	}
+
+	ms, err := parseMessages(msg)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	if len(ms) != len(party.temp.m) {
+		common.Logger.Errorf("presignature is for %d messages, got %d", len(party.temp.m), len(ms))
+		result.Err = fmt.Sprintf("presignature is for %d messages, got %d", len(party.temp.m), len(ms))
+		return
+	}
+
+	// one-time: the presignature can not be taken again, even if signing fails
+	delete(Presignatures, presignKey)
+	party.temp.presign = false
+	party.temp.online = true
+	party.temp.m = ms
+	party.temp.commitment = nil
+	party.temp.signOnlineMessages = make([][]byte, len(party.temp.signRound3Messages))
+
+	SignParties[key] = party
+	result.Ok = true
+	return
+}
+
+// OnsignOnlineExec broadcasts the signing commitment of a party made by NewOnlineLocalParty, the sign mode
+// must be set before
+func OnsignOnlineExec(key string) (result OnsignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.temp.online {
+		common.Logger.Errorf("not an online party: %s", key)
+		result.Err = fmt.Sprintf("not an online party: %s", key)
+		return
+	}
+
+	i := party.PartyID().Index
+	common.Logger.Infof("[sign] party: %d, online round start", i)
+
+	party.temp.commitment = party.signingCommitment()
+	msg := m.NewSignOnlineMessage(party.PartyID(), party.temp.ssid, party.temp.commitment)
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signOnlineMessages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return
+}
+
+// OnSignOnlineMsgAccept checks the commitment of Pj against ours, Pj is the culprit when they differ
+func OnSignOnlineMsgAccept(key string, from int, msgWireBytes string) (result OnsignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.temp.online || party.temp.commitment == nil {
+		common.Logger.Errorf("online round not executed")
+		result.Err = "online round not executed"
+		return
+	}
+	if from < 0 || from >= len(party.temp.signOnlineMessages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	content, ok := msg.Content().(*m.SignOnlineMessage)
+	if !ok || !content.ValidateBasic() {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not SignOnlineMessage")))
+		return
+	}
+	if !bytes.Equal(content.GetSsid(), party.temp.ssid) {
+		result.abort(party.blame(tss.ReasonSessionMismatch, from, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", from)))
+		return
+	}
+	if !bytes.Equal(content.GetCommitment(), party.temp.commitment) {
+		result.abort(party.blame(tss.ReasonSessionMismatch, from, fmt.Errorf("message, wallet path, public key or sign mode mismatch, party: %d", from)))
+		return
+	}
+	party.temp.signOnlineMessages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func OnSignOnlineFinish(key string) (result OnsignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.onlineAgreed() {
+		result.Err = "online commitments not all checked"
+		return
+	}
+	result.Ok = true
+	return
+}
+
+// onlineAgreed reports whether the commitment of every signer was checked in the online round
+func (p *LocalParty) onlineAgreed() bool {
+	for _, msg := range p.temp.signOnlineMessages {
+		if len(msg) == 0 {
+			return false
+		}
+	}
+	return p.temp.signOnlineMessages != nil
+}
+
+// RemovePresignature discards an unused presignature
+func RemovePresignature(presignKey string) bool {
+	if _, ok := Presignatures[presignKey]; !ok {
+		return false
+	}
+	delete(Presignatures, presignKey)
+	return true
+}
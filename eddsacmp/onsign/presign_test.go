@@ -0,0 +1,135 @@
+package onsign_test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresign(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	runPresign(t, saves, []int{0, 2}, 2, walletPath, "presign-1")
+	runPresign(t, saves, []int{0, 1, 2}, 2, walletPath, "presign-2")
+	test.CheckSig(t, pub, runOnline(t, 3, "cafe", "presign-2"))
+	test.CheckSig(t, pub, runOnline(t, 2, "deadbeef", "presign-1"))
+	// presignatures are used once
+	require.False(t, onsign.NewOnlineLocalParty("online-again", "presign-1-0", "00").Ok)
+}
+
+// runPresign runs the offline rounds and stores the presignature of signer i as presignKey-i
+func runPresign(t *testing.T, saves [][]byte, signers []int, threshold int, path, presignKey string) {
+	m := len(signers)
+	pIDs := make([]string, m)
+	keys := make([]string, m)
+	for k, s := range signers {
+		pIDs[k] = test.SaveData(t, saves[s]).ShareID.String()
+	}
+	manifest := test.Manifest(t, onsign.TaskName, pIDs, nil, threshold)
+	for k, s := range signers {
+		keys[k] = fmt.Sprintf("presign-%d", k)
+		r := onsign.NewPresignLocalParty(keys[k], k, m, threshold, pIDs, test.B64(saves[s]), "", path)
+		require.True(t, r.Ok, r.Err)
+		require.True(t, onsign.SetSessionManifest(keys[k], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	r1 := make([][]byte, m)
+	for i := range keys {
+		r := onsign.OnSignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r1[i] = r.MsgWireBytes
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(r1[j])).Ok)
+				require.True(t, onsign.OnSignRound1MsgAccept(keys[i], j, test.B64(onsign.GetRound1Msg2(keys[j], i).MsgWireBytes)).Ok)
+			}
+		}
+		require.True(t, onsign.OnSignRound1Finish(keys[i]).Ok)
+	}
+	for i := range keys {
+		require.True(t, onsign.OnsignRound2Exec(keys[i]).Ok)
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, onsign.OnSignRound2MsgAccept(keys[i], j, test.B64(onsign.GetRound2Msg(keys[j], i).MsgWireBytes)).Ok)
+			}
+		}
+		require.True(t, onsign.OnSignRound2Finish(keys[i]).Ok)
+	}
+	// a presign party has no message to sign
+	require.False(t, onsign.OnsignRound3Exec(keys[0]).Ok)
+	for i := range keys {
+		r := onsign.PresignFinish(keys[i], fmt.Sprintf("%s-%d", presignKey, i))
+		require.True(t, r.Ok, r.Err)
+	}
+}
+
+// runOnline signs msg with the presignatures presignKey-i of m signers
+func runOnline(t *testing.T, m int, msg, presignKey string) []byte {
+	keys := make([]string, m)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("online-%d", i)
+		r := onsign.NewOnlineLocalParty(keys[i], fmt.Sprintf("%s-%d", presignKey, i), msg)
+		require.True(t, r.Ok, r.Err)
+		defer onsign.RemoveSignParty(keys[i])
+	}
+	require.False(t, onsign.OnsignRound3Exec(keys[0]).Ok)
+	for _, r := range runOnlineRound(t, keys) {
+		require.True(t, r.Ok, r.Err)
+	}
+	r3 := make([][]byte, m)
+	for i := range keys {
+		r := onsign.OnsignRound3Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r3[i] = r.MsgWireBytes
+	}
+	require.False(t, onsign.OnsignRound3Exec(keys[0]).Ok)
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.True(t, onsign.OnSignRound3MsgAccept(keys[i], j, test.B64(r3[j])).Ok)
+			}
+		}
+		require.True(t, onsign.OnSignRound3Finish(keys[i]).Ok)
+	}
+	var sig []byte
+	for i := range keys {
+		r := onsign.OnsignFinalExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		sig = r.MsgWireBytes
+	}
+	return sig
+}
+
+// runOnlineRound exchanges the online commitments and returns, for every party, the result of its finish or of
+// its first failed accept
+func runOnlineRound(t *testing.T, keys []string) []onsign.OnsignResult {
+	out := make([][]byte, len(keys))
+	for i := range keys {
+		r := onsign.OnsignOnlineExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	results := make([]onsign.OnsignResult, len(keys))
+	for i := range keys {
+		for j := range keys {
+			if i == j {
+				continue
+			}
+			if r := onsign.OnSignOnlineMsgAccept(keys[i], j, test.B64(out[j])); !r.Ok {
+				results[i] = r
+				break
+			}
+		}
+		if results[i].Err == "" {
+			results[i] = onsign.OnSignOnlineFinish(keys[i])
+		}
+	}
+	return results
+}
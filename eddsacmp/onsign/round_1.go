@@ -7,6 +7,7 @@ import "C"
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -24,11 +25,25 @@ type OnsignExecResult struct {
 	Ok           bool   `json:"ok"`
 	Err          string `json:"error"`
 	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the signers to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
 }
 
 type OnsignResult struct {
-	Ok  bool   `json:"ok"`
-	Err string `json:"error"`
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the signers to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *OnsignExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *OnsignResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
 }
 
 var ProofParameter = crypto.NewProofConfig(edwards.Edwards().N)
@@ -48,46 +63,37 @@ func OnSignRound1Exec(key string) (result OnsignExecResult) {
 	i := Pi.Index
 	common.Logger.Infof("[sign] party: %d, round_1 start", i)
 
-	party.temp.ssidNonce = new(big.Int).SetUint64(0)
 	var err error
 	party.temp.ssid, err = party.getSSID()
 	if err != nil {
+		common.Logger.Errorf("get ssid err: %s", err.Error())
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
 		return
 	}
 
-	pkSum := party.keys.PubXj[0]
-	for j, pubx := range party.keys.PubXj {
-		common.Logger.Infof("%d, pubkey: (%d, %d)", j, pubx.X(), pubx.Y())
-		if j == 0 {
-			continue
-		}
-		pkSum, err = pkSum.Add(pubx)
+	// one k in F_q per message, two with a presignature; Ki = enc(k, ρ)
+	batch := party.nonceCount()
+	party.temp.k = make([]*big.Int, batch)
+	party.temp.rho = make([]*big.Int, batch)
+	party.temp.kCiphertexts[i] = make([]*big.Int, batch)
+	for l := 0; l < batch; l++ {
+		party.temp.k[l] = common.GetRandomPositiveInt(party.params.Rand(), party.params.EC().Params().N)
+		kCiphertext, rho, err := party.keys.PaillierPKs[i].EncryptAndReturnRandomness(
+			party.params.Rand(),
+			party.temp.k[l],
+		)
 		if err != nil {
-			common.Logger.Errorf("calc pubkey failed, party: %d", j)
-			result.Err = fmt.Sprintf("calc pubkey failed, party: %d", j)
+			common.Logger.Errorf("P[%d]: create enc proof failed: %s", i, err)
+			result.Err = fmt.Sprintf("P[%d]: create enc proof failed: %s", i, err)
 			return
 		}
+		party.temp.rho[l] = rho
+		party.temp.kCiphertexts[i][l] = kCiphertext
 	}
-	party.keys.EdDSAPub = pkSum
-
-	// k in F_q
-	party.temp.k = common.GetRandomPositiveInt(party.params.Rand(), party.params.EC().Params().N)
-
-	// Ki = enc(k, ρ)
-	kCiphertext, rho, err := party.keys.PaillierPKs[i].EncryptAndReturnRandomness(
-		party.params.Rand(),
-		party.temp.k,
-	)
-	if err != nil {
-		common.Logger.Errorf("P[%d]: create enc proof failed: %s", i, err)
-		result.Err = fmt.Sprintf("P[%d]: create enc proof failed: %s", i, err)
-		return
-	}
-	party.temp.rho = rho
-	party.temp.kCiphertexts[i] = kCiphertext
 
 	// broadcast Ki
-	r1msg1 := m.NewSignRound1Message1(party.PartyID(), kCiphertext)
+	party.temp.commitment = party.signingCommitment()
+	r1msg1 := m.NewSignRound1Message1(party.PartyID(), party.temp.ssid, party.temp.kCiphertexts[i], party.temp.commitment)
 	msgWireBytes, _, err := r1msg1.WireBytes()
 	if err != nil {
 		common.Logger.Errorf("get msg wire bytes error: %s", key)
@@ -100,23 +106,26 @@ func OnSignRound1Exec(key string) (result OnsignExecResult) {
 
 	// p2p send enc proof to Pj
 	for j, Pj := range party.params.Parties().IDs() {
-		// M(prove, Πenc, (sid,i), (Iε,Ki); (ki,rhoi))
-		encProof, err := encproof.NewEncryptRangeMessage(ProofParameter, contextI, kCiphertext,
-			party.keys.PaillierPKs[i].N, party.temp.k, party.temp.rho, party.keys.RingPedersenPKs[j],
-		)
-		if err != nil {
-			common.Logger.Errorf("create enc proof failed: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("create enc proof failed: %s, party: %d", err, j)
-			return
+		encProofs := make([][]byte, batch)
+		for l := 0; l < batch; l++ {
+			// M(prove, Πenc, (sid,i), (Iε,Ki); (ki,rhoi))
+			encProof, err := encproof.NewEncryptRangeMessage(ProofParameter, contextI, party.temp.kCiphertexts[i][l],
+				party.keys.PaillierPKs[i].N, party.temp.k[l], party.temp.rho[l], party.keys.RingPedersenPKs[j],
+			)
+			if err != nil {
+				common.Logger.Errorf("create enc proof failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("create enc proof failed: %s, party: %d", err, j)
+				return
+			}
+
+			if encProofs[l], err = proto.Marshal(encProof); err != nil {
+				common.Logger.Errorf("marshal enc proof failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("marshal enc proof failed: %s, party: %d", err, j)
+				return
+			}
 		}
 
-		encProofBytes, err := proto.Marshal(encProof)
-		if err != nil {
-			common.Logger.Errorf("marshal enc proof failed: %s, party: %d", err, j)
-			return
-		}
-
-		r1msg2 := m.NewSignRound1Message2(Pj, party.PartyID(), encProofBytes)
+		r1msg2 := m.NewSignRound1Message2(Pj, party.PartyID(), encProofs)
 		msg2WireBytes, _, err := r1msg2.WireBytes()
 		if err != nil {
 			common.Logger.Errorf("get msg wire bytes error: %s", key)
@@ -141,6 +150,10 @@ func GetRound1Msg2(key string, to int) (result OnsignExecResult) {
 		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
+	if to < 0 || to >= len(party.temp.send.signRound1Message2s) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
 	result.Ok = true
 	result.MsgWireBytes = party.temp.send.signRound1Message2s[to]
 	return
@@ -153,6 +166,10 @@ func OnSignRound1MsgAccept(key string, from int, msgWireBytes string) (result On
 		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
+	if from < 0 || from >= len(party.temp.signRound1Message1s) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
 
 	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
 	if err != nil {
@@ -163,8 +180,7 @@ func OnSignRound1MsgAccept(key string, from int, msgWireBytes string) (result On
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
 
@@ -173,7 +189,7 @@ func OnSignRound1MsgAccept(key string, from int, msgWireBytes string) (result On
 	} else if _, ok := msg.Content().(*m.SignRound1Message2); ok {
 		party.temp.signRound1Message2s[from] = rMsgBytes
 	} else {
-		result.Err = "not SignRound1Message"
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not SignRound1Message")))
 		return
 	}
 	result.Ok = true
@@ -6,7 +6,9 @@ package onsign
 import "C"
 
 import (
+	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -46,7 +48,19 @@ func OnsignRound2Exec(key string) (result OnsignResult) {
 			return
 		}
 		r1msg1 := pMsg.Content().(*m.SignRound1Message1)
+		if !bytes.Equal(r1msg1.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+		if !bytes.Equal(r1msg1.GetCommitment(), party.temp.commitment) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("message, wallet path or public key mismatch, party: %d", j)))
+			return
+		}
 		party.temp.kCiphertexts[j] = r1msg1.UnmarshalK()
+		if len(party.temp.kCiphertexts[j]) != party.nonceCount() {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("batch size mismatch, party: %d", j)))
+			return
+		}
 
 		pMsg, err = tss.ParseWireMsg(party.temp.signRound1Message2s[j])
 		if err != nil {
@@ -55,26 +69,30 @@ func OnsignRound2Exec(key string) (result OnsignResult) {
 			return
 		}
 		r1msg2 := pMsg.Content().(*m.SignRound1Message2)
-		encProof, err := r1msg2.UnmarshalEncProof()
-		if err != nil {
-			common.Logger.Errorf("unmarshal enc proof failed, party: %d", j)
-			result.Err = fmt.Sprintf("unmarshal enc proof failed, party: %d", j)
+		encProofs, err := r1msg2.UnmarshalEncProof()
+		if err != nil || len(encProofs) != party.nonceCount() {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal enc proof failed, party: %d", j)))
 			return
 		}
 
 		contextJ := append(party.temp.ssid, big.NewInt(int64(j)).Bytes()...)
 
-		if err := encProof.Verify(ProofParameter, contextJ, party.temp.kCiphertexts[j],
-			party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i],
-		); err != nil {
-			common.Logger.Errorf("verify enc proof failed, party: %d", j)
-			result.Err = fmt.Sprintf("verify enc proof failed, party: %d", j)
-			return
+		for l, encProof := range encProofs {
+			if err := encProof.Verify(ProofParameter, contextJ, party.temp.kCiphertexts[j][l],
+				party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i],
+			); err != nil {
+				result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify enc proof failed, party: %d", j)))
+				return
+			}
 		}
 	}
 
 	// Compute Ri = ki * G
-	Ri := crypto.ScalarBaseMult(party.params.EC(), party.temp.k)
+	batch := party.nonceCount()
+	Ris := make([]*crypto.ECPoint, batch)
+	for l := 0; l < batch; l++ {
+		Ris[l] = crypto.ScalarBaseMult(party.params.EC(), party.temp.k[l])
+	}
 
 	G, err := crypto.NewECPoint(party.params.EC(), party.params.EC().Params().Gx, party.params.EC().Params().Gy)
 	if err != nil {
@@ -87,31 +105,33 @@ func OnsignRound2Exec(key string) (result OnsignResult) {
 
 	// p2p send log proof to Pj
 	for j, Pj := range party.params.Parties().IDs() {
-		// logProof for the secret k, rho: M(prove, Πlog, (sid,i), (Iε,Ki,Ri,g); (ki,rhoi))
-		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(ProofParameter, contextI, party.temp.k,
-			party.temp.rho, party.temp.kCiphertexts[i], party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], Ri, G)
-		if err != nil {
-			common.Logger.Errorf("create log proof failed")
-			result.Err = "create log proof failed"
-			return
+		logProofs := make([][]byte, batch)
+		for l, Ri := range Ris {
+			// logProof for the secret k, rho: M(prove, Πlog, (sid,i), (Iε,Ki,Ri,g); (ki,rhoi))
+			logProof, err := logproof.NewKnowExponentAndPaillierEncryption(ProofParameter, contextI, party.temp.k[l],
+				party.temp.rho[l], party.temp.kCiphertexts[i][l], party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], Ri, G)
+			if err != nil {
+				common.Logger.Errorf("create log proof failed")
+				result.Err = "create log proof failed"
+				return
+			}
+
+			err = logProof.Verify(ProofParameter, contextI, party.temp.kCiphertexts[i][l],
+				party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], Ri, G)
+			if err != nil {
+				common.Logger.Errorf("verify my log proof failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("verify my log proof failed: %s, party: %d", err, j)
+				return
+			}
+
+			if logProofs[l], err = proto.Marshal(logProof); err != nil {
+				common.Logger.Errorf("marshal log proof failed: %s, party: %d", err, j)
+				result.Err = fmt.Sprintf("marshal log proof failed: %s, party: %d", err, j)
+				return
+			}
 		}
 
-		err = logProof.Verify(ProofParameter, contextI, party.temp.kCiphertexts[i],
-			party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], Ri, G)
-		if err != nil {
-			common.Logger.Errorf("verify my log proof failed: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("verify my log proof failed: %s, party: %d", err, j)
-			return
-		}
-
-		logProofBytes, err := proto.Marshal(logProof)
-		if err != nil {
-			common.Logger.Errorf("marshal log proof failed: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("marshal log proof failed: %s, party: %d", err, j)
-			return
-		}
-
-		r2msg := m.NewSignRound2Message(Pj, party.PartyID(), Ri, logProofBytes)
+		r2msg := m.NewSignRound2Message(Pj, party.PartyID(), Ris, logProofs)
 		msgWireBytes, _, err := r2msg.WireBytes()
 		if err != nil {
 			common.Logger.Errorf("get msg wire bytes error: %s", key)
@@ -136,6 +156,10 @@ func GetRound2Msg(key string, to int) (result OnsignExecResult) {
 		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
+	if to < 0 || to >= len(party.temp.send.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
 	result.Ok = true
 	result.MsgWireBytes = party.temp.send.signRound2Messages[to]
 	return
@@ -148,6 +172,10 @@ func OnSignRound2MsgAccept(key string, from int, msgWireBytes string) (result On
 		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
+	if from < 0 || from >= len(party.temp.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
 
 	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
 	if err != nil {
@@ -155,18 +183,17 @@ func OnSignRound2MsgAccept(key string, from int, msgWireBytes string) (result On
 		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
 		return
 	}
-	party.temp.signRound2Messages[from] = rMsgBytes
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
 	if _, ok := msg.Content().(*m.SignRound2Message); !ok {
-		result.Err = "not SignRound2Message"
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not SignRound2Message")))
 		return
 	}
+	party.temp.signRound2Messages[from] = rMsgBytes
 
 	result.Ok = true
 	return
@@ -8,6 +8,7 @@ import "C"
 import (
 	"crypto/sha512"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -33,18 +34,102 @@ func OnsignRound3Exec(key string) (result OnsignExecResult) {
 	i := party.PartyID().Index
 	common.Logger.Infof("[sign] party: %d, party_3 start", i)
 
-	var R edwards25519.ExtendedGroupElement
-	riBytes := bigIntToEncodedBytes(party.temp.k)
-	edwards25519.GeScalarMultBase(&R, riBytes)
+	if party.temp.presign {
+		common.Logger.Errorf("presign party, sign with an online party")
+		result.Err = "presign party, sign with an online party"
+		return
+	}
+	if party.temp.online && !party.onlineAgreed() {
+		common.Logger.Errorf("online commitments not all checked")
+		result.Err = "online commitments not all checked"
+		return
+	}
+	if party.temp.k == nil {
+		common.Logger.Errorf("nonce already used")
+		result.Err = "nonce already used"
+		return
+	}
 
-	G, err := crypto.NewECPoint(party.params.EC(), party.params.EC().Params().Gx, party.params.EC().Params().Gy)
+	// the nonces of a presignature are verified by PresignFinish, and bound to the message now
+	if party.temp.bigRj == nil {
+		if err := party.verifyR(); err != nil {
+			result.abort(err)
+			return
+		}
+	}
+	if party.temp.binding {
+		party.bindNonces()
+	}
+	party.temp.encodedR = party.combineR()
+
+	batch := len(party.temp.m)
+	party.temp.si = make([]*[32]byte, batch)
+	party.temp.r = make([]*big.Int, batch)
+	party.temp.lambda = make([]*big.Int, batch)
+	sis := make([]*big.Int, batch)
+	for l := 0; l < batch; l++ {
+		riBytes := bigIntToEncodedBytes(party.temp.k[l])
+		encodedR := party.temp.encodedR[l]
+		keys := party.keysOf(l)
+		encodedPubKey := ecPointToEncodedBytes(keys.EdDSAPub.X(), keys.EdDSAPub.Y())
+
+		// h = hash512(dom2 || R || X || M), the lambda of message l
+		h := sha512.New()
+		h.Reset()
+		h.Write(party.dom2())
+		h.Write(encodedR[:])
+		h.Write(encodedPubKey[:])
+		h.Write(party.signedMsg(l))
+
+		var lambda [64]byte
+		h.Sum(lambda[:0])
+		var lambdaReduced [32]byte
+		edwards25519.ScReduce(&lambdaReduced, &lambda)
+
+		// compute si
+		var localS [32]byte
+		edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(keys.PrivXi), riBytes)
+
+		// store r3 message pieces
+		party.temp.si[l] = &localS
+		party.temp.r[l] = encodedBytesToBigInt(encodedR)
+		party.temp.lambda[l] = encodedBytesToBigInt(&lambdaReduced)
+		sis[l] = encodedBytesToBigInt(&localS)
+	}
+	// k is never used twice
+	party.temp.k = nil
+
+	// broadcast si to other parties
+	r3msg := m.NewSignRound3Message(party.PartyID(), sis)
+	msgWireBytes, _, err := r3msg.WireBytes()
 	if err != nil {
-		common.Logger.Errorf("create base point failed")
-		result.Err = "create base point failed"
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
 		return
 	}
+	party.temp.signRound3Messages[i] = msgWireBytes
 
-	// verify received log proof and compute R
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// verifyR verifies the log proofs of round 2 and keeps Rj = kj * G of every party and nonce in bigRj
+func (party *LocalParty) verifyR() *tss.Error {
+	i := party.PartyID().Index
+	batch := party.nonceCount()
+
+	G, err := crypto.NewECPoint(party.params.EC(), party.params.EC().Params().Gx, party.params.EC().Params().Gy)
+	if err != nil {
+		return tss.NewError(errors.New("create base point failed"), TaskName, party.number, party.PartyID())
+	}
+
+	// verify received log proof
+	bigRj := make([][]*crypto.ECPoint, len(party.temp.signRound2Messages))
+	bigRj[i] = make([]*crypto.ECPoint, batch)
+	for l, k := range party.temp.k {
+		bigRj[i][l] = crypto.ScalarBaseMult(party.params.EC(), k)
+	}
 	for j := 0; j < len(party.temp.signRound2Messages); j++ {
 		if j == i {
 			continue
@@ -52,90 +137,101 @@ func OnsignRound3Exec(key string) (result OnsignExecResult) {
 
 		pMsg, err := tss.ParseWireMsg(party.temp.signRound2Messages[j])
 		if err != nil {
-			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
-			return
+			return party.blame(tss.ReasonBadMessage, j, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error()))
 		}
 		r2msg := pMsg.Content().(*m.SignRound2Message)
 
-		logProof, err := r2msg.UnmarshalLogProof(party.params.EC())
+		logProofs, err := r2msg.UnmarshalLogProof(party.params.EC())
 		if err != nil {
-			common.Logger.Errorf("failed to unmarshal log proof: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("failed to unmarshal log proof: %s, party: %d", err, j)
-			return
+			return party.blame(tss.ReasonBadMessage, j, fmt.Errorf("failed to unmarshal log proof: %s, party: %d", err, j))
 		}
 
-		Rj, err := r2msg.UnmarshalR(party.params.EC())
+		Rjs, err := r2msg.UnmarshalR(party.params.EC())
 		if err != nil {
-			common.Logger.Errorf("unmarshal R failed: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("unmarshal R failed: %s, party: %d", err, j)
-			return
+			return party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal R failed: %s, party: %d", err, j))
+		}
+		if len(logProofs) != batch || len(Rjs) != batch {
+			return party.blame(tss.ReasonBadMessage, j, fmt.Errorf("batch size mismatch, party: %d", j))
 		}
 
 		contextJ := append(party.temp.ssid, big.NewInt(int64(j)).Bytes()...)
 
-		err = logProof.Verify(ProofParameter, contextJ, party.temp.kCiphertexts[j], party.keys.PaillierPKs[j].N,
-			party.keys.RingPedersenPKs[i], Rj, G)
-		if err != nil {
-			common.Logger.Errorf("verify log proof failed: %s, party: %d", err, j)
-			result.Err = fmt.Sprintf("verify log proof failed: %s, party: %d", err, j)
-			return
-		}
-
-		Rj = Rj.EightInvEight()
-		if err != nil {
-			result.Err = fmt.Sprintf("Rj.EightInvEight: %s", err.Error())
-			return
+		for l, Rj := range Rjs {
+			err = logProofs[l].Verify(ProofParameter, contextJ, party.temp.kCiphertexts[j][l], party.keys.PaillierPKs[j].N,
+				party.keys.RingPedersenPKs[i], Rj, G)
+			if err != nil {
+				return party.blame(tss.ReasonBadProof, j, fmt.Errorf("verify log proof failed: %s, party: %d", err, j))
+			}
+			Rjs[l] = Rj.EightInvEight()
 		}
-
-		extendedRj := ecPointToExtendedElement(party.params.EC(), Rj.X(), Rj.Y(), party.params.Rand())
-		R = addExtendedElements(R, extendedRj)
+		bigRj[j] = Rjs
 	}
+	party.temp.bigRj = bigRj
+	return nil
+}
 
-	// compute lambda
-	var encodedR [32]byte
-	R.ToBytes(&encodedR)
-	encodedPubKey := ecPointToEncodedBytes(party.keys.EdDSAPub.X(), party.keys.EdDSAPub.Y())
+// bindNonces turns the two nonces (d, e) of every message of a presignature into k = d + ρ·e, with the
+// binding factor ρj = H(ssid, commitment, j, all Dj and Ej) of every signer. The commitment of the online
+// round covers the message, so R = Σ(Dj + ρj·Ej) is only known once the message is, and an adversary
+// opening many presignatures can not pick messages for their R values (the ROS attack).
+func (party *LocalParty) bindNonces() {
+	ec := party.params.EC()
+	modN := common.ModInt(ec.Params().N)
+	batch := len(party.temp.m)
 
-	// h = hash512(R || X || M)
-	h := sha512.New()
-	h.Reset()
-	h.Write(encodedR[:])
-	h.Write(encodedPubKey[:])
-	if party.temp.fullBytesLen == 0 {
-		h.Write(party.temp.m.Bytes())
-	} else {
-		var mBytes = make([]byte, party.temp.fullBytesLen)
-		party.temp.m.FillBytes(mBytes)
-		h.Write(mBytes)
+	points := make([]*big.Int, 0, 4*batch*len(party.temp.bigRj))
+	for _, Rjs := range party.temp.bigRj {
+		for _, Rj := range Rjs {
+			points = append(points, Rj.X(), Rj.Y())
+		}
 	}
+	tag := append(append([]byte{}, party.temp.ssid...), party.temp.commitment...)
+	for j, Rjs := range party.temp.bigRj {
+		in := append([]*big.Int{big.NewInt(int64(j))}, points...)
+		rho := common.RejectionSample(ec.Params().N, common.SHA512_256i_TAGGED(tag, in...))
 
-	var lambda [64]byte
-	h.Sum(lambda[:0])
-	var lambdaReduced [32]byte
-	edwards25519.ScReduce(&lambdaReduced, &lambda)
-
-	// compute si
-	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(party.keys.PrivXi), riBytes)
-
-	// store r3 message pieces
-	party.temp.si = &localS
-	party.temp.r = encodedBytesToBigInt(&encodedR)
+		bound := make([]*crypto.ECPoint, batch)
+		for l := range bound {
+			// Dj and ρj·Ej are in the prime order subgroup, their sum is never the identity but for a
+			// negligible ρj
+			bound[l], _ = Rjs[l].Add(Rjs[batch+l].ScalarMult(rho))
+		}
+		party.temp.bigRj[j] = bound
+		if j == party.PartyID().Index {
+			k := make([]*big.Int, batch)
+			for l := range k {
+				k[l] = modN.Add(party.temp.k[l], modN.Mul(rho, party.temp.k[batch+l]))
+			}
+			party.temp.k = k
+		}
+	}
+	party.temp.binding = false
+}
 
-	// broadcast si to other parties
-	r3msg := m.NewSignRound3Message(party.PartyID(), encodedBytesToBigInt(&localS))
-	msgWireBytes, _, err := r3msg.WireBytes()
-	if err != nil {
-		common.Logger.Errorf("get msg wire bytes error: %s", key)
-		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
-		return
+// combineR returns the encoding of R = ΣRj for every message
+func (party *LocalParty) combineR() []*[32]byte {
+	i := party.PartyID().Index
+	batch := len(party.temp.m)
+	Rs := make([]edwards25519.ExtendedGroupElement, batch)
+	for l := range Rs {
+		edwards25519.GeScalarMultBase(&Rs[l], bigIntToEncodedBytes(party.temp.k[l]))
+	}
+	for j, Rjs := range party.temp.bigRj {
+		if j == i {
+			continue
+		}
+		for l := range Rs {
+			extendedRj := ecPointToExtendedElement(party.params.EC(), Rjs[l].X(), Rjs[l].Y(), party.params.Rand())
+			Rs[l] = addExtendedElements(Rs[l], extendedRj)
+		}
 	}
-	party.temp.signRound3Messages[i] = msgWireBytes
 
-	result.Ok = true
-	result.MsgWireBytes = msgWireBytes
-	return result
+	encodedRs := make([]*[32]byte, batch)
+	for l := range Rs {
+		encodedRs[l] = new([32]byte)
+		Rs[l].ToBytes(encodedRs[l])
+	}
+	return encodedRs
 }
 
 func OnSignRound3MsgAccept(key string, from int, msgWireBytes string) (result OnsignResult) {
@@ -145,6 +241,15 @@ func OnSignRound3MsgAccept(key string, from int, msgWireBytes string) (result On
 		result.Err = fmt.Sprintf("party not found: %s", key)
 		return
 	}
+	if from < 0 || from >= len(party.temp.signRound3Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+	if party.temp.lambda == nil {
+		common.Logger.Errorf("round_3 not executed")
+		result.Err = "round_3 not executed"
+		return
+	}
 
 	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
 	if err != nil {
@@ -152,23 +257,43 @@ func OnSignRound3MsgAccept(key string, from int, msgWireBytes string) (result On
 		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
 		return
 	}
-	party.temp.signRound3Messages[from] = rMsgBytes
 
 	msg, err := tss.ParseWireMsg(rMsgBytes)
 	if err != nil {
-		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
-		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
 		return
 	}
-	if _, ok := msg.Content().(*m.SignRound3Message); !ok {
-		result.Err = "not SignRound3Message"
+	content, ok := msg.Content().(*m.SignRound3Message)
+	if !ok || !content.ValidateBasic() {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not SignRound3Message")))
 		return
 	}
+	if err := party.verifyShares(from, content.UnmarshalS()); err != nil {
+		result.abort(party.blame(tss.ReasonBadShare, from, err))
+		return
+	}
+	party.temp.signRound3Messages[from] = rMsgBytes
 
 	result.Ok = true
 	return
 }
 
+// verifyShares checks sj * G == Rj + λ * Xj for every message, so a bad share is blamed on its signer
+// before it is added to the signature
+func (party *LocalParty) verifyShares(j int, sjs []*big.Int) error {
+	ec := party.params.EC()
+	if len(sjs) != len(party.temp.m) {
+		return fmt.Errorf("batch size mismatch, party: %d", j)
+	}
+	for l, sj := range sjs {
+		expected, err := party.temp.bigRj[j][l].Add(party.keysOf(l).PubXj[j].ScalarMult(party.temp.lambda[l]))
+		if err != nil || sj.Cmp(ec.Params().N) >= 0 || !crypto.ScalarBaseMult(ec, sj).Equals(expected) {
+			return fmt.Errorf("verify signature share failed, party: %d, msg: %d", j, l)
+		}
+	}
+	return nil
+}
+
 func OnSignRound3Finish(key string) (result OnsignResult) {
 	party, ok := SignParties[key]
 	if !ok {
@@ -6,6 +6,7 @@ package onsign
 import "C"
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -14,8 +15,6 @@ import (
 	m "tss_sdk/eddsacmp/onsign/message"
 	"tss_sdk/tss"
 
-	// m "tss_sdk/eddsacmp/onsign/message"
-
 	"github.com/agl/ed25519/edwards25519"
 	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
 )
@@ -36,7 +35,8 @@ func OnsignFinalExec(key string) (result OnsignExecResult) {
 
 	common.Logger.Infof("[sign] party: %d, party_4 start", i)
 
-	sumS := party.temp.si
+	batch := len(party.temp.m)
+	sumS := append([]*[32]byte{}, party.temp.si...)
 	for j := range party.params.Parties().IDs() {
 		party.ok[j] = true
 		if j == party.PartyID().Index {
@@ -50,39 +50,55 @@ func OnsignFinalExec(key string) (result OnsignExecResult) {
 			return
 		}
 		r3msg := pMsg.Content().(*m.SignRound3Message)
-		sjBytes := bigIntToEncodedBytes(r3msg.UnmarshalS())
-		var tmpSumS [32]byte
-		edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), sjBytes)
-		sumS = &tmpSumS
-	}
-	s := encodedBytesToBigInt(sumS)
-
-	// save the signature for final output
-	party.data.Signature = append(bigIntToEncodedBytes(party.temp.r)[:], sumS[:]...)
-	party.data.R = party.temp.r.Bytes()
-	party.data.S = s.Bytes()
-	if party.temp.fullBytesLen == 0 {
-		party.data.M = party.temp.m.Bytes()
-	} else {
-		var mBytes = make([]byte, party.temp.fullBytesLen)
-		party.temp.m.FillBytes(mBytes)
-		party.data.M = mBytes
+		sjs := r3msg.UnmarshalS()
+		if len(sjs) != batch {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("batch size mismatch, party: %d", j)))
+			return
+		}
+		for l, sj := range sjs {
+			var tmpSumS [32]byte
+			edwards25519.ScMulAdd(&tmpSumS, sumS[l], bigIntToEncodedBytes(big.NewInt(1)), bigIntToEncodedBytes(sj))
+			sumS[l] = &tmpSumS
+		}
 	}
 
-	pk := edwards.PublicKey{
-		Curve: party.params.EC(),
-		X:     party.keys.EdDSAPub.X(),
-		Y:     party.keys.EdDSAPub.Y(),
+	for l := 0; l < batch; l++ {
+		pk := edwards.PublicKey{
+			Curve: party.params.EC(),
+			X:     party.keysOf(l).EdDSAPub.X(),
+			Y:     party.keysOf(l).EdDSAPub.Y(),
+		}
+		s := encodedBytesToBigInt(sumS[l])
+
+		// save the signature for final output
+		data := party.data[l]
+		data.Signature = append(bigIntToEncodedBytes(party.temp.r[l])[:], sumS[l][:]...)
+		data.R = party.temp.r[l].Bytes()
+		data.S = s.Bytes()
+		data.M = party.temp.m[l]
+
+		var verified bool
+		if party.dom2() == nil {
+			verified = edwards.Verify(&pk, data.M, party.temp.r[l], s)
+		} else {
+			verified = ed25519.VerifyWithOptions(pk.Serialize(), party.signedMsg(l), data.Signature,
+				party.verifyOptions()) == nil
+		}
+		// every share was checked against its signer's Rj and public share on accept, so no one is to blame
+		if !verified {
+			result.abort(tss.NewError(fmt.Errorf("verify failed, msg: %d", l), TaskName, party.number, party.PartyID()))
+			return
+		}
 	}
 
-	ok = edwards.Verify(&pk, party.data.M, party.temp.r, s)
-	if !ok {
-		common.Logger.Errorf("verify failed")
-		result.Err = "verify failed"
-		return
+	// a single message keeps the SignatureData output, a batch outputs the list in message order
+	var saveBytes []byte
+	var err error
+	if batch == 1 {
+		saveBytes, err = json.Marshal(party.data[0])
+	} else {
+		saveBytes, err = json.Marshal(party.data)
 	}
-
-	saveBytes, err := json.Marshal(party.data)
 	if err != nil {
 		common.Logger.Errorf("round_final save err: %s", err.Error())
 		result.Err = fmt.Sprintf("round_final save err: %s", err.Error())
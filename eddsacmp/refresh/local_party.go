@@ -0,0 +1,286 @@
+package refresh
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/paillier"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/tss"
+
+	"github.com/ipfs/go-log"
+)
+
+// Implements Party
+// Implements Stringer
+// var _ tss.Party = (*LocalParty)(nil)
+// var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp     localTempData
+		save     keygen.LocalPartySaveData
+		manifest *tss.SessionManifest
+		number   int
+		ok       []bool
+	}
+
+	localMessageStore struct {
+		rfRound1Messages  [][]byte // msg.WireBytes()
+		rfRound2Messages  [][]byte
+		rfRound2Message2s [][]byte
+		rfRound3Messages  [][]byte
+	}
+
+	sendMessageStore struct {
+		rfRound2Message2s [][]byte // msg.WireBytes()
+	}
+
+	localTempData struct {
+		localMessageStore
+		send sendMessageStore
+
+		// temp data (thrown away after refresh)
+
+		// Echo broadcast and random oracle data seed
+		srid []byte
+		u    []byte
+
+		ssid []byte
+
+		V [][]byte
+
+		// commitments[j] are the delta commitments of Pj, deltas are dealt by us,
+		// receivedDeltas[j] is the delta Pj dealt to us
+		commitments    [][]*crypto.ECPoint
+		deltas         []*big.Int
+		receivedDeltas []*big.Int
+
+		// new aux info, it replaces the saved one when the refresh is done
+		paillierPKs []*paillier.PublicKey
+		pedPKs      []*pailliera.PedPubKey
+		paillierSK  *paillier.PrivateKey
+		pedSK       *pailliera.PedPrivKey
+
+		// X25519 keys the deltas are sealed to: ours, and the one of every Pj from round 1
+		encPK, encSK *[32]byte
+		encPKs       []*[32]byte
+	}
+)
+
+var Parties = map[string]*LocalParty{}
+
+// Exported, used in `tss` client
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	pIDs []string,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+) (result RefreshResult) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		result.Err = fmt.Sprintf("set log level, err: %s", err.Error())
+		return
+	}
+
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := keygen.LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.EdDSAPub == nil {
+		common.Logger.Errorf("keygen save data without group pubkey")
+		result.Err = "keygen save data without group pubkey"
+		return
+	}
+	// a 1-of-n key is held whole by every party, there are no shares to refresh
+	if keys.SignThreshold() < 2 {
+		common.Logger.Errorf("key threshold err: %d", keys.SignThreshold())
+		result.Err = fmt.Sprintf("key threshold err: %d", keys.SignThreshold())
+		return
+	}
+	// the curve of the key, an ecdsacmp key is refreshed on secp256k1
+	ec := keys.EdDSAPub.Curve()
+	tss.SetCurve(ec)
+
+	// every share holder takes part, otherwise the deltas would not sum to zero
+	if partyCount != len(keys.Ks) {
+		common.Logger.Errorf("party count err: %d, key party count: %d", partyCount, len(keys.Ks))
+		result.Err = fmt.Sprintf("party count err: %d, key party count: %d", partyCount, len(keys.Ks))
+		return
+	}
+
+	uIds := make(tss.UnSortedPartyIDs, 0, partyCount)
+	for i := 0; i < partyCount; i++ {
+		pId, _ := new(big.Int).SetString(pIDs[i], 10)
+		common.Logger.Infof("id: %d", pId)
+		uIds = append(uIds, tss.NewPartyID(fmt.Sprintf("%d", i), fmt.Sprintf("m_%d", i), pId))
+	}
+	ids := tss.SortPartyIDs(uIds)
+	for j, id := range ids {
+		if id.KeyInt().Cmp(keys.Ks[j]) != 0 {
+			common.Logger.Errorf("party id not in keygen data: %d", j)
+			result.Err = fmt.Sprintf("party id not in keygen data: %d", j)
+			return
+		}
+	}
+	if ids[partyIndex].KeyInt().Cmp(keys.ShareID) != 0 {
+		common.Logger.Errorf("party index err: %d", partyIndex)
+		result.Err = fmt.Sprintf("party index err: %d", partyIndex)
+		return
+	}
+
+	p2pCtx := tss.NewPeerContext(ids)
+	params := tss.NewParameters(ec, p2pCtx, ids[partyIndex], partyCount, keys.SignThreshold())
+
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		save:      keys,
+		ok:        make([]bool, partyCount),
+	}
+
+	// msgs init
+	p.temp.rfRound1Messages = make([][]byte, partyCount)
+	p.temp.rfRound2Messages = make([][]byte, partyCount)
+	p.temp.rfRound2Message2s = make([][]byte, partyCount)
+	p.temp.rfRound3Messages = make([][]byte, partyCount)
+	p.temp.send.rfRound2Message2s = make([][]byte, partyCount)
+
+	// temp data init
+	p.temp.V = make([][]byte, partyCount)
+	p.temp.commitments = make([][]*crypto.ECPoint, partyCount)
+	p.temp.receivedDeltas = make([]*big.Int, partyCount)
+	p.temp.paillierPKs = make([]*paillier.PublicKey, partyCount)
+	p.temp.pedPKs = make([]*pailliera.PedPubKey, partyCount)
+	p.temp.encPKs = make([]*[32]byte, partyCount)
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(TaskName, party.params.Parties().IDs().Keys(), nil, party.save.SignThreshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
+func RemoveParty(key string) bool {
+	if _, ok := Parties[key]; !ok {
+		return false
+	}
+	delete(Parties, key)
+	return true
+}
+
+func (p *LocalParty) resetOK() {
+	for j := range p.ok {
+		p.ok[j] = false
+	}
+}
+
+// blame returns an identifiable abort error naming Pj as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.PartyID(), p.params.Parties().IDs()[j])
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// deltaPoint returns delta_{j->k} * G, the commitment to the delta Pj deals to Pk. For threshold keys
+// commitments[j] are the coefficients 1..t-1 of f_j, whose constant term is zero.
+func (p *LocalParty) deltaPoint(j, k int) (*crypto.ECPoint, error) {
+	if p.save.IsAdditive() {
+		return p.temp.commitments[j][k], nil
+	}
+	modN := common.ModInt(p.params.EC().Params().N)
+	var D *crypto.ECPoint
+	t := big.NewInt(1)
+	for _, c := range p.temp.commitments[j] {
+		t = modN.Mul(t, p.save.Ks[k])
+		v := c.ScalarMult(t)
+		if D == nil {
+			D = v
+			continue
+		}
+		var err error
+		if D, err = D.Add(v); err != nil {
+			return nil, err
+		}
+	}
+	return D, nil
+}
+
+// auxContext binds the aux info proofs of party j to this session and the final srid
+func (p *LocalParty) auxContext(j int) []byte {
+	return common.SHA512_256(p.temp.ssid, p.temp.srid, []byte(strconv.Itoa(j)))
+}
+
+// get ssid from the session manifest and local params
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	BigXjList, err := crypto.FlattenECPoints(p.save.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	ssidList := BigXjList // BigXj
+	// keys made before aux info keygen have no ring-Pedersen parameters yet
+	if p.save.HasAuxInfo() {
+		for _, pk := range p.save.RingPedersenPKs {
+			ssidList = append(ssidList, pk.N, pk.S, pk.T)
+		}
+	}
+	return p.manifest.SSID(p.params.EC(), p.params.Parties().IDs().Keys(), ssidList...), nil
+}
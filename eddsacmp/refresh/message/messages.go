@@ -0,0 +1,167 @@
+package message
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	paillierzkproof "tss_sdk/crypto/alice/zkproof/paillier"
+	"tss_sdk/crypto/modproof"
+	"tss_sdk/crypto/paillier"
+	"tss_sdk/tss"
+)
+
+// ----- //
+
+func NewRFRound1Message(from *tss.PartyID, hash []byte, encPub *[32]byte) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RFRound1Message{
+		Commitment: hash,
+		EncPub:     encPub[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RFRound1Message) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetCommitment()) && len(m.GetEncPub()) == 32
+}
+
+func (m *RFRound1Message) UnmarshalEncPub() *[32]byte {
+	encPub := new([32]byte)
+	copy(encPub[:], m.GetEncPub())
+	return encPub
+}
+
+// ----- //
+
+func NewRFRound2Message(
+	from *tss.PartyID,
+	ssid []byte,
+	srid []byte,
+	commitments []*crypto.ECPoint,
+	paillierPK *paillier.PublicKey,
+	pedersenPK *pailliera.PedPubKey,
+	u []byte,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	flat, err := crypto.FlattenECPoints(commitments)
+	if err != nil {
+		return nil, err
+	}
+	content := &RFRound2Message{
+		Ssid:        ssid,
+		Srid:        srid,
+		Commitments: common.BigIntsToBytes(flat),
+		PaillierN:   paillierPK.N.Bytes(),
+		PedersenS:   pedersenPK.S.Bytes(),
+		PedersenT:   pedersenPK.T.Bytes(),
+		U:           u,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RFRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetSrid()) &&
+		len(m.GetCommitments()) > 0 &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetPedersenS()) &&
+		common.NonEmptyBytes(m.GetPedersenT()) &&
+		common.NonEmptyBytes(m.GetU())
+}
+
+func (m *RFRound2Message) UnmarshalCommitments(ec elliptic.Curve) ([]*crypto.ECPoint, error) {
+	return crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetCommitments()))
+}
+
+func (m *RFRound2Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+// UnmarshalPedersenPK returns the ring-Pedersen parameters of the sender, which share the Paillier modulus
+func (m *RFRound2Message) UnmarshalPedersenPK() *pailliera.PedPubKey {
+	return &pailliera.PedPubKey{
+		N: new(big.Int).SetBytes(m.GetPaillierN()),
+		S: new(big.Int).SetBytes(m.GetPedersenS()),
+		T: new(big.Int).SetBytes(m.GetPedersenT()),
+	}
+}
+
+// ----- //
+
+func NewRFRound2Message2(
+	to, from *tss.PartyID,
+	sealedDelta []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RFRound2Message2{
+		SealedDelta: sealedDelta,
+		To:          int32(to.Index),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+// a zero delta is valid, but even its seal is not empty
+func (m *RFRound2Message2) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetSealedDelta())
+}
+
+// ----- //
+
+func NewRFRound3Message(
+	from *tss.PartyID,
+	modProof *modproof.ProofMod,
+	prmProof *paillierzkproof.RingPederssenParameterMessage,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	modPfBzs := modProof.Bytes()
+	prmPfBzs, err := proto.Marshal(prmProof)
+	if err != nil {
+		return nil, err
+	}
+	content := &RFRound3Message{
+		ModProof: modPfBzs[:],
+		PrmProof: prmPfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RFRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+		common.NonEmptyBytes(m.GetPrmProof())
+}
+
+func (m *RFRound3Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+func (m *RFRound3Message) UnmarshalPrmProof() (*paillierzkproof.RingPederssenParameterMessage, error) {
+	prmProof := &paillierzkproof.RingPederssenParameterMessage{}
+	if err := proto.Unmarshal(m.GetPrmProof(), prmProof); err != nil {
+		return nil, err
+	}
+	return prmProof, nil
+}
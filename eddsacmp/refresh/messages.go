@@ -0,0 +1,19 @@
+package refresh
+
+import (
+	msg "tss_sdk/eddsacmp/refresh/message"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-cmp-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*msg.RFRound1Message)(nil),
+		(*msg.RFRound2Message)(nil),
+		(*msg.RFRound2Message2)(nil),
+		(*msg.RFRound3Message)(nil),
+	}
+)
@@ -0,0 +1,76 @@
+package refresh_test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/refresh"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestRefreshThreshold(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	newSaves := test.Refresh(t, saves)
+	for i := range saves {
+		old, refreshed := test.SaveData(t, saves[i]), test.SaveData(t, newSaves[i])
+		require.True(t, old.EdDSAPub.Equals(refreshed.EdDSAPub))
+		require.NotEqual(t, 0, old.PrivXi.Cmp(refreshed.PrivXi))
+		require.NotEqual(t, 0, old.PaillierSK.N.Cmp(refreshed.PaillierSK.N))
+	}
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{0, 2}, 2, "deadbeef", walletPath, ""))
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{1, 2}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestRefreshAdditive(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	newSaves := test.Refresh(t, saves)
+	for i := range saves {
+		require.True(t, test.SaveData(t, saves[i]).EdDSAPub.Equals(test.SaveData(t, newSaves[i]).EdDSAPub))
+	}
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{0, 1}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestRefreshMisroutedDelta(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	pIDs := test.PartyIDs(2)
+	manifest := test.Manifest(t, refresh.TaskName, pIDs, nil, 2)
+	keys := make([]string, 2)
+	r1 := make([][]byte, 2)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("misrouted-%d", i)
+		require.True(t, refresh.NewLocalParty(keys[i], i, 2, pIDs, test.B64(saves[i])).Ok)
+		key := keys[i]
+		t.Cleanup(func() { refresh.RemoveParty(key) })
+		require.True(t, refresh.SetSessionManifest(keys[i], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+		r := refresh.RefreshRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r1[i] = r.MsgWireBytes
+	}
+	require.True(t, refresh.RefreshRound1Accept(keys[0], 1, test.B64(r1[1])).Ok)
+	require.True(t, refresh.RefreshRound1Accept(keys[1], 0, test.B64(r1[0])).Ok)
+	r2 := make([][]byte, 2)
+	for i := range keys {
+		require.True(t, refresh.RefreshRound1Finish(keys[i]).Ok)
+		r := refresh.RefreshRound2Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		r2[i] = r.MsgWireBytes
+	}
+	require.False(t, refresh.GetRound2Msg2(keys[1], 2).Ok)
+	require.False(t, refresh.GetRound2Msg2(keys[1], -1).Ok)
+
+	// the relay delivers to P0 the delta P1 dealt to itself
+	require.True(t, refresh.RefreshRound2Accept(keys[0], 1, test.B64(r2[1])).Ok)
+	require.True(t, refresh.RefreshRound2Accept(keys[0], 1, test.B64(refresh.GetRound2Msg2(keys[1], 1).MsgWireBytes)).Ok)
+	require.True(t, refresh.RefreshRound2Finish(keys[0]).Ok)
+	r := refresh.RefreshRound3Exec(keys[0])
+	require.False(t, r.Ok)
+	require.Equal(t, "1", r.Culprits)
+	require.Equal(t, tss.ReasonBadMessage, r.Reason)
+}
@@ -0,0 +1,211 @@
+package refresh
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/vss"
+	"tss_sdk/eddsacmp/keygen"
+	m "tss_sdk/eddsacmp/refresh/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+type RefreshExecResult struct {
+	Ok           bool   `json:"ok"`
+	Err          string `json:"error"`
+	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+type RefreshResult struct {
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *RefreshExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *RefreshResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func RefreshRound1Exec(key string) (result RefreshExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 1
+	party.resetOK()
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, refresh round_1 start", i)
+
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	// Generate the new Paillier key and ring-Pedersen parameters
+	paillierSK, pedSK, err := keygen.GenerateAuxInfo(party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("generate aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate aux info err: %s", err.Error())
+		return
+	}
+	party.temp.paillierSK = paillierSK
+	party.temp.pedSK = pedSK
+	party.temp.paillierPKs[i] = &paillierSK.PublicKey
+	party.temp.pedPKs[i] = &pedSK.PedPubKey
+
+	// Deal deltas which sum to zero, so the shared key stays the same
+	ec := party.params.EC()
+	if party.save.IsAdditive() {
+		modN := common.ModInt(ec.Params().N)
+		n := len(party.save.Ks)
+		deltas := make([]*big.Int, n)
+		sum := big.NewInt(0)
+		for k := 0; k < n-1; k++ {
+			deltas[k] = common.GetRandomPositiveInt(party.params.PartialKeyRand(), ec.Params().N)
+			sum = modN.Add(sum, deltas[k])
+		}
+		deltas[n-1] = modN.Sub(big.NewInt(0), sum)
+
+		commitments := make([]*crypto.ECPoint, n)
+		for k, delta := range deltas {
+			commitments[k] = crypto.ScalarBaseMult(ec, delta)
+		}
+		party.temp.deltas = deltas
+		party.temp.commitments[i] = commitments
+	} else {
+		vs, shares, err := vss.Create(ec, party.save.SignThreshold(), big.NewInt(0), party.save.Ks, party.params.PartialKeyRand())
+		if err != nil {
+			common.Logger.Errorf("vss create err: %s", err.Error())
+			result.Err = fmt.Sprintf("vss create err: %s", err.Error())
+			return
+		}
+		party.temp.deltas = make([]*big.Int, len(shares))
+		for k, share := range shares {
+			party.temp.deltas[k] = share.Share
+		}
+		// vs[0] commits to f_i(0) = 0, the identity has no affine encoding on secp256k1 so it is left out
+		party.temp.commitments[i] = vs[1:]
+	}
+
+	party.temp.u, _ = common.GetRandomBytes(party.params.Rand(), 32)
+	party.temp.srid, _ = common.GetRandomBytes(party.params.Rand(), 32)
+
+	// Compute V_i
+	hash := party.hashV(i, party.temp.srid, party.temp.commitments[i], &pedSK.PedPubKey, party.temp.u)
+
+	// the deltas dealt to us in round 2 are sealed to this key
+	party.temp.encPK, party.temp.encSK, err = box.GenerateKey(party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("generate enc key err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate enc key err: %s", err.Error())
+		return
+	}
+
+	msg := m.NewRFRound1Message(party.PartyID(), hash, party.temp.encPK)
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.rfRound1Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// hashV computes the round 1 commitment V_j of party j
+func (p *LocalParty) hashV(
+	j int,
+	srid []byte,
+	commitments []*crypto.ECPoint,
+	pedPK *pailliera.PedPubKey,
+	u []byte,
+) []byte {
+	in := [][]byte{p.temp.ssid, []byte(strconv.Itoa(j)), srid}
+	for _, c := range commitments {
+		in = append(in, c.X().Bytes(), c.Y().Bytes())
+	}
+	in = append(in, pedPK.N.Bytes(), pedPK.S.Bytes(), pedPK.T.Bytes(), u)
+	return common.SHA512_256(in...)
+}
+
+func RefreshRound1Accept(key string, from int, msgWireBytes string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	if r1Msg, ok := msg.Content().(*m.RFRound1Message); !ok || !r1Msg.ValidateBasic() {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not RFRound1Message")))
+		return
+	}
+	party.temp.rfRound1Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func RefreshRound1Finish(key string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rfRound1Messages {
+		if j == party.PartyID().Index {
+			continue
+		}
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
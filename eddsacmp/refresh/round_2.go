@@ -0,0 +1,166 @@
+package refresh
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"tss_sdk/common"
+	m "tss_sdk/eddsacmp/refresh/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func RefreshRound2Exec(key string) (result RefreshExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 2
+	party.resetOK()
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, refresh round_2 start", i)
+
+	for j := 0; j < len(party.temp.rfRound1Messages); j++ {
+		pMsg, err := tss.ParseWireMsg(party.temp.rfRound1Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r1Msg := pMsg.Content().(*m.RFRound1Message)
+		party.temp.V[j] = r1Msg.Commitment
+		party.temp.encPKs[j] = r1Msg.UnmarshalEncPub()
+	}
+
+	msg, err := m.NewRFRound2Message(
+		party.PartyID(),
+		party.temp.ssid,
+		party.temp.srid,
+		party.temp.commitments[i],
+		party.temp.paillierPKs[i],
+		party.temp.pedPKs[i],
+		party.temp.u,
+	)
+	if err != nil {
+		common.Logger.Errorf("new round_2 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_2 msg err: %s", err.Error())
+		return
+	}
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.rfRound2Messages[i] = msgWireBytes
+
+	// p2p send the delta to Pj, sealed to its key of round 1 so that only Pj can read it
+	for j, Pj := range party.params.Parties().IDs() {
+		sealedDelta, err := box.SealAnonymous(nil, party.temp.deltas[j].Bytes(), party.temp.encPKs[j], party.params.Rand())
+		if err != nil {
+			common.Logger.Errorf("seal delta err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("seal delta err: %s, party: %d", err.Error(), j)
+			return
+		}
+		r2msg2 := m.NewRFRound2Message2(Pj, party.PartyID(), sealedDelta)
+		msg2WireBytes, _, err := r2msg2.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.rfRound2Message2s[j] = msg2WireBytes
+	}
+	party.temp.rfRound2Message2s[i] = party.temp.send.rfRound2Message2s[i]
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// GetRound2Msg2 returns the p2p delta message for Pj, the delta is sealed to the key Pj sent in round 1
+func GetRound2Msg2(key string, to int) (result RefreshExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.rfRound2Message2s) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.rfRound2Message2s[to]
+	return
+}
+
+func RefreshRound2Accept(key string, from int, msgWireBytes string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+
+	if _, ok := msg.Content().(*m.RFRound2Message); ok {
+		party.temp.rfRound2Messages[from] = rMsgBytes
+	} else if _, ok := msg.Content().(*m.RFRound2Message2); ok {
+		party.temp.rfRound2Message2s[from] = rMsgBytes
+	} else {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not RFRound2Message")))
+		return
+	}
+
+	result.Ok = true
+	return
+}
+
+func RefreshRound2Finish(key string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rfRound2Messages {
+		if j == party.PartyID().Index {
+			continue
+		}
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+		if len(party.temp.rfRound2Message2s[j]) == 0 {
+			result.Err = fmt.Sprintf("msg2 is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,217 @@
+package refresh
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/alice/utils"
+	"tss_sdk/eddsacmp/keygen"
+	m "tss_sdk/eddsacmp/refresh/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+func RefreshRound3Exec(key string) (result RefreshExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 3
+	party.resetOK()
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, refresh round_3 start", i)
+
+	ec := party.params.EC()
+	minusOne := new(big.Int).Sub(ec.Params().N, big.NewInt(1))
+	commitmentCount := party.save.SignThreshold() - 1
+	if party.save.IsAdditive() {
+		commitmentCount = len(party.save.Ks)
+	}
+
+	for j := 0; j < len(party.temp.rfRound2Messages); j++ {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.rfRound2Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			return
+		}
+		r2Msg := pMsg.Content().(*m.RFRound2Message)
+
+		if !bytes.Equal(r2Msg.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+
+		commitments, err := r2Msg.UnmarshalCommitments(ec)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal r2msg commitments err:%s", err.Error())))
+			return
+		}
+		if len(commitments) != commitmentCount {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("commitments length err: %d, party: %d", len(commitments), j)))
+			return
+		}
+		pedPK := r2Msg.UnmarshalPedersenPK()
+
+		// Verify commited V_j
+		v := party.hashV(j, r2Msg.GetSrid(), commitments, pedPK, r2Msg.GetU())
+		if !bytes.Equal(v, party.temp.V[j]) {
+			result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("hash != V, party: %d", j)))
+			return
+		}
+		party.temp.commitments[j] = commitments
+		party.temp.paillierPKs[j] = r2Msg.UnmarshalPaillierPK()
+		party.temp.pedPKs[j] = pedPK
+
+		// Verify the deltas of Pj sum to zero, D_1 + .. + D_{n-1} = -D_n; for threshold keys f_j(0) = 0 holds
+		// as the commitments leave out the constant term
+		if party.save.IsAdditive() {
+			last := len(commitments) - 1
+			sum := commitments[0]
+			for _, c := range commitments[1:last] {
+				if sum, err = sum.Add(c); err != nil {
+					result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc delta sum failed, party: %d", j)))
+					return
+				}
+			}
+			if !sum.Equals(commitments[last].ScalarMult(minusOne)) {
+				result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("deltas do not sum to zero, party: %d", j)))
+				return
+			}
+		}
+
+		// Verify the delta Pj dealt to us
+		pMsg, err = tss.ParseWireMsg(party.temp.rfRound2Message2s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg2 fail, err: %s, j: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("msg error, parse wire msg2 fail, err: %s, j: %d", err.Error(), j)
+			return
+		}
+		r2msg2, ok := pMsg.Content().(*m.RFRound2Message2)
+		if !ok || !r2msg2.ValidateBasic() {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("not RFRound2Message2, party: %d", j)))
+			return
+		}
+		if int(r2msg2.GetTo()) != i {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("RFRound2Message2 to party %d, party: %d", r2msg2.GetTo(), j)))
+			return
+		}
+		plaintext, ok := box.OpenAnonymous(nil, r2msg2.GetSealedDelta(), party.temp.encPK, party.temp.encSK)
+		if !ok {
+			result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("open sealed delta failed, party: %d", j)))
+			return
+		}
+		delta := new(big.Int).SetBytes(plaintext)
+		D, err := party.deltaPoint(j, i)
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc delta point failed, party: %d", j)))
+			return
+		}
+		if !crypto.ScalarBaseMult(ec, delta).Equals(D) {
+			result.abort(party.blame(tss.ReasonBadShare, j, fmt.Errorf("delta verify failed, party: %d", j)))
+			return
+		}
+		party.temp.receivedDeltas[j] = delta
+
+		// Set srid as xor of all party's srid_i
+		party.temp.srid = utils.Xor(party.temp.srid, r2Msg.GetSrid())
+	}
+	party.temp.receivedDeltas[i] = party.temp.deltas[i]
+
+	// Prove the new Paillier key and ring-Pedersen parameters
+	modProof, prmProof, err := keygen.ProveAuxInfo(party.auxContext(i), party.temp.paillierSK, party.temp.pedSK, party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("prove aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("prove aux info err: %s", err.Error())
+		return
+	}
+
+	// BROADCAST proofs
+	bmsg, err := m.NewRFRound3Message(party.PartyID(), modProof, prmProof)
+	if err != nil {
+		common.Logger.Errorf("new round_3 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_3 msg err: %s", err.Error())
+		return
+	}
+	msgWireBytes, _, err := bmsg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.rfRound3Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func RefreshRound3Accept(key string, from int, msgWireBytes string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	if _, ok := msg.Content().(*m.RFRound3Message); !ok {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not RFRound3Message")))
+		return
+	}
+	party.temp.rfRound3Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func RefreshRound3Finish(key string) (result RefreshResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rfRound3Messages {
+		if j == party.PartyID().Index {
+			continue
+		}
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
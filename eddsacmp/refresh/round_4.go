@@ -0,0 +1,122 @@
+package refresh
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/eddsacmp/keygen"
+	m "tss_sdk/eddsacmp/refresh/message"
+	"tss_sdk/tss"
+)
+
+func RefreshRound4Exec(key string) (result RefreshExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 4
+	party.resetOK()
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, refresh round_4 start", i)
+
+	for j := 0; j < len(party.temp.rfRound3Messages); j++ {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.rfRound3Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r3Msg := pMsg.Content().(*m.RFRound3Message)
+
+		modProof, err := r3Msg.UnmarshalModProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal mod proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		prmProof, err := r3Msg.UnmarshalPrmProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal prm proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		if err := keygen.VerifyAuxInfo(party.auxContext(j), party.temp.paillierPKs[j], party.temp.pedPKs[j], modProof, prmProof); err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("aux info verify failed: %s, party: %d", err.Error(), j)))
+			return
+		}
+	}
+
+	// x_i' = x_i + sum of the deltas dealt to us, X_k' = X_k + sum of the delta commitments for Pk
+	modN := common.ModInt(party.params.EC().Params().N)
+	xi := party.save.PrivXi
+	for _, delta := range party.temp.receivedDeltas {
+		xi = modN.Add(xi, delta)
+	}
+
+	pubXj := make([]*crypto.ECPoint, len(party.save.PubXj))
+	for k, Xk := range party.save.PubXj {
+		for j := range party.temp.commitments {
+			D, err := party.deltaPoint(j, k)
+			if err != nil {
+				result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc delta point failed, party: %d", j)))
+				return
+			}
+			if Xk, err = Xk.Add(D); err != nil {
+				result.abort(party.blame(tss.ReasonBadCommitment, j, fmt.Errorf("calc pubxj failed, party: %d", j)))
+				return
+			}
+		}
+		pubXj[k] = Xk
+	}
+	// every delta was checked against its dealer's commitments in round 3, so no one is to blame below
+	if !pubXj[i].Equals(crypto.ScalarBaseMult(party.params.EC(), xi)) {
+		result.abort(tss.NewError(fmt.Errorf("x_i * G != X_i, party: %d", i), TaskName, party.number, party.PartyID()))
+		return
+	}
+	if party.save.IsAdditive() {
+		pub := pubXj[0]
+		var err error
+		for _, Xk := range pubXj[1:] {
+			if pub, err = pub.Add(Xk); err != nil {
+				result.abort(tss.NewError(errors.New("calc pubkey failed"), TaskName, party.number, party.PartyID()))
+				return
+			}
+		}
+		if !pub.Equals(party.save.EdDSAPub) {
+			result.abort(tss.NewError(errors.New("refreshed pubkey != eddsa pubkey"), TaskName, party.number, party.PartyID()))
+			return
+		}
+	}
+
+	party.save.PrivXi = xi
+	party.save.PubXj = pubXj
+	party.save.PaillierSK = party.temp.paillierSK
+	party.save.PaillierPKs = party.temp.paillierPKs
+	party.save.RingPedersenPKs = party.temp.pedPKs
+
+	saveBytes, err := json.Marshal(party.save)
+	if err != nil {
+		common.Logger.Errorf("round_4 save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_4 save err: %s", err.Error())
+		return
+	}
+	common.Logger.Infof("party: %d, refresh round_4 save", i)
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
@@ -0,0 +1,5 @@
+package refresh
+
+const (
+	TaskName = "eddsa-cmp-refresh"
+)
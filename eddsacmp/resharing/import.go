@@ -0,0 +1,172 @@
+package resharing
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/alice/utils"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/tss"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// Key import is a resharing whose old committee is the single party holding an existing Ed25519 key:
+// the importer deals the key to the new committee in round 1, sealing every share to the enc key the
+// new party sent it, and every new party checks the dealt key against the public key of the imported
+// wallet in round 3, so the address does not change.
+
+const (
+	ImportSeed   = "seed"   // RFC 8032 32-byte private key, the scalar is derived from SHA-512(seed)
+	ImportScalar = "scalar" // the 32-byte little-endian scalar, or the 64-byte expanded key starting with it
+)
+
+// NewImporterParty creates the old committee party of a key import, it holds the key to import.
+// The imported key is only kept until round 1 has dealt it, which needs the enc keys of the whole new committee.
+func NewImporterParty(
+	key string,
+	importerID string,
+	newPIDs []string,
+	newThreshold int,
+	privKey string, // hex string
+	keyFormat string, // ImportSeed or ImportScalar
+	pubKey string, // the Ed25519 public key of the imported wallet, hex string
+	chainCodes string, // optional, hex string array
+) (result ResharingResult) {
+	x, err := importScalar(privKey, keyFormat)
+	if err != nil {
+		common.Logger.Errorf("import key err: %s", err.Error())
+		result.Err = fmt.Sprintf("import key err: %s", err.Error())
+		return
+	}
+	ec := tss.Edwards()
+	pub, err := parseEdDSAPub(ec, pubKey)
+	if err != nil {
+		common.Logger.Errorf("parse pubkey err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse pubkey err: %s", err.Error())
+		return
+	}
+	if !crypto.ScalarBaseMult(ec, x).Equals(pub) {
+		common.Logger.Errorf("imported key does not match the pubkey")
+		result.Err = "imported key does not match the pubkey"
+		return
+	}
+
+	p, err := newLocalParty(ec, 0, []string{importerID}, newPIDs, 1, newThreshold, true)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	// a 1-of-1 additive key, round 1 deals it as w_i = x
+	input := keygen.NewLocalPartySaveData(1)
+	input.PrivXi = x
+	input.ShareID = p.PartyID().KeyInt()
+	input.Ks = []*big.Int{input.ShareID}
+	input.PubXj[0] = pub
+	input.EdDSAPub = pub
+	input.Threshold = 1
+	if chainCodes != "" {
+		for k, code := range strings.Split(chainCodes, "|") {
+			c, ok := new(big.Int).SetString(code, 16)
+			if !ok {
+				common.Logger.Errorf("hex decode chaincode err, k: %d, chaincode: %s", k, code)
+				result.Err = fmt.Sprintf("hex decode chaincode err, k: %d, chaincode: %s", k, code)
+				return
+			}
+			input.ChainCodes = append(input.ChainCodes, c)
+		}
+	}
+	p.input = input
+	p.taskName = ImportTaskName
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+// NewImportParty creates the party of a future share holder of an imported key.
+// The device of the importer runs one of these besides its NewImporterParty.
+func NewImportParty(
+	key string,
+	partyIndex int, // index in newPIDs
+	importerID string,
+	newPIDs []string,
+	newThreshold int,
+	pubKey string, // the Ed25519 public key of the imported wallet, hex string
+) (result ResharingResult) {
+	pub, err := parseEdDSAPub(tss.Edwards(), pubKey)
+	if err != nil {
+		common.Logger.Errorf("parse pubkey err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse pubkey err: %s", err.Error())
+		return
+	}
+
+	p, err := newCommitteeParty(tss.Edwards(), partyIndex, []string{importerID}, newPIDs, newThreshold)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	p.taskName = ImportTaskName
+	p.expectedPub = pub
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+// importScalar returns the secret scalar of an Ed25519 key, reduced mod N
+func importScalar(privKey string, keyFormat string) (*big.Int, error) {
+	bz, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode privKey err: %s", err.Error())
+	}
+	var scalar []byte
+	switch keyFormat {
+	case ImportSeed:
+		if len(bz) != 32 {
+			return nil, fmt.Errorf("seed length err: %d", len(bz))
+		}
+		h := sha512.Sum512(bz)
+		h[0] &= 248
+		h[31] &= 127
+		h[31] |= 64
+		scalar = h[:32]
+	case ImportScalar:
+		if len(bz) != 32 && len(bz) != 64 {
+			return nil, fmt.Errorf("scalar length err: %d", len(bz))
+		}
+		scalar = bz[:32]
+	default:
+		return nil, fmt.Errorf("unknown key format: %s", keyFormat)
+	}
+	x := new(big.Int).SetBytes(utils.ReverseByte(scalar))
+	x.Mod(x, tss.Edwards().Params().N)
+	if x.Sign() == 0 {
+		return nil, errors.New("scalar is zero")
+	}
+	return x, nil
+}
+
+func parseEdDSAPub(ec elliptic.Curve, pubKey string) (*crypto.ECPoint, error) {
+	bz, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := edwards.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewECPoint(ec, pk.GetX(), pk.GetY())
+}
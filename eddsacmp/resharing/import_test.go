@@ -0,0 +1,65 @@
+package resharing_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"tss_sdk/eddsacmp/resharing"
+	"tss_sdk/test"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubHex := hex.EncodeToString(pub)
+	saves, errStr := test.Import(t, hex.EncodeToString(priv.Seed()), resharing.ImportSeed, pubHex, pubHex, []string{"11", "12", "13"}, 2)
+	require.Empty(t, errStr)
+	require.Equal(t, pub, test.ChildPub(t, saves[0], ""))
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{0, 2}, 2, "deadbeef", "", ""))
+	// no chain codes were imported, so there are no child keys
+	_, err = test.SaveData(t, saves[0]).ChildTweak(walletPath)
+	require.EqualError(t, err, "the key has no chain codes, it derives no child keys")
+
+	// the clamped scalar of the seed
+	h := sha512.Sum512(priv.Seed())
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	saves, errStr = test.Import(t, hex.EncodeToString(h[:]), resharing.ImportScalar, pubHex, pubHex, []string{"11", "12"}, 2)
+	require.Empty(t, errStr)
+	test.CheckSig(t, pub, test.Sign(t, saves, []int{0, 1}, 2, "cafe", "", ""))
+
+	// the new committee expects another wallet
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, errStr = test.Import(t, hex.EncodeToString(priv.Seed()), resharing.ImportSeed, pubHex, hex.EncodeToString(other), []string{"11"}, 1)
+	require.NotEmpty(t, errStr)
+	require.False(t, resharing.NewImporterParty("importer-bad", test.ImporterID, []string{"11", "12"}, 2, hex.EncodeToString(priv.Seed()), resharing.ImportSeed, hex.EncodeToString(other), "").Ok)
+}
+
+func TestImportNeedsEncKeys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubHex := hex.EncodeToString(pub)
+	newPIDs := []string{"11"}
+	manifest := test.Manifest(t, resharing.ImportTaskName, []string{test.ImporterID}, newPIDs, 1)
+	require.True(t, resharing.NewImporterParty("importer-enc", test.ImporterID, newPIDs, 1, hex.EncodeToString(priv.Seed()), resharing.ImportSeed, pubHex, "").Ok)
+	t.Cleanup(func() { resharing.RemoveParty("importer-enc") })
+	require.True(t, resharing.SetSessionManifest("importer-enc", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	require.True(t, resharing.NewImportParty("import-enc-0", 0, test.ImporterID, newPIDs, 1, pubHex).Ok)
+	t.Cleanup(func() { resharing.RemoveParty("import-enc-0") })
+	require.True(t, resharing.SetSessionManifest("import-enc-0", manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+
+	// the importer deals no share it cannot seal, and keeps the key until it can
+	r := resharing.ResharingRound1Exec("importer-enc")
+	require.False(t, r.Ok)
+	require.Contains(t, r.Err, "enc key msg is null")
+	test.SendEncKeys(t, []string{"importer-enc"}, []string{"import-enc-0"})
+	r = resharing.ResharingRound1Exec("importer-enc")
+	require.True(t, r.Ok, r.Err)
+}
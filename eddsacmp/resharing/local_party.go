@@ -0,0 +1,404 @@
+package resharing
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	"tss_sdk/crypto/ckd"
+	"tss_sdk/crypto/paillier"
+	"tss_sdk/crypto/vss"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/tss"
+
+	"github.com/ipfs/go-log"
+)
+
+// Implements Party
+// Implements Stringer
+// var _ tss.Party = (*LocalParty)(nil)
+// var _ fmt.Stringer = (*LocalParty)(nil)
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.ReSharingParameters
+
+		// the key being reshared, only known to the old committee
+		input    keygen.LocalPartySaveData
+		temp     localTempData
+		save     keygen.LocalPartySaveData
+		manifest *tss.SessionManifest
+		taskName string
+		// new committee: the group key and chain codes it must end up with, checked in the final round;
+		// a key import has no expected chain codes
+		expectedPub        *crypto.ECPoint
+		expectedChainCodes []*big.Int
+		// a device in both committees runs one party for each
+		isOld  bool
+		number int
+	}
+
+	localMessageStore struct {
+		rsEncKeyMessages [][]byte // msg.WireBytes(), from the new committee to the old committee
+		rsRound1Messages,
+		rsRound1Message2s [][]byte // msg.WireBytes(), from the old committee
+		rsRound2Messages [][]byte // msg.WireBytes(), from the new committee
+	}
+
+	sendMessageStore struct {
+		rsRound1Message2s [][]byte // msg.WireBytes(), to the new committee
+	}
+
+	localTempData struct {
+		localMessageStore
+		send sendMessageStore
+
+		// temp data (thrown away after resharing)
+
+		ssid []byte
+
+		// old committee: shares of w_i dealt to the new committee
+		shares vss.Shares
+
+		// new committee: vs[j] are the commitments of old party Pj
+		vs []vss.Vs
+
+		// new committee aux info
+		paillierSK *paillier.PrivateKey
+		pedSK      *pailliera.PedPrivKey
+
+		// X25519 keys the dealt shares are sealed to: the one of the new party, and the old committee's
+		// copy of those of every new party
+		encPK, encSK *[32]byte
+		encPKs       []*[32]byte
+	}
+)
+
+var Parties = map[string]*LocalParty{}
+
+// NewOldCommitteeParty creates the party of a current share holder.
+// oldPIDs are the old parties taking part, at least the key threshold, or every party of an n-of-n key.
+func NewOldCommitteeParty(
+	key string,
+	partyIndex int, // index in oldPIDs
+	oldPIDs []string,
+	newPIDs []string,
+	newThreshold int,
+	keyData string, // keygen.LocalPartySaveData, base64 string
+) (result ResharingResult) {
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := keygen.LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, &keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.EdDSAPub == nil {
+		common.Logger.Errorf("keygen save data without group pubkey")
+		result.Err = "keygen save data without group pubkey"
+		return
+	}
+
+	if len(oldPIDs) < keys.SignThreshold() || (keys.IsAdditive() && len(oldPIDs) != len(keys.Ks)) {
+		common.Logger.Errorf("old party count err: %d, key threshold: %d", len(oldPIDs), keys.SignThreshold())
+		result.Err = fmt.Sprintf("old party count err: %d, key threshold: %d", len(oldPIDs), keys.SignThreshold())
+		return
+	}
+
+	p, err := newLocalParty(keys.EdDSAPub.Curve(), partyIndex, oldPIDs, newPIDs, keys.SignThreshold(), newThreshold, true)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	if p.params.PartyID().KeyInt().Cmp(keys.ShareID) != 0 {
+		common.Logger.Errorf("party index err: %d", partyIndex)
+		result.Err = fmt.Sprintf("party index err: %d", partyIndex)
+		return
+	}
+	// the share holders must be parties of the key
+	keySet := make(map[string]struct{}, len(keys.Ks))
+	for _, k := range keys.Ks {
+		keySet[k.String()] = struct{}{}
+	}
+	for _, Pj := range p.params.OldParties().IDs() {
+		if _, ok := keySet[Pj.KeyInt().String()]; !ok {
+			common.Logger.Errorf("old party not in keygen data: %d", Pj.Index)
+			result.Err = fmt.Sprintf("old party not in keygen data: %d", Pj.Index)
+			return
+		}
+	}
+	p.input = keys
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+// NewNewCommitteeParty creates the party of a future share holder. groupKey is the watch-only key of the
+// key being reshared, ckd.GroupExtendedKey serialized, which the new party must get from a source it
+// trusts rather than from the old committee: the final round fails unless the reshared key has its group
+// key and chain codes, so the addresses of the wallet stay the same. It also gives the curve and the
+// derivation scheme of the key.
+func NewNewCommitteeParty(
+	key string,
+	partyIndex int, // index in newPIDs
+	oldPIDs []string,
+	newPIDs []string,
+	newThreshold int,
+	groupKey string,
+) (result ResharingResult) {
+	gk, err := ckd.NewGroupExtendedKeyFromString(groupKey)
+	if err != nil {
+		common.Logger.Errorf("parse group key err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse group key err: %s", err.Error())
+		return
+	}
+	p, err := newCommitteeParty(gk.PublicKey.Curve(), partyIndex, oldPIDs, newPIDs, newThreshold)
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	p.expectedPub = gk.PublicKey
+	p.expectedChainCodes = gk.ChainCodes
+	p.save.Derivation = gk.Scheme()
+
+	Parties[key] = p
+	result.Ok = true
+	return
+}
+
+func newCommitteeParty(
+	ec elliptic.Curve,
+	partyIndex int,
+	oldPIDs []string,
+	newPIDs []string,
+	newThreshold int,
+) (*LocalParty, error) {
+	p, err := newLocalParty(ec, partyIndex, oldPIDs, newPIDs, 0, newThreshold, false)
+	if err != nil {
+		return nil, err
+	}
+
+	oldCount, newCount := len(oldPIDs), len(newPIDs)
+	p.save = keygen.NewLocalPartySaveData(newCount)
+	p.save.Threshold = newThreshold
+	p.save.Shamir = true
+	p.save.Ks = p.params.NewParties().IDs().Keys()
+	p.save.ShareID = p.params.PartyID().KeyInt()
+
+	// msgs init
+	p.temp.rsRound1Messages = make([][]byte, oldCount)
+	p.temp.rsRound1Message2s = make([][]byte, oldCount)
+	p.temp.rsRound2Messages = make([][]byte, newCount)
+
+	// temp data init
+	p.temp.vs = make([]vss.Vs, oldCount)
+	return p, nil
+}
+
+func newLocalParty(
+	ec elliptic.Curve,
+	partyIndex int,
+	oldPIDs []string,
+	newPIDs []string,
+	oldThreshold int,
+	newThreshold int,
+	isOld bool,
+) (*LocalParty, error) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		return nil, fmt.Errorf("set log level, err: %s", err.Error())
+	}
+	tss.SetCurve(ec)
+
+	if newThreshold < 1 || newThreshold > len(newPIDs) {
+		common.Logger.Errorf("new threshold err: %d, new party count: %d", newThreshold, len(newPIDs))
+		return nil, fmt.Errorf("new threshold err: %d, new party count: %d", newThreshold, len(newPIDs))
+	}
+
+	partyCount := len(newPIDs)
+	if isOld {
+		partyCount = len(oldPIDs)
+	}
+	if partyIndex < 0 || partyIndex >= partyCount {
+		common.Logger.Errorf("party index err: %d", partyIndex)
+		return nil, fmt.Errorf("party index err: %d", partyIndex)
+	}
+
+	oldIds := sortedPartyIDs(oldPIDs, "old")
+	newIds := sortedPartyIDs(newPIDs, "new")
+	var partyID *tss.PartyID
+	if isOld {
+		partyID = oldIds[partyIndex]
+	} else {
+		partyID = newIds[partyIndex]
+	}
+	params := tss.NewReSharingParameters(
+		ec, tss.NewPeerContext(oldIds), tss.NewPeerContext(newIds), partyID,
+		len(oldPIDs), oldThreshold, len(newPIDs), newThreshold)
+
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		temp:      localTempData{},
+		taskName:  TaskName,
+		isOld:     isOld,
+	}
+	if isOld {
+		p.temp.rsEncKeyMessages = make([][]byte, len(newPIDs))
+	}
+	return p, nil
+}
+
+// blame returns an identifiable abort error naming Pj of the new committee as the culprit of the current round
+func (p *LocalParty) blame(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, p.taskName, p.number, p.PartyID(), p.params.NewParties().IDs()[j])
+}
+
+// blameOld returns an identifiable abort error naming Pj of the old committee as the culprit
+func (p *LocalParty) blameOld(reason string, j int, err error) *tss.Error {
+	return tss.NewAbortError(err, reason, p.taskName, p.number, p.PartyID(), p.params.OldParties().IDs()[j])
+}
+
+func sortedPartyIDs(pIDs []string, committee string) tss.SortedPartyIDs {
+	uIds := make(tss.UnSortedPartyIDs, 0, len(pIDs))
+	for i := 0; i < len(pIDs); i++ {
+		pId, _ := new(big.Int).SetString(pIDs[i], 10)
+		common.Logger.Infof("%s id: %d", committee, pId)
+		uIds = append(uIds, tss.NewPartyID(fmt.Sprintf("%s_%d", committee, i), fmt.Sprintf("m_%s_%d", committee, i), pId))
+	}
+	return tss.SortPartyIDs(uIds)
+}
+
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(party.taskName, party.params.OldParties().IDs().Keys(), party.params.NewParties().IDs().Keys(), party.params.NewThreshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
+// GenerateAuxInfo generates the Paillier key of a new committee party ahead of the session, it takes minutes.
+// The result is a JSON paillier.PrivateKey, each one must be set to a single party.
+func GenerateAuxInfo() (result ResharingExecResult) {
+	paillierSK, err := keygen.GeneratePaillierKey(rand.Reader)
+	if err != nil {
+		common.Logger.Errorf("generate paillier key err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate paillier key err: %s", err.Error())
+		return
+	}
+	bz, err := json.Marshal(paillierSK)
+	if err != nil {
+		result.Err = fmt.Sprintf("marshal paillier key err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = bz
+	return
+}
+
+// auxInfo: the output of GenerateAuxInfo, base64 string; must be set before round 2 to a new committee
+// party, which then does not generate its own Paillier key in round 2
+func SetAuxInfo(key string, auxInfo string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+	if party.number >= 2 {
+		result.Err = "aux info must be set before round 2"
+		return
+	}
+
+	bz, err := base64.StdEncoding.DecodeString(auxInfo)
+	if err != nil {
+		result.Err = fmt.Sprintf("aux info base64 decode err: %s", err.Error())
+		return
+	}
+	var sk paillier.PrivateKey
+	if err := json.Unmarshal(bz, &sk); err != nil {
+		result.Err = fmt.Sprintf("aux info unmarshal err: %s", err.Error())
+		return
+	}
+	paillierSK, err := keygen.PaillierKey(sk.P, sk.Q)
+	if err != nil {
+		common.Logger.Errorf("aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("aux info err: %s", err.Error())
+		return
+	}
+	party.temp.paillierSK = paillierSK
+	result.Ok = true
+	return
+}
+
+func RemoveParty(key string) bool {
+	if _, ok := Parties[key]; !ok {
+		return false
+	}
+	delete(Parties, key)
+	return true
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// auxContext binds the aux info proofs of new party j to this session
+func (p *LocalParty) auxContext(j int) []byte {
+	return common.SHA512_256(p.temp.ssid, []byte(strconv.Itoa(j)))
+}
+
+// get ssid from the session manifest and both committees
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	keys := append(p.params.OldParties().IDs().Keys(), p.params.NewParties().IDs().Keys()...)
+	return p.manifest.SSID(p.params.EC(), keys), nil
+}
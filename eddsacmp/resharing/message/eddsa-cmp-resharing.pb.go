@@ -0,0 +1,440 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protob/eddsa-cmp-resharing.proto
+
+package message
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to the old committee by each new party before Round 1 of the EDDSA TSS
+// resharing protocol.
+type RSEncKeyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssid []byte `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	// X25519 key of the sender, the shares dealt to it in round 1 are sealed to this key
+	EncPub []byte `protobuf:"bytes,2,opt,name=enc_pub,json=encPub,proto3" json:"enc_pub,omitempty"`
+}
+
+func (x *RSEncKeyMessage) Reset() {
+	*x = RSEncKeyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RSEncKeyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RSEncKeyMessage) ProtoMessage() {}
+
+func (x *RSEncKeyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RSEncKeyMessage.ProtoReflect.Descriptor instead.
+func (*RSEncKeyMessage) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_resharing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RSEncKeyMessage) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *RSEncKeyMessage) GetEncPub() []byte {
+	if x != nil {
+		return x.EncPub
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to the new committee during Round 1 of the EDDSA TSS resharing protocol.
+type RSRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssid      []byte `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	EddsaPubX []byte `protobuf:"bytes,2,opt,name=eddsa_pub_x,json=eddsaPubX,proto3" json:"eddsa_pub_x,omitempty"`
+	EddsaPubY []byte `protobuf:"bytes,3,opt,name=eddsa_pub_y,json=eddsaPubY,proto3" json:"eddsa_pub_y,omitempty"`
+	// Feldman commitments to the coefficients of the dealt polynomial, flattened as x, y
+	Vs         [][]byte `protobuf:"bytes,4,rep,name=vs,proto3" json:"vs,omitempty"`
+	ChainCodes [][]byte `protobuf:"bytes,5,rep,name=chain_codes,json=chainCodes,proto3" json:"chain_codes,omitempty"`
+}
+
+func (x *RSRound1Message) Reset() {
+	*x = RSRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RSRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RSRound1Message) ProtoMessage() {}
+
+func (x *RSRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RSRound1Message.ProtoReflect.Descriptor instead.
+func (*RSRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_resharing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RSRound1Message) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *RSRound1Message) GetEddsaPubX() []byte {
+	if x != nil {
+		return x.EddsaPubX
+	}
+	return nil
+}
+
+func (x *RSRound1Message) GetEddsaPubY() []byte {
+	if x != nil {
+		return x.EddsaPubY
+	}
+	return nil
+}
+
+func (x *RSRound1Message) GetVs() [][]byte {
+	if x != nil {
+		return x.Vs
+	}
+	return nil
+}
+
+func (x *RSRound1Message) GetChainCodes() [][]byte {
+	if x != nil {
+		return x.ChainCodes
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each new party during Round 1 of the EDDSA TSS resharing protocol.
+type RSRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the share, sealed to the X25519 key the recipient sent before round 1
+	SealedShare []byte `protobuf:"bytes,1,opt,name=sealed_share,json=sealedShare,proto3" json:"sealed_share,omitempty"`
+	// index of the recipient in the new committee
+	To int32 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *RSRound1Message2) Reset() {
+	*x = RSRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RSRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RSRound1Message2) ProtoMessage() {}
+
+func (x *RSRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RSRound1Message2.ProtoReflect.Descriptor instead.
+func (*RSRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_resharing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RSRound1Message2) GetSealedShare() []byte {
+	if x != nil {
+		return x.SealedShare
+	}
+	return nil
+}
+
+func (x *RSRound1Message2) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// Represents a BROADCAST message sent within the new committee during Round 2 of the EDDSA TSS resharing protocol.
+type RSRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaillierN []byte   `protobuf:"bytes,1,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+	PedersenS []byte   `protobuf:"bytes,2,opt,name=pedersen_s,json=pedersenS,proto3" json:"pedersen_s,omitempty"`
+	PedersenT []byte   `protobuf:"bytes,3,opt,name=pedersen_t,json=pedersenT,proto3" json:"pedersen_t,omitempty"`
+	ModProof  [][]byte `protobuf:"bytes,4,rep,name=mod_proof,json=modProof,proto3" json:"mod_proof,omitempty"`
+	PrmProof  []byte   `protobuf:"bytes,5,opt,name=prm_proof,json=prmProof,proto3" json:"prm_proof,omitempty"`
+}
+
+func (x *RSRound2Message) Reset() {
+	*x = RSRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RSRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RSRound2Message) ProtoMessage() {}
+
+func (x *RSRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_cmp_resharing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RSRound2Message.ProtoReflect.Descriptor instead.
+func (*RSRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_cmp_resharing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RSRound2Message) GetPaillierN() []byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+func (x *RSRound2Message) GetPedersenS() []byte {
+	if x != nil {
+		return x.PedersenS
+	}
+	return nil
+}
+
+func (x *RSRound2Message) GetPedersenT() []byte {
+	if x != nil {
+		return x.PedersenT
+	}
+	return nil
+}
+
+func (x *RSRound2Message) GetModProof() [][]byte {
+	if x != nil {
+		return x.ModProof
+	}
+	return nil
+}
+
+func (x *RSRound2Message) GetPrmProof() []byte {
+	if x != nil {
+		return x.PrmProof
+	}
+	return nil
+}
+
+var File_protob_eddsa_cmp_resharing_proto protoreflect.FileDescriptor
+
+var file_protob_eddsa_cmp_resharing_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x6d, 0x70, 0x2d, 0x72, 0x65, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x20, 0x6c, 0x65, 0x67, 0x65, 0x6e, 0x64, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x65, 0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2e, 0x72, 0x65, 0x73, 0x68, 0x61,
+	0x72, 0x69, 0x6e, 0x67, 0x22, 0x3e, 0x0a, 0x0f, 0x52, 0x53, 0x45, 0x6e, 0x63, 0x4b, 0x65, 0x79,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x65,
+	0x6e, 0x63, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e,
+	0x63, 0x50, 0x75, 0x62, 0x22, 0x96, 0x01, 0x0a, 0x0f, 0x52, 0x53, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0b,
+	0x65, 0x64, 0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x65, 0x64, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x58, 0x12, 0x1e, 0x0a, 0x0b,
+	0x65, 0x64, 0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x65, 0x64, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x59, 0x12, 0x0e, 0x0a, 0x02,
+	0x76, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x76, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x45, 0x0a,
+	0x10, 0x52, 0x53, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x5f, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x02, 0x74, 0x6f, 0x22, 0xa8, 0x01, 0x0a, 0x0f, 0x52, 0x53, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x32, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c,
+	0x6c, 0x69, 0x65, 0x72, 0x5f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61,
+	0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x64, 0x65, 0x72,
+	0x73, 0x65, 0x6e, 0x5f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x65, 0x64,
+	0x65, 0x72, 0x73, 0x65, 0x6e, 0x53, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73,
+	0x65, 0x6e, 0x5f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x65, 0x64, 0x65,
+	0x72, 0x73, 0x65, 0x6e, 0x54, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x5f, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6d, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42,
+	0x14, 0x5a, 0x12, 0x65, 0x64, 0x64, 0x73, 0x61, 0x63, 0x6d, 0x70, 0x2f, 0x72, 0x65, 0x73, 0x68,
+	0x61, 0x72, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_eddsa_cmp_resharing_proto_rawDescOnce sync.Once
+	file_protob_eddsa_cmp_resharing_proto_rawDescData = file_protob_eddsa_cmp_resharing_proto_rawDesc
+)
+
+func file_protob_eddsa_cmp_resharing_proto_rawDescGZIP() []byte {
+	file_protob_eddsa_cmp_resharing_proto_rawDescOnce.Do(func() {
+		file_protob_eddsa_cmp_resharing_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_eddsa_cmp_resharing_proto_rawDescData)
+	})
+	return file_protob_eddsa_cmp_resharing_proto_rawDescData
+}
+
+var file_protob_eddsa_cmp_resharing_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_eddsa_cmp_resharing_proto_goTypes = []interface{}{
+	(*RSEncKeyMessage)(nil),  // 0: legend.tsslib.eddsacmp.resharing.RSEncKeyMessage
+	(*RSRound1Message)(nil),  // 1: legend.tsslib.eddsacmp.resharing.RSRound1Message
+	(*RSRound1Message2)(nil), // 2: legend.tsslib.eddsacmp.resharing.RSRound1Message2
+	(*RSRound2Message)(nil),  // 3: legend.tsslib.eddsacmp.resharing.RSRound2Message
+}
+var file_protob_eddsa_cmp_resharing_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_eddsa_cmp_resharing_proto_init() }
+func file_protob_eddsa_cmp_resharing_proto_init() {
+	if File_protob_eddsa_cmp_resharing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_eddsa_cmp_resharing_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RSEncKeyMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_cmp_resharing_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RSRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_cmp_resharing_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RSRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_eddsa_cmp_resharing_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RSRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_eddsa_cmp_resharing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_eddsa_cmp_resharing_proto_goTypes,
+		DependencyIndexes: file_protob_eddsa_cmp_resharing_proto_depIdxs,
+		MessageInfos:      file_protob_eddsa_cmp_resharing_proto_msgTypes,
+	}.Build()
+	File_protob_eddsa_cmp_resharing_proto = out.File
+	file_protob_eddsa_cmp_resharing_proto_rawDesc = nil
+	file_protob_eddsa_cmp_resharing_proto_goTypes = nil
+	file_protob_eddsa_cmp_resharing_proto_depIdxs = nil
+}
@@ -0,0 +1,188 @@
+package message
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	pailliera "tss_sdk/crypto/alice/paillier"
+	paillierzkproof "tss_sdk/crypto/alice/zkproof/paillier"
+	"tss_sdk/crypto/modproof"
+	"tss_sdk/crypto/paillier"
+	"tss_sdk/crypto/vss"
+	"tss_sdk/tss"
+)
+
+// ----- //
+
+func NewRSEncKeyMessage(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	ssid []byte,
+	encPub *[32]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: true,
+	}
+	content := &RSEncKeyMessage{
+		Ssid:   ssid,
+		EncPub: encPub[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RSEncKeyMessage) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetSsid()) && len(m.GetEncPub()) == 32
+}
+
+func (m *RSEncKeyMessage) UnmarshalEncPub() *[32]byte {
+	encPub := new([32]byte)
+	copy(encPub[:], m.GetEncPub())
+	return encPub
+}
+
+// ----- //
+
+func NewRSRound1Message(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	ssid []byte,
+	eddsaPub *crypto.ECPoint,
+	vs vss.Vs,
+	chainCodes []*big.Int,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: true,
+	}
+	vsFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return nil, err
+	}
+	content := &RSRound1Message{
+		Ssid:       ssid,
+		EddsaPubX:  eddsaPub.X().Bytes(),
+		EddsaPubY:  eddsaPub.Y().Bytes(),
+		Vs:         common.BigIntsToBytes(vsFlat),
+		ChainCodes: common.BigIntsToBytes(chainCodes),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RSRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetEddsaPubX()) &&
+		common.NonEmptyBytes(m.GetEddsaPubY()) &&
+		len(m.GetVs()) > 0
+}
+
+func (m *RSRound1Message) UnmarshalEdDSAPub(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetEddsaPubX()),
+		new(big.Int).SetBytes(m.GetEddsaPubY()),
+	)
+}
+
+func (m *RSRound1Message) UnmarshalVs(ec elliptic.Curve) (vss.Vs, error) {
+	return crypto.UnFlattenECPoints(ec, common.MultiBytesToBigInts(m.GetVs()))
+}
+
+func (m *RSRound1Message) UnmarshalChainCodes() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetChainCodes())
+}
+
+// ----- //
+
+func NewRSRound1Message2(
+	to, from *tss.PartyID,
+	sealedShare []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RSRound1Message2{
+		SealedShare: sealedShare,
+		To:          int32(to.Index),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RSRound1Message2) ValidateBasic() bool {
+	return m != nil && common.NonEmptyBytes(m.GetSealedShare())
+}
+
+// ----- //
+
+func NewRSRound2Message(
+	from *tss.PartyID,
+	paillierPK *paillier.PublicKey,
+	pedersenPK *pailliera.PedPubKey,
+	modProof *modproof.ProofMod,
+	prmProof *paillierzkproof.RingPederssenParameterMessage,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	modPfBzs := modProof.Bytes()
+	prmPfBzs, err := proto.Marshal(prmProof)
+	if err != nil {
+		return nil, err
+	}
+	content := &RSRound2Message{
+		PaillierN: paillierPK.N.Bytes(),
+		PedersenS: pedersenPK.S.Bytes(),
+		PedersenT: pedersenPK.T.Bytes(),
+		ModProof:  modPfBzs[:],
+		PrmProof:  prmPfBzs,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RSRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPaillierN()) &&
+		common.NonEmptyBytes(m.GetPedersenS()) &&
+		common.NonEmptyBytes(m.GetPedersenT()) &&
+		common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts) &&
+		common.NonEmptyBytes(m.GetPrmProof())
+}
+
+func (m *RSRound2Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+// UnmarshalPedersenPK returns the ring-Pedersen parameters of the sender, which share the Paillier modulus
+func (m *RSRound2Message) UnmarshalPedersenPK() *pailliera.PedPubKey {
+	return &pailliera.PedPubKey{
+		N: new(big.Int).SetBytes(m.GetPaillierN()),
+		S: new(big.Int).SetBytes(m.GetPedersenS()),
+		T: new(big.Int).SetBytes(m.GetPedersenT()),
+	}
+}
+
+func (m *RSRound2Message) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
+
+func (m *RSRound2Message) UnmarshalPrmProof() (*paillierzkproof.RingPederssenParameterMessage, error) {
+	prmProof := &paillierzkproof.RingPederssenParameterMessage{}
+	if err := proto.Unmarshal(m.GetPrmProof(), prmProof); err != nil {
+		return nil, err
+	}
+	return prmProof, nil
+}
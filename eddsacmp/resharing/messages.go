@@ -0,0 +1,19 @@
+package resharing
+
+import (
+	msg "tss_sdk/eddsacmp/resharing/message"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-cmp-resharing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that resharing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*msg.RSEncKeyMessage)(nil),
+		(*msg.RSRound1Message)(nil),
+		(*msg.RSRound1Message2)(nil),
+		(*msg.RSRound2Message)(nil),
+	}
+)
@@ -0,0 +1,76 @@
+package resharing_test
+
+import (
+	"testing"
+
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsacmp/resharing"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestReshareThreshold(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	newSaves := test.Reshare(t, saves, []int{0, 2}, []string{"5001", "5002", "5003"}, 2)
+	require.Equal(t, pub, test.ChildPub(t, newSaves[1], walletPath))
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{1, 2}, 2, "deadbeef", walletPath, ""))
+	// to a t = n Shamir key
+	newSaves = test.Reshare(t, newSaves, []int{0, 2}, []string{"7001", "7002"}, 2)
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{0, 1}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestReshareAdditive(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	newSaves := test.Reshare(t, saves, []int{0, 1}, []string{"6001", "6002", "6003"}, 2)
+	require.Equal(t, pub, test.ChildPub(t, newSaves[0], walletPath))
+	test.CheckSig(t, pub, test.Sign(t, newSaves, []int{0, 2}, 2, "deadbeef", walletPath, ""))
+}
+
+func TestReshareExpectedGroupKey(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	other := keygen.WatchOnlyKey(test.B64(test.KeygenFixtures(t, 2, 2)[0]))
+	require.True(t, other.Ok, other.Err)
+	for _, r := range test.ReshareExpecting(t, saves, []int{0, 1}, []string{"8001"}, 1, string(other.MsgWireBytes)) {
+		require.False(t, r.Ok)
+		require.Contains(t, r.Err, "expected")
+	}
+	require.False(t, resharing.NewNewCommitteeParty("expected-bad", 0, []string{"1"}, []string{"8001"}, 1, "xpub").Ok)
+}
+
+func TestReshareMisroutedShare(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	groupKey := keygen.WatchOnlyKey(test.B64(saves[0]))
+	require.True(t, groupKey.Ok, groupKey.Err)
+	oldKeys, newKeys := test.ResharingParties(t, saves, []int{0, 1}, []string{"9001", "9002"}, 2, string(groupKey.MsgWireBytes))
+	test.SendEncKeys(t, oldKeys, newKeys)
+	for j := range oldKeys {
+		r := resharing.ResharingRound1Exec(oldKeys[j])
+		require.True(t, r.Ok, r.Err)
+		require.True(t, resharing.ResharingRound1Accept(newKeys[0], j, test.B64(r.MsgWireBytes)).Ok)
+		// the relay delivers to the new P0 the share the old P1 dealt to the new P1
+		require.True(t, resharing.ResharingRound1Accept(newKeys[0], j, test.B64(resharing.GetRound1Msg2(oldKeys[j], j).MsgWireBytes)).Ok)
+	}
+	require.True(t, resharing.ResharingRound1Finish(newKeys[0]).Ok)
+	r := resharing.ResharingRound2Exec(newKeys[0])
+	require.False(t, r.Ok)
+	require.Equal(t, "1", r.Culprits)
+	require.Equal(t, tss.ReasonBadMessage, r.Reason)
+}
+
+func TestSetAuxInfo(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	groupKey := keygen.WatchOnlyKey(test.B64(saves[0]))
+	require.True(t, groupKey.Ok, groupKey.Err)
+	oldKeys, newKeys := test.ResharingParties(t, saves, []int{0, 1}, []string{"9101"}, 1, string(groupKey.MsgWireBytes))
+	require.False(t, resharing.SetAuxInfo(oldKeys[0], test.B64(test.AuxInfoFixtures(t, 1)[0])).Ok)
+	// 13 is not a safe prime, and 7 * 11 is far too small a modulus
+	require.Contains(t, resharing.SetAuxInfo(newKeys[0], test.B64([]byte(`{"P":13,"Q":11}`))).Err, "safe prime")
+	require.Contains(t, resharing.SetAuxInfo(newKeys[0], test.B64([]byte(`{"P":7,"Q":11}`))).Err, "too small")
+	require.False(t, resharing.SetAuxInfo(newKeys[0], "garbage").Ok)
+}
@@ -0,0 +1,351 @@
+package resharing
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto/vss"
+	m "tss_sdk/eddsacmp/resharing/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// ResharingExecResult and ResharingResult give the culprits by their index in their committee:
+// the old committee for the dealt shares of round 1, the new committee for the aux info of round 2
+type ResharingExecResult struct {
+	Ok           bool   `json:"ok"`
+	Err          string `json:"error"`
+	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+type ResharingResult struct {
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *ResharingExecResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+func (result *ResharingResult) abort(err *tss.Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+// ResharingEncKeyExec is run by the new committee before round 1, it broadcasts to the old committee the
+// X25519 key the shares dealt to us are sealed to
+func ResharingEncKeyExec(key string) (result ResharingExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		common.Logger.Errorf("party is not in the new committee: %s", key)
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	party.temp.encPK, party.temp.encSK, err = box.GenerateKey(party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("generate enc key err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate enc key err: %s", err.Error())
+		return
+	}
+
+	msg := m.NewRSEncKeyMessage(party.params.OldParties().IDs(), party.PartyID(), party.temp.ssid, party.temp.encPK)
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// ResharingEncKeyAccept is run by the old committee, from is the index of the sender in the new committee
+func ResharingEncKeyAccept(key string, from int, msgWireBytes string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.isOld {
+		result.Err = fmt.Sprintf("party is not in the old committee: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.rsEncKeyMessages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	if content, ok := msg.Content().(*m.RSEncKeyMessage); !ok || !content.ValidateBasic() {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not RSEncKeyMessage")))
+		return
+	}
+	party.temp.rsEncKeyMessages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func ResharingEncKeyFinish(key string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rsEncKeyMessages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
+
+// ResharingRound1Exec is run by the old committee only, each old party deals its additive share w_i
+// to the new committee and is done once the messages are sent. Its culprits are new parties whose
+// key of ResharingEncKeyExec is of another session.
+func ResharingRound1Exec(key string) (result ResharingExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if !party.isOld {
+		common.Logger.Errorf("party is not in the old committee: %s", key)
+		result.Err = fmt.Sprintf("party is not in the old committee: %s", key)
+		return
+	}
+
+	party.number = 1
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, resharing round_1 start", i)
+
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	if party.input.PrivXi == nil {
+		common.Logger.Errorf("key already dealt: %s", key)
+		result.Err = fmt.Sprintf("key already dealt: %s", key)
+		return
+	}
+
+	// the keys of the new parties, the shares are sealed to
+	party.temp.encPKs = make([]*[32]byte, len(party.temp.rsEncKeyMessages))
+	for j, msgBytes := range party.temp.rsEncKeyMessages {
+		if len(msgBytes) == 0 {
+			result.Err = fmt.Sprintf("enc key msg is null: %d", j)
+			return
+		}
+		pMsg, err := tss.ParseWireMsg(msgBytes)
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			return
+		}
+		encKeyMsg := pMsg.Content().(*m.RSEncKeyMessage)
+		if !bytes.Equal(encKeyMsg.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+		party.temp.encPKs[j] = encKeyMsg.UnmarshalEncPub()
+	}
+
+	// w_i = lambda_i * x_i over the old quorum, so that sum(w_i) = x
+	ec := party.params.EC()
+	wi := party.input.PrivXi
+	if !party.input.IsAdditive() {
+		modN := common.ModInt(ec.Params().N)
+		wi = modN.Mul(vss.LagrangeCoefficient(ec, i, party.params.OldParties().IDs().Keys()), wi)
+	}
+
+	// Deal shares of w_i to the new committee
+	newKs := party.params.NewParties().IDs().Keys()
+	vs, shares, err := vss.Create(ec, party.params.NewThreshold(), wi, newKs, party.params.PartialKeyRand())
+	if err != nil {
+		common.Logger.Errorf("vss create err: %s", err.Error())
+		result.Err = fmt.Sprintf("vss create err: %s", err.Error())
+		return
+	}
+	party.temp.shares = shares
+	if party.taskName == ImportTaskName {
+		// the imported key now only exists as the dealt shares
+		party.input.PrivXi = nil
+	}
+
+	// BROADCAST the commitments and the public key data to the new committee
+	msg, err := m.NewRSRound1Message(
+		party.params.NewParties().IDs(),
+		party.PartyID(),
+		party.temp.ssid,
+		party.input.EdDSAPub,
+		vs,
+		party.input.ChainCodes,
+	)
+	if err != nil {
+		common.Logger.Errorf("new round_1 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_1 msg err: %s", err.Error())
+		return
+	}
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+
+	// p2p send the share to the new party Pj, sealed to its key so that only Pj can read it
+	party.temp.send.rsRound1Message2s = make([][]byte, len(shares))
+	for j, Pj := range party.params.NewParties().IDs() {
+		sealedShare, err := box.SealAnonymous(nil, shares[j].Share.Bytes(), party.temp.encPKs[j], party.params.Rand())
+		if err != nil {
+			common.Logger.Errorf("seal share err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("seal share err: %s, party: %d", err.Error(), j)
+			return
+		}
+		r1msg2 := m.NewRSRound1Message2(Pj, party.PartyID(), sealedShare)
+		msg2WireBytes, _, err := r1msg2.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.rsRound1Message2s[j] = msg2WireBytes
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+// GetRound1Msg2 returns the p2p share message for the new party Pj, the share is sealed to the key Pj sent
+// in ResharingEncKeyExec
+func GetRound1Msg2(key string, to int) (result ResharingExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.rsRound1Message2s) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.rsRound1Message2s[to]
+	return
+}
+
+// ResharingRound1Accept is run by the new committee, from is the index of the sender in the old committee
+func ResharingRound1Accept(key string, from int, msgWireBytes string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.rsRound1Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blameOld(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+
+	if _, ok := msg.Content().(*m.RSRound1Message); ok {
+		party.temp.rsRound1Messages[from] = rMsgBytes
+	} else if _, ok := msg.Content().(*m.RSRound1Message2); ok {
+		party.temp.rsRound1Message2s[from] = rMsgBytes
+	} else {
+		result.abort(party.blameOld(tss.ReasonBadMessage, from, errors.New("not RSRound1Message")))
+		return
+	}
+
+	result.Ok = true
+	return
+}
+
+func ResharingRound1Finish(key string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rsRound1Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+		if len(party.temp.rsRound1Message2s[j]) == 0 {
+			result.Err = fmt.Sprintf("msg2 is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,259 @@
+package resharing
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/vss"
+	"tss_sdk/eddsacmp/keygen"
+	m "tss_sdk/eddsacmp/resharing/message"
+	"tss_sdk/tss"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// ResharingRound2Exec is run by the new committee, it verifies the dealt shares and broadcasts the new aux info
+func ResharingRound2Exec(key string) (result ResharingExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		common.Logger.Errorf("party is not in the new committee: %s", key)
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+
+	party.number = 2
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, resharing round_2 start", i)
+
+	if party.temp.encSK == nil {
+		common.Logger.Errorf("enc key not sent: %s", key)
+		result.Err = fmt.Sprintf("enc key not sent: %s", key)
+		return
+	}
+	ssid, err := party.getSSID()
+	if err != nil {
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+	party.temp.ssid = ssid
+
+	ec := party.params.EC()
+	modN := common.ModInt(ec.Params().N)
+	threshold := party.params.NewThreshold()
+	xi := big.NewInt(0)
+	var pub *crypto.ECPoint
+	for j := 0; j < len(party.temp.rsRound1Messages); j++ {
+		pMsg, err := tss.ParseWireMsg(party.temp.rsRound1Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err: %s, j: %d", err.Error(), j)
+			return
+		}
+		r1Msg := pMsg.Content().(*m.RSRound1Message)
+
+		if !bytes.Equal(r1Msg.GetSsid(), party.temp.ssid) {
+			result.abort(party.blameOld(tss.ReasonSessionMismatch, j, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+
+		// Every old party must reshare the same key
+		eddsaPub, err := r1Msg.UnmarshalEdDSAPub(ec)
+		if err != nil {
+			result.abort(party.blameOld(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal r1msg eddsa pub err:%s", err.Error())))
+			return
+		}
+		chainCodes := r1Msg.UnmarshalChainCodes()
+		if j == 0 {
+			party.save.EdDSAPub = eddsaPub
+			party.save.ChainCodes = chainCodes
+		} else if !eddsaPub.Equals(party.save.EdDSAPub) || !equalInts(chainCodes, party.save.ChainCodes) {
+			// either Pj or P0 reshares another key, the final round tells which one against the expected key
+			oldIDs := party.params.OldParties().IDs()
+			result.abort(tss.NewAbortError(fmt.Errorf("eddsa pub or chain codes mismatch, party: %d", j),
+				tss.ReasonBadMessage, party.taskName, party.number, party.PartyID(), oldIDs[0], oldIDs[j]))
+			return
+		}
+
+		vs, err := r1Msg.UnmarshalVs(ec)
+		if err != nil {
+			result.abort(party.blameOld(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal r1msg vs err:%s", err.Error())))
+			return
+		}
+		if len(vs) != threshold {
+			result.abort(party.blameOld(tss.ReasonBadMessage, j, fmt.Errorf("vs length err: %d, party: %d", len(vs), j)))
+			return
+		}
+		party.temp.vs[j] = vs
+
+		// Verify the share Pj dealt to us
+		pMsg, err = tss.ParseWireMsg(party.temp.rsRound1Message2s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg2 fail, err: %s, j: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("msg error, parse wire msg2 fail, err: %s, j: %d", err.Error(), j)
+			return
+		}
+		r1msg2, ok := pMsg.Content().(*m.RSRound1Message2)
+		if !ok || !r1msg2.ValidateBasic() {
+			result.abort(party.blameOld(tss.ReasonBadMessage, j, fmt.Errorf("not RSRound1Message2, party: %d", j)))
+			return
+		}
+		if int(r1msg2.GetTo()) != i {
+			result.abort(party.blameOld(tss.ReasonBadMessage, j, fmt.Errorf("RSRound1Message2 to party %d, party: %d", r1msg2.GetTo(), j)))
+			return
+		}
+		plaintext, ok := box.OpenAnonymous(nil, r1msg2.GetSealedShare(), party.temp.encPK, party.temp.encSK)
+		if !ok {
+			result.abort(party.blameOld(tss.ReasonBadShare, j, fmt.Errorf("open sealed share failed, party: %d", j)))
+			return
+		}
+		share := &vss.Share{Threshold: threshold, ID: party.PartyID().KeyInt(), Share: new(big.Int).SetBytes(plaintext)}
+		if !share.Verify(ec, threshold, vs) {
+			result.abort(party.blameOld(tss.ReasonBadShare, j, fmt.Errorf("share verify failed, party: %d", j)))
+			return
+		}
+		xi = modN.Add(xi, share.Share)
+
+		if pub == nil {
+			pub = vs[0]
+		} else if pub, err = pub.Add(vs[0]); err != nil {
+			result.abort(party.blameOld(tss.ReasonBadCommitment, j, fmt.Errorf("calc pubkey failed, party: %d", j)))
+			return
+		}
+	}
+
+	// The dealt secrets must add up to the reshared key
+	if !pub.Equals(party.save.EdDSAPub) {
+		result.abort(tss.NewError(errors.New("reshared pubkey != eddsa pubkey"), party.taskName, party.number, party.PartyID()))
+		return
+	}
+	party.save.PrivXi = xi
+
+	// Generate the Paillier key of the new share, unless SetAuxInfo gave one, and its ring-Pedersen parameters
+	paillierSK := party.temp.paillierSK
+	if paillierSK == nil {
+		if paillierSK, err = keygen.GeneratePaillierKey(party.params.Rand()); err != nil {
+			common.Logger.Errorf("generate aux info err: %s", err.Error())
+			result.Err = fmt.Sprintf("generate aux info err: %s", err.Error())
+			return
+		}
+	}
+	pedSK := keygen.RingPedersen(party.params.Rand(), paillierSK)
+	party.temp.paillierSK = paillierSK
+	party.temp.pedSK = pedSK
+	party.save.PaillierPKs[i] = &paillierSK.PublicKey
+	party.save.RingPedersenPKs[i] = &pedSK.PedPubKey
+
+	modProof, prmProof, err := keygen.ProveAuxInfo(party.auxContext(i), paillierSK, pedSK, party.params.Rand())
+	if err != nil {
+		common.Logger.Errorf("prove aux info err: %s", err.Error())
+		result.Err = fmt.Sprintf("prove aux info err: %s", err.Error())
+		return
+	}
+
+	// BROADCAST the aux info to the new committee
+	msg, err := m.NewRSRound2Message(party.PartyID(), &paillierSK.PublicKey, &pedSK.PedPubKey, modProof, prmProof)
+	if err != nil {
+		common.Logger.Errorf("new round_2 msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("new round_2 msg err: %s", err.Error())
+		return
+	}
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.rsRound2Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func equalInts(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k].Cmp(b[k]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ResharingRound2Accept is run by the new committee, from is the index of the sender in the new committee
+func ResharingRound2Accept(key string, from int, msgWireBytes string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.rsRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		result.abort(party.blame(tss.ReasonBadMessage, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+		return
+	}
+	if _, ok := msg.Content().(*m.RSRound2Message); !ok {
+		result.abort(party.blame(tss.ReasonBadMessage, from, errors.New("not RSRound2Message")))
+		return
+	}
+	party.temp.rsRound2Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func ResharingRound2Finish(key string) (result ResharingResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.rsRound2Messages {
+		if j == party.PartyID().Index {
+			continue
+		}
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,116 @@
+package resharing
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/eddsacmp/keygen"
+	m "tss_sdk/eddsacmp/resharing/message"
+	"tss_sdk/tss"
+)
+
+// ResharingRound3Exec is run by the new committee, it verifies the aux info of the other new parties and
+// outputs the new keygen save data
+func ResharingRound3Exec(key string) (result ResharingExecResult) {
+	party, ok := Parties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.isOld {
+		common.Logger.Errorf("party is not in the new committee: %s", key)
+		result.Err = fmt.Sprintf("party is not in the new committee: %s", key)
+		return
+	}
+
+	party.number = 3
+
+	i := party.PartyID().Index
+	common.Logger.Infof("party: %d, resharing round_3 start", i)
+
+	for j := 0; j < len(party.temp.rsRound2Messages); j++ {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.rsRound2Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r2Msg := pMsg.Content().(*m.RSRound2Message)
+
+		paillierPK := r2Msg.UnmarshalPaillierPK()
+		pedPK := r2Msg.UnmarshalPedersenPK()
+		modProof, err := r2Msg.UnmarshalModProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal mod proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		prmProof, err := r2Msg.UnmarshalPrmProof()
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, j, fmt.Errorf("unmarshal prm proof err: %s, party: %d", err.Error(), j)))
+			return
+		}
+		if err := keygen.VerifyAuxInfo(party.auxContext(j), paillierPK, pedPK, modProof, prmProof); err != nil {
+			result.abort(party.blame(tss.ReasonBadProof, j, fmt.Errorf("aux info verify failed: %s, party: %d", err.Error(), j)))
+			return
+		}
+		party.save.PaillierPKs[j] = paillierPK
+		party.save.RingPedersenPKs[j] = pedPK
+	}
+
+	// X_k' = sum of the commitments of every old party evaluated at the id of Pk
+	for k, Pk := range party.params.NewParties().IDs() {
+		var Xk *crypto.ECPoint
+		for j, vs := range party.temp.vs {
+			v, err := vs.Evaluate(Pk.KeyInt())
+			if err == nil && Xk != nil {
+				v, err = Xk.Add(v)
+			}
+			if err != nil {
+				result.abort(tss.NewError(fmt.Errorf("calc pubxj failed, party: %d", j), party.taskName, party.number, party.PartyID()))
+				return
+			}
+			Xk = v
+		}
+		party.save.PubXj[k] = Xk
+	}
+	if !party.save.PubXj[i].Equals(crypto.ScalarBaseMult(party.params.EC(), party.save.PrivXi)) {
+		result.abort(tss.NewError(fmt.Errorf("x_i * G != X_i, party: %d", i), party.taskName, party.number, party.PartyID()))
+		return
+	}
+	party.save.PaillierSK = party.temp.paillierSK
+
+	// The reshared key must be the expected one, or its addresses change
+	if !party.save.EdDSAPub.Equals(party.expectedPub) {
+		result.abort(tss.NewError(errors.New("reshared pubkey != expected pubkey"), party.taskName, party.number, party.PartyID()))
+		return
+	}
+	if party.taskName != ImportTaskName && !equalInts(party.save.ChainCodes, party.expectedChainCodes) {
+		result.abort(tss.NewError(errors.New("reshared chain codes != expected chain codes"), party.taskName, party.number, party.PartyID()))
+		return
+	}
+
+	saveBytes, err := json.Marshal(party.save)
+	if err != nil {
+		common.Logger.Errorf("round_3 save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_3 save err: %s", err.Error())
+		return
+	}
+	common.Logger.Infof("party: %d, resharing round_3 save", i)
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
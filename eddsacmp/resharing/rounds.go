@@ -0,0 +1,6 @@
+package resharing
+
+const (
+	TaskName       = "eddsa-cmp-resharing"
+	ImportTaskName = "eddsa-cmp-import"
+)
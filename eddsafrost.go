@@ -0,0 +1,99 @@
+package tss_sdk
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"strings"
+	frostsign "tss_sdk/eddsafrost/sign"
+)
+
+// ---------------------frost sign------------------------
+
+// FROST(Ed25519, SHA-512) signing with a key of NewKeygenLocalParty: two rounds and no Paillier keys,
+// the signature is in the format of OnsignFinalExec
+func NewFrostSignLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // the keygen threshold
+	pIDs string,
+	msg string, // hex string
+	keyData string, // keygen.LocalPartySaveData, base64 string; aux info is not needed
+	walletPath string,
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := frostsign.NewLocalParty(key, partyIndex, partyCount, threshold, ids, msg, keyData, walletPath)
+	return resFromFrostSign(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "eddsa-frost-sign",
+// the parties are the signers and the threshold is the keygen threshold
+func SetFrostSignSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := frostsign.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromFrostSign(res)
+}
+
+func RemoveFrostSignParty(key string) bool {
+	return frostsign.RemoveSignParty(key)
+}
+
+// broadcast the nonce commitments
+func FrostSignRound1Exec(key string) *MpcExecResult {
+	res := frostsign.SignRound1Exec(key)
+	return execResFromFrostSign(res)
+}
+
+func FrostSignRound1MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := frostsign.SignRound1MsgAccept(key, from, msgWireBytes)
+	return resFromFrostSign(res)
+}
+
+func FrostSignRound1Finish(key string) *MpcResult {
+	res := frostsign.SignRound1Finish(key)
+	return resFromFrostSign(res)
+}
+
+// broadcast the signature share
+func FrostSignRound2Exec(key string) *MpcExecResult {
+	res := frostsign.SignRound2Exec(key)
+	return execResFromFrostSign(res)
+}
+
+func FrostSignRound2MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := frostsign.SignRound2MsgAccept(key, from, msgWireBytes)
+	return resFromFrostSign(res)
+}
+
+// verifies the signature shares, the culprits are the signers of the invalid ones
+func FrostSignRound2Finish(key string) *MpcResult {
+	res := frostsign.SignRound2Finish(key)
+	return resFromFrostSign(res)
+}
+
+// the output is common.SignatureData, the 64-byte Ed25519 signature R || s
+func FrostSignFinalExec(key string) *MpcExecResult {
+	res := frostsign.SignFinalExec(key)
+	return execResFromFrostSign(res)
+}
+
+func execResFromFrostSign(res frostsign.SignExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromFrostSign(res frostsign.SignResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
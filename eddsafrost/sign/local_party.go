@@ -0,0 +1,246 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsacmp/onsign"
+	"tss_sdk/tss"
+
+	"github.com/ipfs/go-log"
+)
+
+// FROST(Ed25519, SHA-512) of RFC 9591 with the shares of eddsacmp/keygen, no Paillier keys needed:
+// round 1 broadcasts the nonce commitments D_i = d_i·G and E_i = e_i·G, round 2 binds every E_j to
+// the message and the commitment list with ρ_j and broadcasts z_i = d_i + e_i·ρ_i + c·w_i, and the
+// final round checks every z_j and outputs an Ed25519 signature in the format of onsign.
+
+type (
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys     keygen.LocalPartySaveData
+		temp     localTempData
+		data     *common.SignatureData
+		manifest *tss.SessionManifest
+		number   int
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages [][]byte // msg.WireBytes()
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after sign) / round 1
+		d, e   *big.Int // hiding and binding nonces, cleared once used
+		mBytes []byte
+		pub    *crypto.ECPoint // the signing key, Σ W_j
+
+		// round 2
+		D, E []*crypto.ECPoint
+		rho  []*big.Int // binding factors
+		R    *crypto.ECPoint
+		c    *big.Int
+		zs   []*big.Int // verified in round_2 finish
+
+		ssid []byte
+	}
+)
+
+var SignParties = map[string]*LocalParty{}
+
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // number of parties required to sign, as given to keygen
+	pIDs []string,
+	msg string, // hex string
+	keyData string, // keygen.LocalPartySaveData, base64 string; aux info is not needed
+	walletPath string,
+) (result SignResult) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		result.Err = fmt.Sprintf("set log level, err: %s", err.Error())
+		return
+	}
+	tss.SetCurve(tss.Edwards())
+
+	if partyIndex < 0 || partyIndex >= partyCount || len(pIDs) != partyCount {
+		common.Logger.Errorf("party index err: %d, party count: %d", partyIndex, partyCount)
+		result.Err = fmt.Sprintf("party index err: %d, party count: %d", partyIndex, partyCount)
+		return
+	}
+	uIds := make(tss.UnSortedPartyIDs, 0, partyCount)
+	for i := 0; i < partyCount; i++ {
+		pId, _ := new(big.Int).SetString(pIDs[i], 10)
+		common.Logger.Infof("id: %d", pId)
+		uIds = append(uIds, tss.NewPartyID(fmt.Sprintf("%d", i), fmt.Sprintf("m_%d", i), pId))
+	}
+	ids := tss.SortPartyIDs(uIds)
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(ids), ids[partyIndex], partyCount, threshold)
+
+	keyDataBytes, err := base64.StdEncoding.DecodeString(keyData)
+	if err != nil {
+		common.Logger.Errorf("base64 decode keygen data fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("base64 decode keygen data fail, err:%s", err.Error())
+		return
+	}
+	keys := &keygen.LocalPartySaveData{}
+	if err := json.Unmarshal(keyDataBytes, keys); err != nil {
+		common.Logger.Errorf("unmarshal keygen save data err: %s", err.Error())
+		result.Err = fmt.Sprintf("unmarshal keygen save data err: %s", err.Error())
+		return
+	}
+	if keys.EdDSAPub == nil {
+		result.Err = "keygen data has no public key"
+		return
+	}
+	if name, _ := tss.GetCurveName(keys.EdDSAPub.Curve()); name != tss.Ed25519 {
+		common.Logger.Errorf("keygen data is not an ed25519 key")
+		result.Err = "keygen data is not an ed25519 key"
+		return
+	}
+
+	if threshold != keys.SignThreshold() {
+		common.Logger.Errorf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		result.Err = fmt.Sprintf("threshold err: %d, key threshold: %d", threshold, keys.SignThreshold())
+		return
+	}
+	if partyCount < threshold || (keys.IsAdditive() && partyCount != len(keys.Ks)) {
+		common.Logger.Errorf("party count err: %d, threshold: %d", partyCount, threshold)
+		result.Err = fmt.Sprintf("party count err: %d, threshold: %d", partyCount, threshold)
+		return
+	}
+
+	mBytes, err := hex.DecodeString(msg)
+	if err != nil {
+		common.Logger.Errorf("hex decode msg err: %s", err.Error())
+		result.Err = fmt.Sprintf("hex decode msg err: %s", err.Error())
+		return
+	}
+
+	if err := onsign.DeriveChildKeys(keys, partyIndex, walletPath); err != nil {
+		result.Err = err.Error()
+		return
+	}
+	keyParty, err := keygen.BuildLocalSaveDataSubset(*keys, params.Parties().IDs())
+	if err != nil {
+		result.Err = err.Error()
+		return
+	}
+	if !keys.IsAdditive() {
+		onsign.PrepareForSigning(params.EC(), partyIndex, &keyParty)
+	}
+
+	// the signing key is the sum of the additive public shares
+	pub := keyParty.PubXj[0]
+	for j := 1; j < len(keyParty.PubXj); j++ {
+		if pub, err = pub.Add(keyParty.PubXj[j]); err != nil {
+			common.Logger.Errorf("calc pubkey failed, party: %d", j)
+			result.Err = fmt.Sprintf("calc pubkey failed, party: %d", j)
+			return
+		}
+	}
+
+	p := &LocalParty{
+		BaseParty: new(tss.BaseParty),
+		params:    params,
+		keys:      keyParty,
+		temp:      localTempData{},
+		data:      &common.SignatureData{},
+	}
+	// msgs init
+	p.temp.signRound1Messages = make([][]byte, partyCount)
+	p.temp.signRound2Messages = make([][]byte, partyCount)
+
+	// temp data init
+	p.temp.mBytes = mBytes
+	p.temp.pub = pub
+	p.temp.D = make([]*crypto.ECPoint, partyCount)
+	p.temp.E = make([]*crypto.ECPoint, partyCount)
+	p.temp.rho = make([]*big.Int, partyCount)
+
+	SignParties[key] = p
+	result.Ok = true
+	return
+}
+
+// manifest: tss.SessionManifest, base64 string; must be set before round 1.
+// signature, coordinatorPub: hex strings, the manifest is refused unless the coordinator signed it
+func SetSessionManifest(key string, manifest string, signature string, coordinatorPub string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	sm, err := tss.ParseSessionManifest(manifest, signature, coordinatorPub)
+	if err != nil {
+		common.Logger.Errorf("parse session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse session manifest err: %s", err.Error())
+		return
+	}
+	if err := sm.Check(TaskName, party.params.Parties().IDs().Keys(), nil, party.params.Threshold()); err != nil {
+		common.Logger.Errorf("check session manifest err: %s", err.Error())
+		result.Err = fmt.Sprintf("check session manifest err: %s", err.Error())
+		return
+	}
+	party.manifest = sm
+	result.Ok = true
+	return
+}
+
+func RemoveSignParty(key string) bool {
+	if _, ok := SignParties[key]; !ok {
+		return false
+	}
+	delete(SignParties, key)
+	return true
+}
+
+// blame returns an identifiable abort error naming the signers js as the culprits of the current round
+func (p *LocalParty) blame(reason string, js []int, err error) *tss.Error {
+	culprits := make([]*tss.PartyID, len(js))
+	for k, j := range js {
+		culprits[k] = p.params.Parties().IDs()[j]
+	}
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.PartyID(), culprits...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+// get ssid from the session manifest and local params
+func (p *LocalParty) getSSID() ([]byte, error) {
+	if p.manifest == nil {
+		return nil, errors.New("session manifest not set")
+	}
+	BigXjList, err := crypto.FlattenECPoints(p.keys.PubXj)
+	if err != nil {
+		return nil, errors.New("read BigXj failed")
+	}
+	return p.manifest.SSID(p.params.EC(), p.params.Parties().IDs().Keys(), BigXjList...), nil
+}
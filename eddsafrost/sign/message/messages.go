@@ -0,0 +1,90 @@
+package message
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into eddsa-frost-sign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	ssid []byte,
+	D, E *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		Ssid: ssid,
+		DX:   D.X().Bytes(),
+		DY:   D.Y().Bytes(),
+		EX:   E.X().Bytes(),
+		EY:   E.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		common.NonEmptyBytes(m.GetDY()) &&
+		common.NonEmptyBytes(m.GetEY())
+}
+
+func (m *SignRound1Message) UnmarshalD(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetDX()),
+		new(big.Int).SetBytes(m.GetDY()),
+	)
+}
+
+func (m *SignRound1Message) UnmarshalE(ec elliptic.Curve) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		ec,
+		new(big.Int).SetBytes(m.GetEX()),
+		new(big.Int).SetBytes(m.GetEY()),
+	)
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		Z: zi.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignRound2Message) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
@@ -0,0 +1,119 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	m "tss_sdk/eddsafrost/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignRound1Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 1
+	i := party.PartyID().Index
+	common.Logger.Infof("[frost sign] party: %d, round_1 start", i)
+
+	var err error
+	party.temp.ssid, err = party.getSSID()
+	if err != nil {
+		common.Logger.Errorf("get ssid err: %s", err.Error())
+		result.Err = fmt.Sprintf("get ssid err: %s", err.Error())
+		return
+	}
+
+	// hiding and binding nonces d, e and their commitments D = d·G, E = e·G
+	if party.temp.d, err = nonceGenerate(party.params.Rand(), party.keys.PrivXi); err != nil {
+		common.Logger.Errorf("generate nonce err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate nonce err: %s", err.Error())
+		return
+	}
+	if party.temp.e, err = nonceGenerate(party.params.Rand(), party.keys.PrivXi); err != nil {
+		common.Logger.Errorf("generate nonce err: %s", err.Error())
+		result.Err = fmt.Sprintf("generate nonce err: %s", err.Error())
+		return
+	}
+	ec := party.params.EC()
+	party.temp.D[i] = crypto.ScalarBaseMult(ec, party.temp.d)
+	party.temp.E[i] = crypto.ScalarBaseMult(ec, party.temp.e)
+
+	// broadcast Di, Ei
+	r1msg := m.NewSignRound1Message(party.PartyID(), party.temp.ssid, party.temp.D[i], party.temp.E[i])
+	msgWireBytes, _, err := r1msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound1Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound1MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound1Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound1Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound1Message"
+		return
+	}
+	party.temp.signRound1Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound1Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound1Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,207 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	m "tss_sdk/eddsafrost/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignRound2Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.temp.d == nil || party.temp.e == nil {
+		result.Err = "nonces not generated or already used"
+		return
+	}
+
+	party.number = 2
+	i := party.PartyID().Index
+	common.Logger.Infof("[frost sign] party: %d, round_2 start", i)
+
+	ec := party.params.EC()
+	modQ := common.ModInt(ec.Params().N)
+
+	// read the commitments of the other parties
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound1Messages[j])
+		if err != nil {
+			result.abort(party.blame(tss.ReasonBadMessage, []int{j}, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error())))
+			return
+		}
+		r1msg, ok := pMsg.Content().(*m.SignRound1Message)
+		if !ok {
+			result.abort(party.blame(tss.ReasonBadMessage, []int{j}, fmt.Errorf("not SignRound1Message, party: %d", j)))
+			return
+		}
+		if !bytes.Equal(r1msg.GetSsid(), party.temp.ssid) {
+			result.abort(party.blame(tss.ReasonSessionMismatch, []int{j}, fmt.Errorf("payload.ssid != round.temp.ssid, party: %d", j)))
+			return
+		}
+		Dj, err := r1msg.UnmarshalD(ec)
+		if err != nil || !validElement(Dj) {
+			result.abort(party.blame(tss.ReasonBadCommitment, []int{j}, fmt.Errorf("invalid commitment D, party: %d", j)))
+			return
+		}
+		Ej, err := r1msg.UnmarshalE(ec)
+		if err != nil || !validElement(Ej) {
+			result.abort(party.blame(tss.ReasonBadCommitment, []int{j}, fmt.Errorf("invalid commitment E, party: %d", j)))
+			return
+		}
+		party.temp.D[j], party.temp.E[j] = Dj, Ej
+	}
+
+	// ρj = H1(PK || H4(msg) || H5(commitment list) || idj), the list is ordered by identifier
+	var encodedCommitments []byte
+	for j, kj := range party.keys.Ks {
+		encodedCommitments = append(encodedCommitments, serializeScalar(kj)...)
+		encodedCommitments = append(encodedCommitments, serializeElement(party.temp.D[j])...)
+		encodedCommitments = append(encodedCommitments, serializeElement(party.temp.E[j])...)
+	}
+	encodedPub := serializeElement(party.temp.pub)
+	msgHash, commitmentsHash := h4(party.temp.mBytes), h5(encodedCommitments)
+	for j, kj := range party.keys.Ks {
+		party.temp.rho[j] = h1(encodedPub, msgHash, commitmentsHash, serializeScalar(kj))
+	}
+
+	// R = Σ(Dj + ρj·Ej)
+	var R *crypto.ECPoint
+	for j := range party.keys.Ks {
+		Rj, err := party.temp.D[j].Add(party.temp.E[j].ScalarMult(party.temp.rho[j]))
+		if err == nil && R != nil {
+			Rj, err = R.Add(Rj)
+		}
+		if err != nil {
+			common.Logger.Errorf("calc R failed, party: %d", j)
+			result.Err = fmt.Sprintf("calc R failed, party: %d", j)
+			return
+		}
+		R = Rj
+	}
+	party.temp.R = R
+
+	// c = H2(R || PK || msg), zi = di + ei·ρi + c·wi; the Lagrange coefficient is already in wi
+	party.temp.c = h2(serializeElement(R), encodedPub, party.temp.mBytes)
+	zi := modQ.Add(modQ.Add(party.temp.d, modQ.Mul(party.temp.e, party.temp.rho[i])),
+		modQ.Mul(party.temp.c, party.keys.PrivXi))
+	party.temp.d, party.temp.e = nil, nil
+	party.temp.zs = nil
+
+	// broadcast zi
+	r2msg := m.NewSignRound2Message(party.PartyID(), zi)
+	msgWireBytes, _, err := r2msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound2Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound2MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound2Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound2Message"
+		return
+	}
+	party.temp.signRound2Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound2Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.temp.R == nil {
+		result.Err = "round_2 not executed"
+		return
+	}
+
+	for j, msg := range party.temp.signRound2Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+
+	// check zj·G = Dj + ρj·Ej + c·Wj for every signature share, so a bad one can be blamed
+	ec := party.params.EC()
+	zs := make([]*big.Int, len(party.keys.Ks))
+	var culprits []int
+	for j, msg := range party.temp.signRound2Messages {
+		pMsg, err := tss.ParseWireMsg(msg)
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		zj := pMsg.Content().(*m.SignRound2Message).UnmarshalZ()
+		expected, err := party.temp.D[j].Add(party.temp.E[j].ScalarMult(party.temp.rho[j]))
+		if err == nil {
+			expected, err = expected.Add(party.keys.PubXj[j].ScalarMult(party.temp.c))
+		}
+		if err != nil || zj.Cmp(ec.Params().N) >= 0 || !crypto.ScalarBaseMult(ec, zj).Equals(expected) {
+			common.Logger.Errorf("verify signature share failed, party: %d", j)
+			culprits = append(culprits, j)
+			continue
+		}
+		zs[j] = zj
+	}
+	if len(culprits) > 0 {
+		result.abort(party.blame(tss.ReasonBadShare, culprits, fmt.Errorf("verify signature share failed, parties: %v", culprits)))
+		return
+	}
+	party.temp.zs = zs
+
+	result.Ok = true
+	return
+}
@@ -0,0 +1,70 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+func SignFinalExec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.temp.zs == nil {
+		result.Err = "round_2 not finished"
+		return
+	}
+
+	party.number = 3
+	i := party.PartyID().Index
+	common.Logger.Infof("[frost sign] party: %d, round_final start", i)
+
+	// s = Σzj
+	modQ := common.ModInt(party.params.EC().Params().N)
+	s := big.NewInt(0)
+	for _, zj := range party.temp.zs {
+		s = modQ.Add(s, zj)
+	}
+
+	// save the signature for final output, as onsign does: R || s, both little endian
+	encodedR := serializeElement(party.temp.R)
+	party.data.Signature = append(encodedR, serializeScalar(s)...)
+	r := new(big.Int).SetBytes(reverseBytes(append([]byte{}, encodedR...)))
+	party.data.R = r.Bytes()
+	party.data.S = s.Bytes()
+	party.data.M = party.temp.mBytes
+
+	pk := edwards.PublicKey{
+		Curve: party.params.EC(),
+		X:     party.temp.pub.X(),
+		Y:     party.temp.pub.Y(),
+	}
+	if !edwards.Verify(&pk, party.data.M, r, s) {
+		common.Logger.Errorf("verify failed")
+		result.Err = "verify failed"
+		return
+	}
+
+	saveBytes, err := json.Marshal(party.data)
+	if err != nil {
+		common.Logger.Errorf("round_final save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_final save err: %s", err.Error())
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
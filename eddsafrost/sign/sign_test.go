@@ -0,0 +1,183 @@
+package sign_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsafrost/sign"
+	m "tss_sdk/eddsafrost/sign/message"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+const walletPath = "81/0/0/35/0"
+
+func TestSign(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	pub := test.ChildPub(t, saves[0], walletPath)
+	msg, _ := hex.DecodeString("00deadbeef")
+	sig, _ := runSign(t, saves, []int{0, 2}, 2, msg, -1)
+	test.CheckSig(t, pub, sig)
+	data := &common.SignatureData{}
+	require.NoError(t, json.Unmarshal(sig, data))
+	require.Equal(t, msg, data.M)
+
+	sig, _ = runSign(t, saves, []int{0, 1, 2}, 2, msg, -1)
+	test.CheckSig(t, pub, sig)
+
+	// FROST does not need the Paillier keys of the CMP aux info
+	noAux := make([][]byte, len(saves))
+	for i := range saves {
+		data := test.SaveData(t, saves[i])
+		data.LocalRefreshSaveData = keygen.LocalRefreshSaveData{}
+		bz, err := json.Marshal(data)
+		require.NoError(t, err)
+		noAux[i] = bz
+	}
+	sig, _ = runSign(t, noAux, []int{1, 2}, 2, msg, -1)
+	test.CheckSig(t, pub, sig)
+
+	additive := test.KeygenFixtures(t, 2, 2)
+	sig, _ = runSign(t, additive, []int{0, 1}, 2, msg, -1)
+	test.CheckSig(t, test.ChildPub(t, additive[0], walletPath), sig)
+}
+
+func TestSignSingleChainCode(t *testing.T) {
+	msg := []byte("hi")
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1]))
+		sig, _ := runSign(t, saves, []int{0, 1}, c[1], msg, -1)
+		test.CheckSig(t, test.ChildPub(t, saves[0], walletPath), sig)
+	}
+}
+
+func TestSignBip32Ed25519(t *testing.T) {
+	msg := []byte("hi")
+	for _, c := range [][2]int{{3, 2}, {2, 2}} {
+		saves := test.Bip32Ed25519(t, test.SingleChainCode(t, test.KeygenFixtures(t, c[0], c[1])))
+		sig, _ := runSign(t, saves, []int{0, 1}, c[1], msg, -1)
+		test.CheckSig(t, test.ChildPub(t, saves[0], walletPath), sig)
+	}
+}
+
+func TestSignCulprit(t *testing.T) {
+	saves := test.KeygenFixtures(t, 3, 2)
+	msg, _ := hex.DecodeString("00deadbeef")
+	_, culprits := runSign(t, saves, []int{0, 1, 2}, 2, msg, 1)
+	require.Equal(t, []string{"1", "1"}, culprits)
+}
+
+func TestSignBadCommitment(t *testing.T) {
+	saves := test.KeygenFixtures(t, 2, 2)
+	pIDs := test.PartyIDs(2)
+	manifest := test.Manifest(t, sign.TaskName, pIDs, nil, 2)
+	keys := []string{"frost-bad-0", "frost-bad-1"}
+	for i := range keys {
+		require.True(t, sign.NewLocalParty(keys[i], i, 2, 2, pIDs, "00", test.B64(saves[i]), walletPath).Ok)
+		defer sign.RemoveSignParty(keys[i])
+		require.True(t, sign.SetSessionManifest(keys[i], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	require.True(t, sign.SignRound1Exec(keys[0]).Ok)
+	r := sign.SignRound1Exec(keys[1])
+	require.True(t, r.Ok, r.Err)
+
+	// P1 commits to D = (0, -1), a point of order 2
+	pMsg, err := tss.ParseWireMsg(r.MsgWireBytes)
+	require.NoError(t, err)
+	content := pMsg.Content().(*m.SignRound1Message)
+	ec := tss.Edwards()
+	E, err := content.UnmarshalE(ec)
+	require.NoError(t, err)
+	D := crypto.NewECPointNoCurveCheck(ec, big.NewInt(0), new(big.Int).Sub(ec.Params().P, big.NewInt(1)))
+	bad, _, err := m.NewSignRound1Message(tss.NewPartyID("1", "m_1", big.NewInt(1007)), content.GetSsid(), D, E).WireBytes()
+	require.NoError(t, err)
+	require.True(t, sign.SignRound1MsgAccept(keys[0], 1, test.B64(bad)).Ok)
+	require.True(t, sign.SignRound1Finish(keys[0]).Ok)
+
+	e := sign.SignRound2Exec(keys[0])
+	require.False(t, e.Ok)
+	require.Equal(t, "1", e.Culprits)
+	require.Equal(t, tss.ReasonBadCommitment, e.Reason)
+}
+
+// runSign returns the signature json, or the culprits every other signer reports when the signature share of
+// tamper is replaced by that of the next signer
+func runSign(t *testing.T, saves [][]byte, signers []int, threshold int, msg []byte, tamper int) ([]byte, []string) {
+	m := len(signers)
+	all := test.PartyIDs(len(saves))
+	pIDs := make([]string, m)
+	for k, s := range signers {
+		pIDs[k] = all[s]
+	}
+	manifest := test.Manifest(t, sign.TaskName, pIDs, nil, threshold)
+	keys := make([]string, m)
+	for k, s := range signers {
+		keys[k] = fmt.Sprintf("frost-%d", k)
+		r := sign.NewLocalParty(keys[k], k, m, threshold, pIDs, hex.EncodeToString(msg), test.B64(saves[s]), walletPath)
+		require.True(t, r.Ok, r.Err)
+		defer sign.RemoveSignParty(keys[k])
+		require.True(t, sign.SetSessionManifest(keys[k], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	for i := range keys {
+		r := sign.SignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				a := sign.SignRound1MsgAccept(keys[j], i, test.B64(r.MsgWireBytes))
+				require.True(t, a.Ok, a.Err)
+			}
+		}
+	}
+	for i := range keys {
+		require.True(t, sign.SignRound1Finish(keys[i]).Ok)
+	}
+	out := make([][]byte, m)
+	for i := range keys {
+		r := sign.SignRound2Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	// the nonces are used once
+	require.False(t, sign.SignRound2Exec(keys[0]).Ok)
+	if tamper >= 0 {
+		out[tamper] = out[(tamper+1)%m]
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				a := sign.SignRound2MsgAccept(keys[j], i, test.B64(out[i]))
+				require.True(t, a.Ok, a.Err)
+			}
+		}
+	}
+	if tamper >= 0 {
+		var culprits []string
+		for i := range keys {
+			if r := sign.SignRound2Finish(keys[i]); i != tamper {
+				require.False(t, r.Ok)
+				require.Equal(t, tss.ReasonBadShare, r.Reason)
+				culprits = append(culprits, r.Culprits)
+			}
+		}
+		return nil, culprits
+	}
+	for i := range keys {
+		r := sign.SignRound2Finish(keys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+	var sig []byte
+	for i := range keys {
+		r := sign.SignFinalExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		sig = r.MsgWireBytes
+	}
+	return sig, nil
+}
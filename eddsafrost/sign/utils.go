@@ -0,0 +1,84 @@
+package sign
+
+import (
+	"crypto/sha512"
+	"io"
+	"math/big"
+
+	"tss_sdk/crypto"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// contextString of the FROST(Ed25519, SHA-512) ciphersuite, RFC 9591 section 6.1
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// h1 derives the binding factors
+func h1(m ...[]byte) *big.Int {
+	return hashToScalar(append([][]byte{[]byte(contextString + "rho")}, m...)...)
+}
+
+// h2 is the Ed25519 challenge hash, without domain separation so the signature verifies as Ed25519
+func h2(m ...[]byte) *big.Int {
+	return hashToScalar(m...)
+}
+
+// h3 derives the nonces
+func h3(m ...[]byte) *big.Int {
+	return hashToScalar(append([][]byte{[]byte(contextString + "nonce")}, m...)...)
+}
+
+// h4 hashes the message
+func h4(m []byte) []byte {
+	h := sha512.Sum512(append([]byte(contextString+"msg"), m...))
+	return h[:]
+}
+
+// h5 hashes the encoded commitment list
+func h5(m []byte) []byte {
+	h := sha512.Sum512(append([]byte(contextString+"com"), m...))
+	return h[:]
+}
+
+// hashToScalar reads the SHA-512 digest of m as a little endian integer mod L
+func hashToScalar(m ...[]byte) *big.Int {
+	h := sha512.New()
+	for _, b := range m {
+		h.Write(b)
+	}
+	digest := reverseBytes(h.Sum(nil))
+	return new(big.Int).Mod(new(big.Int).SetBytes(digest), edwards.Edwards().N)
+}
+
+// nonceGenerate mixes fresh randomness with the secret share, so a weak RNG alone does not leak it
+func nonceGenerate(rand io.Reader, secret *big.Int) (*big.Int, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := io.ReadFull(rand, randomBytes); err != nil {
+		return nil, err
+	}
+	return h3(randomBytes, serializeScalar(secret)), nil
+}
+
+// serializeScalar is the 32-byte little endian encoding of s
+func serializeScalar(s *big.Int) []byte {
+	b := make([]byte, 32)
+	return reverseBytes(new(big.Int).Mod(s, edwards.Edwards().N).FillBytes(b))
+}
+
+// serializeElement is the 32-byte Ed25519 encoding of p
+func serializeElement(p *crypto.ECPoint) []byte {
+	return edwards.PublicKey{Curve: p.Curve(), X: p.X(), Y: p.Y()}.Serialize()
+}
+
+// validElement rejects the identity and points with a small order component, RFC 9591 section 6.1
+func validElement(p *crypto.ECPoint) bool {
+	return p.X().Sign() != 0 && p.Equals(p.EightInvEight())
+}
+
+// reverseBytes reverses b in place and returns it, to switch between big and little endian
+func reverseBytes(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
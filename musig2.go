@@ -0,0 +1,96 @@
+package tss_sdk
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"strings"
+	musig2sign "tss_sdk/musig2/sign"
+)
+
+// ---------------------musig2 sign------------------------
+
+// n-of-n MuSig2: every signer holds its own secp256k1 key, no keygen is needed
+func NewMusig2SignLocalParty(
+	key string,
+	partyIndex int,
+	privKey string, // our secp256k1 private key, hex string
+	pubKeys string, // x-only public keys of all signers, hex strings, comma separated; the same order for every signer
+	msg string, // 32-byte message, hex string
+	taproot bool, // sign for the P2TR output key of the aggregated key
+	merkleRoot string, // taproot script tree root, hex string; "" for a key-path only output
+) *MpcResult {
+	keys := strings.Split(pubKeys, ",")
+	res := musig2sign.NewLocalParty(key, partyIndex, privKey, keys, msg, taproot, merkleRoot)
+	return resFromMusig2Sign(res)
+}
+
+// the output is the 32-byte x-only aggregated key the signature verifies against
+func GetMusig2CombinedKey(key string) *MpcExecResult {
+	res := musig2sign.GetCombinedKey(key)
+	return execResFromMusig2Sign(res)
+}
+
+func RemoveMusig2SignParty(key string) bool {
+	return musig2sign.RemoveSignParty(key)
+}
+
+// broadcast the public nonce
+func Musig2SignRound1Exec(key string) *MpcExecResult {
+	res := musig2sign.SignRound1Exec(key)
+	return execResFromMusig2Sign(res)
+}
+
+func Musig2SignRound1MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := musig2sign.SignRound1MsgAccept(key, from, msgWireBytes)
+	return resFromMusig2Sign(res)
+}
+
+func Musig2SignRound1Finish(key string) *MpcResult {
+	res := musig2sign.SignRound1Finish(key)
+	return resFromMusig2Sign(res)
+}
+
+// broadcast the partial signature
+func Musig2SignRound2Exec(key string) *MpcExecResult {
+	res := musig2sign.SignRound2Exec(key)
+	return execResFromMusig2Sign(res)
+}
+
+func Musig2SignRound2MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := musig2sign.SignRound2MsgAccept(key, from, msgWireBytes)
+	return resFromMusig2Sign(res)
+}
+
+// verifies the partial signatures, the culprits are the signers of the invalid ones
+func Musig2SignRound2Finish(key string) *MpcResult {
+	res := musig2sign.SignRound2Finish(key)
+	return resFromMusig2Sign(res)
+}
+
+// the output is common.SignatureData, the 64-byte BIP-340 signature x(R) || s
+func Musig2SignFinalExec(key string) *MpcExecResult {
+	res := musig2sign.SignFinalExec(key)
+	return execResFromMusig2Sign(res)
+}
+
+func execResFromMusig2Sign(res musig2sign.SignExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromMusig2Sign(res musig2sign.SignResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
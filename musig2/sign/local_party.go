@@ -0,0 +1,212 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+	"github.com/ipfs/go-log"
+)
+
+// n-of-n MuSig2 over the vendored btcec musig2 package: every signer holds its own secp256k1 key,
+// round 1 broadcasts the public nonces, round 2 broadcasts the partial signatures and the final round
+// checks each of them and combines them into a BIP-340 signature for the aggregated key.
+
+type (
+	LocalParty struct {
+		partyID *tss.PartyID
+		keys    []*btcec.PublicKey // the signers, in the order every party passed them
+		ctx     *musig2.Context
+		session *musig2.Session
+		temp    localTempData
+		data    *common.SignatureData
+		number  int
+	}
+
+	localMessageStore struct {
+		signRound1Messages,
+		signRound2Messages [][]byte // msg.WireBytes()
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		msg         [32]byte
+		signOpts    []musig2.SignOption // the tweak of the aggregated key, to check partial signatures
+		pubNonces   [][musig2.PubNonceSize]byte
+		partialSig  *musig2.PartialSignature
+		partialSigs []*musig2.PartialSignature // verified in round_2 finish
+	}
+)
+
+var SignParties = map[string]*LocalParty{}
+
+func NewLocalParty(
+	key string,
+	partyIndex int,
+	privKey string, // our secp256k1 private key, hex string
+	pubKeys []string, // x-only public keys of all signers, hex strings; the same list in the same order for every signer
+	msg string, // 32-byte message, hex string
+	taproot bool, // sign for the P2TR output key of the aggregated key
+	merkleRoot string, // taproot script tree root, hex string; "" for a key-path only output
+) (result SignResult) {
+	if err := log.SetLogLevel("tss-lib", "info"); err != nil {
+		common.Logger.Errorf("set log level, err: %s", err.Error())
+		result.Err = fmt.Sprintf("set log level, err: %s", err.Error())
+		return
+	}
+
+	partyCount := len(pubKeys)
+	if partyIndex < 0 || partyIndex >= partyCount {
+		common.Logger.Errorf("party index err: %d, party count: %d", partyIndex, partyCount)
+		result.Err = fmt.Sprintf("party index err: %d, party count: %d", partyIndex, partyCount)
+		return
+	}
+	keys := make([]*btcec.PublicKey, partyCount)
+	for j, pubKey := range pubKeys {
+		pubKeyBytes, err := hex.DecodeString(pubKey)
+		if err != nil {
+			common.Logger.Errorf("hex decode public key err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("hex decode public key err: %s, party: %d", err.Error(), j)
+			return
+		}
+		if keys[j], err = schnorr.ParsePubKey(pubKeyBytes); err != nil {
+			common.Logger.Errorf("parse public key err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("parse public key err: %s, party: %d", err.Error(), j)
+			return
+		}
+	}
+
+	privKeyBytes, err := hex.DecodeString(privKey)
+	if err != nil || len(privKeyBytes) != 32 {
+		common.Logger.Errorf("private key must be 32 bytes, hex string")
+		result.Err = "private key must be 32 bytes, hex string"
+		return
+	}
+	signingKey, pub := btcec.PrivKeyFromBytes(privKeyBytes)
+	if !keys[partyIndex].IsEqual(xOnlyKey(pub)) {
+		common.Logger.Errorf("private key does not match public key: %d", partyIndex)
+		result.Err = fmt.Sprintf("private key does not match public key: %d", partyIndex)
+		return
+	}
+
+	mBytes, err := hex.DecodeString(msg)
+	if err != nil || len(mBytes) != 32 {
+		common.Logger.Errorf("msg must be 32 bytes, hex string")
+		result.Err = "msg must be 32 bytes, hex string"
+		return
+	}
+
+	// the key order is kept as given, so the aggregated key does not depend on key sorting
+	ctxOpts := []musig2.ContextOption{musig2.WithKnownSigners(keys)}
+	var signOpts []musig2.SignOption
+	if taproot {
+		if merkleRoot == "" {
+			ctxOpts = append(ctxOpts, musig2.WithBip86TweakCtx())
+			signOpts = append(signOpts, musig2.WithBip86SignTweak())
+		} else {
+			rootBytes, err := hex.DecodeString(merkleRoot)
+			if err != nil || len(rootBytes) != 32 {
+				common.Logger.Errorf("merkle root must be 32 bytes, hex string")
+				result.Err = "merkle root must be 32 bytes, hex string"
+				return
+			}
+			ctxOpts = append(ctxOpts, musig2.WithTaprootTweakCtx(rootBytes))
+			signOpts = append(signOpts, musig2.WithTaprootSignTweak(rootBytes))
+		}
+	}
+	ctx, err := musig2.NewContext(signingKey, false, ctxOpts...)
+	if err != nil {
+		common.Logger.Errorf("new musig2 context err: %s", err.Error())
+		result.Err = fmt.Sprintf("new musig2 context err: %s", err.Error())
+		return
+	}
+
+	p := &LocalParty{
+		keys: keys,
+		ctx:  ctx,
+		data: &common.SignatureData{},
+	}
+	p.partyID = p.signerID(partyIndex)
+	// msgs init
+	p.temp.signRound1Messages = make([][]byte, partyCount)
+	p.temp.signRound2Messages = make([][]byte, partyCount)
+
+	// temp data init
+	copy(p.temp.msg[:], mBytes)
+	p.temp.signOpts = signOpts
+	p.temp.pubNonces = make([][musig2.PubNonceSize]byte, partyCount)
+
+	SignParties[key] = p
+	result.Ok = true
+	return
+}
+
+// xOnlyKey is the x-only lift of pub, the form in which musig2 keeps signer keys
+func xOnlyKey(pub *btcec.PublicKey) *btcec.PublicKey {
+	even, _ := schnorr.ParsePubKey(schnorr.SerializePubKey(pub))
+	return even
+}
+
+// GetCombinedKey outputs the x-only aggregated key, the P2TR output key when taproot is set
+func GetCombinedKey(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	combinedKey, err := party.ctx.CombinedKey()
+	if err != nil {
+		common.Logger.Errorf("combined key err: %s", err.Error())
+		result.Err = fmt.Sprintf("combined key err: %s", err.Error())
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = schnorr.SerializePubKey(combinedKey)
+	return
+}
+
+func RemoveSignParty(key string) bool {
+	if _, ok := SignParties[key]; !ok {
+		return false
+	}
+	delete(SignParties, key)
+	return true
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.partyID
+}
+
+// signerID is the party id of signer j, keyed by its x-only public key
+func (p *LocalParty) signerID(j int) *tss.PartyID {
+	id := tss.NewPartyID(fmt.Sprintf("%d", j), fmt.Sprintf("m_%d", j), new(big.Int).SetBytes(schnorr.SerializePubKey(p.keys[j])))
+	id.Index = j
+	return id
+}
+
+// blame returns an identifiable abort error naming the signers js as the culprits of the current round
+func (p *LocalParty) blame(reason string, js []int, err error) *tss.Error {
+	culprits := make([]*tss.PartyID, len(js))
+	for k, j := range js {
+		culprits[k] = p.signerID(j)
+	}
+	return tss.NewAbortError(err, reason, TaskName, p.number, p.PartyID(), culprits...)
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, round: %d", p.PartyID(), p.number)
+}
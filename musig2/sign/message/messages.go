@@ -0,0 +1,59 @@
+package message
+
+import (
+	"tss_sdk/common"
+	"tss_sdk/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into musig2-sign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	pubNonce []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		PubNonce: pubNonce,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPubNonce())
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	partialSig []byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		PartialSig: partialSig,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetPartialSig())
+}
@@ -0,0 +1,137 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"tss_sdk/common"
+	m "tss_sdk/musig2/sign/message"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+)
+
+func SignRound1Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 1
+	i := party.PartyID().Index
+	common.Logger.Infof("[musig2 sign] party: %d, round_1 start", i)
+
+	// a fresh session per signature, so the secret nonce is never reused
+	session, err := party.ctx.NewSession()
+	if err != nil {
+		common.Logger.Errorf("new musig2 session err: %s", err.Error())
+		result.Err = fmt.Sprintf("new musig2 session err: %s", err.Error())
+		return
+	}
+	party.session = session
+	pubNonce := session.PublicNonce()
+	party.temp.pubNonces[i] = pubNonce
+
+	// broadcast the public nonce
+	r1msg := m.NewSignRound1Message(party.PartyID(), pubNonce[:])
+	msgWireBytes, _, err := r1msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound1Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound1MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound1Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	content, ok := msg.Content().(*m.SignRound1Message)
+	if !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound1Message"
+		return
+	}
+	if len(content.GetPubNonce()) != musig2.PubNonceSize {
+		result.Err = fmt.Sprintf("invalid public nonce size: %d, party: %d", len(content.GetPubNonce()), from)
+		return
+	}
+	party.temp.signRound1Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound1Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.session == nil {
+		result.Err = "round_1 not executed"
+		return
+	}
+
+	for j, msg := range party.temp.signRound1Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+
+	// register the nonces of the other signers, the session aggregates them once all are known
+	i := party.PartyID().Index
+	for j, msg := range party.temp.signRound1Messages {
+		if j == i {
+			continue
+		}
+		pMsg, err := tss.ParseWireMsg(msg)
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		copy(party.temp.pubNonces[j][:], pMsg.Content().(*m.SignRound1Message).GetPubNonce())
+		if _, err := party.session.RegisterPubNonce(party.temp.pubNonces[j]); err != nil {
+			common.Logger.Errorf("register public nonce err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("register public nonce err: %s, party: %d", err.Error(), j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,159 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"tss_sdk/common"
+	m "tss_sdk/musig2/sign/message"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr/musig2"
+)
+
+func SignRound2Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.session == nil {
+		result.Err = "round_1 not executed"
+		return
+	}
+
+	party.number = 2
+	i := party.PartyID().Index
+	common.Logger.Infof("[musig2 sign] party: %d, round_2 start", i)
+
+	// the session blanks its secret nonce after signing, a second call fails instead of reusing it
+	partialSig, err := party.session.Sign(party.temp.msg)
+	if err != nil {
+		common.Logger.Errorf("musig2 sign err: %s", err.Error())
+		result.Err = fmt.Sprintf("musig2 sign err: %s", err.Error())
+		return
+	}
+	party.temp.partialSig = partialSig
+
+	var sBytes bytes.Buffer
+	if err := partialSig.Encode(&sBytes); err != nil {
+		common.Logger.Errorf("encode partial signature err: %s", err.Error())
+		result.Err = fmt.Sprintf("encode partial signature err: %s", err.Error())
+		return
+	}
+
+	// broadcast the partial signature
+	r2msg := m.NewSignRound2Message(party.PartyID(), sBytes.Bytes())
+	msgWireBytes, _, err := r2msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound2Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound2MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	content, ok := msg.Content().(*m.SignRound2Message)
+	if !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound2Message"
+		return
+	}
+	if len(content.GetPartialSig()) != 32 {
+		result.Err = fmt.Sprintf("invalid partial signature size: %d, party: %d", len(content.GetPartialSig()), from)
+		return
+	}
+	party.temp.signRound2Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound2Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound2Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+
+	// check every partial signature against its signer's nonce and key, so a bad one can be blamed
+	combinedNonce, err := musig2.AggregateNonces(party.temp.pubNonces)
+	if err != nil {
+		common.Logger.Errorf("aggregate nonces err: %s", err.Error())
+		result.Err = fmt.Sprintf("aggregate nonces err: %s", err.Error())
+		return
+	}
+	i := party.PartyID().Index
+	party.temp.partialSigs = make([]*musig2.PartialSignature, len(party.keys))
+	party.temp.partialSigs[i] = party.temp.partialSig
+	var culprits []int
+	for j, msg := range party.temp.signRound2Messages {
+		if j == i {
+			continue
+		}
+		pMsg, err := tss.ParseWireMsg(msg)
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		partialSig := new(musig2.PartialSignature)
+		if err := partialSig.Decode(bytes.NewReader(pMsg.Content().(*m.SignRound2Message).GetPartialSig())); err != nil ||
+			!partialSig.Verify(party.temp.pubNonces[j], combinedNonce, party.keys, party.keys[j], party.temp.msg,
+				party.temp.signOpts...) {
+			common.Logger.Errorf("verify partial signature failed, party: %d", j)
+			culprits = append(culprits, j)
+			continue
+		}
+		party.temp.partialSigs[j] = partialSig
+	}
+	if len(culprits) > 0 {
+		result.abort(party.blame(tss.ReasonBadShare, culprits, fmt.Errorf("verify partial signature failed, parties: %v", culprits)))
+		return
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,65 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tss_sdk/common"
+)
+
+func SignFinalExec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if party.temp.partialSigs == nil {
+		result.Err = "round_2 not finished"
+		return
+	}
+
+	party.number = 3
+	i := party.PartyID().Index
+	common.Logger.Infof("[musig2 sign] party: %d, round_final start", i)
+
+	// our own partial signature is already in the session, the combined signature is verified on the last one
+	for j, partialSig := range party.temp.partialSigs {
+		if j == i {
+			continue
+		}
+		if _, err := party.session.CombineSig(partialSig); err != nil {
+			common.Logger.Errorf("combine signature err: %s, party: %d", err.Error(), j)
+			result.Err = fmt.Sprintf("combine signature err: %s, party: %d", err.Error(), j)
+			return
+		}
+	}
+	sig := party.session.FinalSig()
+	if sig == nil {
+		common.Logger.Errorf("final signature not available")
+		result.Err = "final signature not available"
+		return
+	}
+
+	// save the signature for final output: x(R) || s
+	party.data.Signature = sig.Serialize()
+	party.data.R = party.data.Signature[:32]
+	party.data.S = party.data.Signature[32:]
+	party.data.M = party.temp.msg[:]
+
+	saveBytes, err := json.Marshal(party.data)
+	if err != nil {
+		common.Logger.Errorf("round_final save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_final save err: %s", err.Error())
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
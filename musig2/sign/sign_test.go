@@ -0,0 +1,106 @@
+package sign_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/musig2/sign"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	runSign(t, 3, false, "", -1)
+	runSign(t, 3, true, "", -1)
+	runSign(t, 2, true, hex.EncodeToString(make([]byte, 32)), -1)
+}
+
+func TestSignCulprit(t *testing.T) {
+	runSign(t, 3, false, "", 1)
+}
+
+// runSign runs a session of n signers, the partial signature of tamper is replaced by that of the next signer
+func runSign(t *testing.T, n int, taproot bool, tapscriptRoot string, tamper int) {
+	privs := make([]string, n)
+	pubs := make([]string, n)
+	for i := range privs {
+		k, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		privs[i] = hex.EncodeToString(k.Serialize())
+		pubs[i] = hex.EncodeToString(schnorr.SerializePubKey(k.PubKey()))
+	}
+	msg := make([]byte, 32)
+	_, err := rand.Read(msg)
+	require.NoError(t, err)
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("musig2-%d", i)
+		r := sign.NewLocalParty(keys[i], i, privs[i], pubs, hex.EncodeToString(msg), taproot, tapscriptRoot)
+		require.True(t, r.Ok, r.Err)
+		defer sign.RemoveSignParty(keys[i])
+	}
+	for i := range keys {
+		r := sign.SignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				a := sign.SignRound1MsgAccept(keys[j], i, test.B64(r.MsgWireBytes))
+				require.True(t, a.Ok, a.Err)
+			}
+		}
+	}
+	for i := range keys {
+		r := sign.SignRound1Finish(keys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+	out := make([][]byte, n)
+	for i := range keys {
+		r := sign.SignRound2Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	if tamper >= 0 {
+		out[tamper] = out[(tamper+1)%n]
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				a := sign.SignRound2MsgAccept(keys[j], i, test.B64(out[i]))
+				require.True(t, a.Ok, a.Err)
+			}
+		}
+	}
+	for i := range keys {
+		r := sign.SignRound2Finish(keys[i])
+		if tamper < 0 {
+			require.True(t, r.Ok, r.Err)
+		} else if i != tamper {
+			require.False(t, r.Ok)
+			require.Equal(t, fmt.Sprint(tamper), r.Culprits)
+			require.Equal(t, tss.ReasonBadShare, r.Reason)
+		}
+	}
+	if tamper >= 0 {
+		return
+	}
+
+	pub, err := schnorr.ParsePubKey(sign.GetCombinedKey(keys[0]).MsgWireBytes)
+	require.NoError(t, err)
+	for i := range keys {
+		r := sign.SignFinalExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		data := &common.SignatureData{}
+		require.NoError(t, json.Unmarshal(r.MsgWireBytes, data))
+		sig, err := schnorr.ParseSignature(data.Signature)
+		require.NoError(t, err)
+		require.True(t, sig.Verify(msg, pub))
+	}
+}
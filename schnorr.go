@@ -0,0 +1,139 @@
+package tss_sdk
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"strings"
+	schnorrsign "tss_sdk/schnorrcmp/sign"
+)
+
+// ---------------------schnorr sign------------------------
+
+// BIP-340 signing with a key of NewEcdsaKeygenLocalParty
+func NewSchnorrSignLocalParty(
+	key string,
+	partyIndex int,
+	partyCount int,
+	threshold int, // the keygen threshold
+	pIDs string,
+	msg string, // 32-byte message, hex string
+	keyData string, // keygen.LocalPartySaveData of a secp256k1 key, base64 string
+	walletPath string, // non-hardened BIP 32 path, "" signs with the root key
+	taproot bool, // sign for the P2TR output key of the (child) key
+	merkleRoot string, // taproot script tree root, hex string; "" for a key-path only output
+) *MpcResult {
+	ids := strings.Split(pIDs, ",")
+	res := schnorrsign.NewLocalParty(key, partyIndex, partyCount, threshold, ids, msg, keyData, walletPath, taproot, merkleRoot)
+	return resFromSchnorrSign(res)
+}
+
+// manifest: tss.SessionManifest, base64 string; the protocol is "schnorr-cmp-sign",
+// the parties are the signers and the threshold is the keygen threshold
+func SetSchnorrSignSessionManifest(key string, manifest string, signature string, coordinatorPub string) *MpcResult {
+	res := schnorrsign.SetSessionManifest(key, manifest, signature, coordinatorPub)
+	return resFromSchnorrSign(res)
+}
+
+func RemoveSchnorrSignParty(key string) bool {
+	return schnorrsign.RemoveSignParty(key)
+}
+
+func SchnorrSignRound1Exec(key string) *MpcExecResult {
+	res := schnorrsign.SignRound1Exec(key)
+	return execResFromSchnorrSign(res)
+}
+
+func GetSchnorrSignRound1Msg(key string, to int) *MpcExecResult {
+	res := schnorrsign.GetRound1Msg2(key, to)
+	return execResFromSchnorrSign(res)
+}
+
+func SchnorrSignRound1MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := schnorrsign.SignRound1MsgAccept(key, from, msgWireBytes)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignRound1Finish(key string) *MpcResult {
+	res := schnorrsign.SignRound1Finish(key)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignRound2Exec(key string) *MpcResult {
+	res := schnorrsign.SignRound2Exec(key)
+	return resFromSchnorrSign(res)
+}
+
+func GetSchnorrSignRound2Msg(key string, to int) *MpcExecResult {
+	res := schnorrsign.GetRound2Msg(key, to)
+	return execResFromSchnorrSign(res)
+}
+
+func SchnorrSignRound2MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := schnorrsign.SignRound2MsgAccept(key, from, msgWireBytes)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignRound2Finish(key string) *MpcResult {
+	res := schnorrsign.SignRound2Finish(key)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignRound3Exec(key string) *MpcExecResult {
+	res := schnorrsign.SignRound3Exec(key)
+	return execResFromSchnorrSign(res)
+}
+
+func SchnorrSignRound3MsgAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := schnorrsign.SignRound3MsgAccept(key, from, msgWireBytes)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignRound3Finish(key string) *MpcResult {
+	res := schnorrsign.SignRound3Finish(key)
+	return resFromSchnorrSign(res)
+}
+
+// the output is common.SignatureData, the 64-byte BIP-340 signature x(R) || s
+func SchnorrSignFinalExec(key string) *MpcExecResult {
+	res := schnorrsign.SignFinalExec(key)
+	return execResFromSchnorrSign(res)
+}
+
+// optional echo of broadcast round 1 or 3, run between SchnorrSignRoundNFinish and the next exec
+// when the transport is not a reliable broadcast; every signer must run it for the same rounds
+func SchnorrSignEchoExec(key string, round int) *MpcExecResult {
+	res := schnorrsign.SignEchoExec(key, round)
+	return execResFromSchnorrSign(res)
+}
+
+func SchnorrSignEchoAccept(key string, from int, msgWireBytes string) *MpcResult {
+	res := schnorrsign.SignEchoAccept(key, from, msgWireBytes)
+	return resFromSchnorrSign(res)
+}
+
+func SchnorrSignEchoFinish(key string, round int) *MpcResult {
+	res := schnorrsign.SignEchoFinish(key, round)
+	return resFromSchnorrSign(res)
+}
+
+func execResFromSchnorrSign(res schnorrsign.SignExecResult) *MpcExecResult {
+	return &MpcExecResult{
+		Ok:           res.Ok,
+		Err:          res.Err,
+		MsgWireBytes: res.MsgWireBytes,
+		Culprits:     res.Culprits,
+		Reason:       res.Reason,
+	}
+}
+
+func resFromSchnorrSign(res schnorrsign.SignResult) *MpcResult {
+	return &MpcResult{
+		Ok:       res.Ok,
+		Err:      res.Err,
+		Culprits: res.Culprits,
+		Reason:   res.Reason,
+	}
+}
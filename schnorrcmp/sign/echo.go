@@ -0,0 +1,50 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"fmt"
+
+	"tss_sdk/tss"
+)
+
+// The echo of a broadcast round is optional, it is run between SignRoundNFinish and
+// SignRoundN+1Exec (SignFinalExec after round 3) when the transport does not guarantee
+// every party gets the same broadcast.
+
+// broadcastMessages returns the broadcast messages of sign round 1 or 3, by sender
+func (p *LocalParty) broadcastMessages(round int) ([][]byte, error) {
+	switch round {
+	case 1:
+		return p.temp.signRound1Message1s, nil
+	case 3:
+		return p.temp.signRound3Messages, nil
+	}
+	return nil, fmt.Errorf("not a broadcast round: %d", round)
+}
+
+// echoOf looks up the echo state and the ssid of the party of key
+func echoOf(key string) (*tss.Echo, []byte, bool) {
+	party, ok := SignParties[key]
+	if !ok {
+		return nil, nil, false
+	}
+	return party.temp.echo, party.temp.ssid, true
+}
+
+// SignEchoExec broadcasts the hash of every message received in the broadcast round
+func SignEchoExec(key string, round int) SignExecResult {
+	return SignExecResult(tss.EchoExec(echoOf, key, round))
+}
+
+func SignEchoAccept(key string, from int, msgWireBytes string) SignResult {
+	return SignResult(tss.EchoAccept(echoOf, key, from, msgWireBytes))
+}
+
+// SignEchoFinish checks every party echoed the same broadcast messages as we received
+func SignEchoFinish(key string, round int) SignResult {
+	return SignResult(tss.EchoFinish(echoOf, key, round))
+}
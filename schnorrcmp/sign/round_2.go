@@ -0,0 +1,188 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/logproof"
+	m "tss_sdk/schnorrcmp/sign/message"
+	"tss_sdk/tss"
+)
+
+func SignRound2Exec(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 2
+	i := party.PartyID().Index
+	common.Logger.Infof("[schnorr sign] party: %d, round_2 start", i)
+
+	// Verify received enc proof
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound1Message1s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg1 fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg1 fail, err:%s", err.Error())
+			return
+		}
+		r1msg1 := pMsg.Content().(*m.SignRound1Message1)
+		if !bytes.Equal(r1msg1.GetSsid(), party.temp.ssid) {
+			common.Logger.Errorf("payload.ssid != round.temp.ssid, party: %d", j)
+			result.Err = fmt.Sprintf("payload.ssid != round.temp.ssid, party: %d", j)
+			return
+		}
+		party.temp.kCiphertexts[j] = r1msg1.UnmarshalK()
+
+		pMsg, err = tss.ParseWireMsg(party.temp.signRound1Message2s[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg2 fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg2 fail, err:%s", err.Error())
+			return
+		}
+		encProof, err := pMsg.Content().(*m.SignRound1Message2).UnmarshalEncProof()
+		if err != nil {
+			common.Logger.Errorf("unmarshal enc proof failed, party: %d", j)
+			result.Err = fmt.Sprintf("unmarshal enc proof failed, party: %d", j)
+			return
+		}
+		if err := encProof.Verify(ProofParameter, party.proofContext(j), party.temp.kCiphertexts[j],
+			party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i],
+		); err != nil {
+			common.Logger.Errorf("verify enc proof failed, party: %d", j)
+			result.Err = fmt.Sprintf("verify enc proof failed, party: %d", j)
+			return
+		}
+	}
+
+	// Ri = ki * G
+	ec := party.params.EC()
+	Ri := crypto.ScalarBaseMult(ec, party.temp.k)
+	G, err := crypto.NewECPoint(ec, ec.Params().Gx, ec.Params().Gy)
+	if err != nil {
+		common.Logger.Errorf("create base point failed")
+		result.Err = "create base point failed"
+		return
+	}
+
+	// p2p send log proof to Pj
+	contextI := party.proofContext(i)
+	for j, Pj := range party.params.Parties().IDs() {
+		// M(prove, Πlog, (sid,i), (Iε,Ki,Ri,g); (ki,rhoi))
+		logProof, err := logproof.NewKnowExponentAndPaillierEncryption(ProofParameter, contextI, party.temp.k,
+			party.temp.rho, party.temp.kCiphertexts[i], party.keys.PaillierPKs[i].N, party.keys.RingPedersenPKs[j], Ri, G)
+		if err != nil {
+			common.Logger.Errorf("create log proof failed, party: %d", j)
+			result.Err = fmt.Sprintf("create log proof failed, party: %d", j)
+			return
+		}
+		logProofBytes, err := proto.Marshal(logProof)
+		if err != nil {
+			common.Logger.Errorf("marshal log proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("marshal log proof failed: %s, party: %d", err, j)
+			return
+		}
+
+		r2msg := m.NewSignRound2Message(Pj, party.PartyID(), Ri, logProofBytes)
+		msgWireBytes, _, err := r2msg.WireBytes()
+		if err != nil {
+			common.Logger.Errorf("get msg wire bytes error: %s", key)
+			result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+			return
+		}
+		party.temp.send.signRound2Messages[j] = msgWireBytes
+		if j == i {
+			party.temp.signRound2Messages[i] = msgWireBytes
+		}
+	}
+
+	result.Ok = true
+	return result
+}
+
+// p2p Ri and log proof for party `to`
+func GetRound2Msg(key string, to int) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if to < 0 || to >= len(party.temp.send.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", to)
+		return
+	}
+	result.Ok = true
+	result.MsgWireBytes = party.temp.send.signRound2Messages[to]
+	return
+}
+
+func SignRound2MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound2Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound2Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound2Message"
+		return
+	}
+	party.temp.signRound2Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound2Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound2Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
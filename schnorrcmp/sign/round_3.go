@@ -0,0 +1,165 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	m "tss_sdk/schnorrcmp/sign/message"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+func SignRound3Exec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 3
+	i := party.PartyID().Index
+	common.Logger.Infof("[schnorr sign] party: %d, round_3 start", i)
+
+	ec := party.params.EC()
+	q := ec.Params().N
+	modQ := common.ModInt(q)
+	G, err := crypto.NewECPoint(ec, ec.Params().Gx, ec.Params().Gy)
+	if err != nil {
+		common.Logger.Errorf("create base point failed")
+		result.Err = "create base point failed"
+		return
+	}
+
+	// verify received log proof and compute R = ΣRj
+	R := crypto.ScalarBaseMult(ec, party.temp.k)
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound2Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		r2msg := pMsg.Content().(*m.SignRound2Message)
+
+		Rj, err := r2msg.UnmarshalR(ec)
+		if err != nil {
+			common.Logger.Errorf("unmarshal R failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("unmarshal R failed: %s, party: %d", err, j)
+			return
+		}
+		logProof, err := r2msg.UnmarshalLogProof(ec)
+		if err != nil {
+			common.Logger.Errorf("failed to unmarshal log proof: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("failed to unmarshal log proof: %s, party: %d", err, j)
+			return
+		}
+		if err := logProof.Verify(ProofParameter, party.proofContext(j), party.temp.kCiphertexts[j],
+			party.keys.PaillierPKs[j].N, party.keys.RingPedersenPKs[i], Rj, G,
+		); err != nil {
+			common.Logger.Errorf("verify log proof failed: %s, party: %d", err, j)
+			result.Err = fmt.Sprintf("verify log proof failed: %s, party: %d", err, j)
+			return
+		}
+
+		if R, err = R.Add(Rj); err != nil {
+			common.Logger.Errorf("calc R failed, party: %d", j)
+			result.Err = fmt.Sprintf("calc R failed, party: %d", j)
+			return
+		}
+	}
+
+	// BIP-340 nonces have an even Y: when R is odd every party negates its k
+	k := party.temp.k
+	if R.Y().Bit(0) == 1 {
+		k = modQ.Sub(big.NewInt(0), k)
+		R = R.ScalarMult(big.NewInt(-1))
+	}
+	party.temp.R = R
+
+	// e = hash_BIP0340/challenge(x(R) || x(P) || m), si = ki + e * wi
+	e := new(big.Int).SetBytes(chainhash.TaggedHash(chainhash.TagBIP0340Challenge,
+		xOnly(R), xOnly(party.temp.pub), party.temp.mBytes)[:])
+	e.Mod(e, q)
+	party.temp.si = modQ.Add(k, modQ.Mul(e, party.keys.PrivXi))
+
+	// broadcast si to other parties
+	r3msg := m.NewSignRound3Message(party.PartyID(), party.temp.si)
+	msgWireBytes, _, err := r3msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("get msg wire bytes error: %s", key)
+		result.Err = fmt.Sprintf("get msg wire bytes error: %s", key)
+		return
+	}
+	party.temp.signRound3Messages[i] = msgWireBytes
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func SignRound3MsgAccept(key string, from int, msgWireBytes string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+	if from < 0 || from >= len(party.temp.signRound3Messages) {
+		result.Err = fmt.Sprintf("party index err: %d", from)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+
+	msg, err := tss.ParseWireMsg(rMsgBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+		return
+	}
+	if content, ok := msg.Content().(*m.SignRound3Message); !ok || !content.ValidateBasic() {
+		result.Err = "not SignRound3Message"
+		return
+	}
+	party.temp.signRound3Messages[from] = rMsgBytes
+
+	result.Ok = true
+	return
+}
+
+func SignRound3Finish(key string) (result SignResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	for j, msg := range party.temp.signRound3Messages {
+		if len(msg) == 0 {
+			result.Err = fmt.Sprintf("msg is null: %d", j)
+			return
+		}
+	}
+	result.Ok = true
+	return
+}
@@ -0,0 +1,83 @@
+package sign
+
+//#include <stdio.h>
+//#include <stdlib.h>
+//#include <string.h>
+import "C"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"tss_sdk/common"
+	m "tss_sdk/schnorrcmp/sign/message"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+func SignFinalExec(key string) (result SignExecResult) {
+	party, ok := SignParties[key]
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	party.number = 4
+	i := party.PartyID().Index
+	common.Logger.Infof("[schnorr sign] party: %d, round_final start", i)
+
+	// s = Σsj
+	modQ := common.ModInt(party.params.EC().Params().N)
+	s := party.temp.si
+	for j := range party.params.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pMsg, err := tss.ParseWireMsg(party.temp.signRound3Messages[j])
+		if err != nil {
+			common.Logger.Errorf("msg error, parse wire msg fail, err:%s", err.Error())
+			result.Err = fmt.Sprintf("msg error, parse wire msg fail, err:%s", err.Error())
+			return
+		}
+		s = modQ.Add(s, pMsg.Content().(*m.SignRound3Message).UnmarshalS())
+	}
+
+	// save the signature for final output: x(R) || s
+	sBytes := make([]byte, 32)
+	s.FillBytes(sBytes)
+	party.data.Signature = append(xOnly(party.temp.R), sBytes...)
+	party.data.R = party.temp.R.X().Bytes()
+	party.data.S = s.Bytes()
+	party.data.M = party.temp.mBytes
+
+	sig, err := schnorr.ParseSignature(party.data.Signature)
+	if err != nil {
+		common.Logger.Errorf("parse signature err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse signature err: %s", err.Error())
+		return
+	}
+	pk, err := schnorr.ParsePubKey(xOnly(party.temp.pub))
+	if err != nil {
+		common.Logger.Errorf("parse public key err: %s", err.Error())
+		result.Err = fmt.Sprintf("parse public key err: %s", err.Error())
+		return
+	}
+	if !sig.Verify(party.temp.mBytes, pk) {
+		common.Logger.Errorf("verify failed")
+		result.Err = "verify failed"
+		return
+	}
+
+	saveBytes, err := json.Marshal(party.data)
+	if err != nil {
+		common.Logger.Errorf("round_final save err: %s", err.Error())
+		result.Err = fmt.Sprintf("round_final save err: %s", err.Error())
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = saveBytes
+	return result
+}
@@ -0,0 +1,147 @@
+package sign_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/schnorrcmp/sign"
+	"tss_sdk/test"
+	"tss_sdk/tss"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	saves := test.Secp256k1KeygenFixtures(t, 3, 2)
+	data := test.SaveData(t, saves[0])
+	pub := data.PubKey()
+	tweak, err := data.ChildTweak("0/1/5")
+	require.NoError(t, err)
+	child, err := pub.Add(crypto.ScalarBaseMult(tss.S256(), tweak))
+	require.NoError(t, err)
+	root := make([]byte, 32)
+	_, err = rand.Read(root)
+	require.NoError(t, err)
+	// the parity of the nonce and of the key both vary, sign a few messages
+	for n := 0; n < 3; n++ {
+		msg := make([]byte, 32)
+		_, err := rand.Read(msg)
+		require.NoError(t, err)
+		checkSignature(t, pub, msg, runSign(t, saves, []int{0, 2}, 2, msg, "", false, ""))
+		checkSignature(t, outputKey(pub, nil), msg, runSign(t, saves, []int{1, 2}, 2, msg, "", true, ""))
+		checkSignature(t, child, msg, runSign(t, saves, []int{0, 1}, 2, msg, "0/1/5", false, ""))
+		checkSignature(t, outputKey(child, root), msg, runSign(t, saves, []int{0, 1, 2}, 2, msg, "0/1/5", true, hex.EncodeToString(root)))
+	}
+}
+
+func runSign(t *testing.T, saves [][]byte, signers []int, threshold int, msg []byte, path string, taproot bool, tapscriptRoot string) *common.SignatureData {
+	m := len(signers)
+	all := test.PartyIDs(len(saves))
+	pIDs := make([]string, m)
+	for k, s := range signers {
+		pIDs[k] = all[s]
+	}
+	manifest := test.Manifest(t, sign.TaskName, pIDs, nil, threshold)
+	keys := make([]string, m)
+	for k, s := range signers {
+		keys[k] = fmt.Sprintf("schnorr-%d", k)
+		r := sign.NewLocalParty(keys[k], k, m, threshold, pIDs, hex.EncodeToString(msg), test.B64(saves[s]), path, taproot, tapscriptRoot)
+		require.True(t, r.Ok, r.Err)
+		defer sign.RemoveSignParty(keys[k])
+		require.True(t, sign.SetSessionManifest(keys[k], manifest, test.SignManifest(manifest), test.CoordinatorPub).Ok)
+	}
+	deliver := func(from, to int, bz []byte, accept func(string, int, string) sign.SignResult) {
+		r := accept(keys[to], from, test.B64(bz))
+		require.True(t, r.Ok, r.Err)
+	}
+	finish := func(finish func(string) sign.SignResult) {
+		for i := range keys {
+			r := finish(keys[i])
+			require.True(t, r.Ok, r.Err)
+		}
+	}
+
+	for i := range keys {
+		r := sign.SignRound1Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignRound1MsgAccept)
+				deliver(i, j, sign.GetRound1Msg2(keys[i], j).MsgWireBytes, sign.SignRound1MsgAccept)
+			}
+		}
+	}
+	finish(sign.SignRound1Finish)
+	finish(sign.SignRound2Exec)
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				deliver(i, j, sign.GetRound2Msg(keys[i], j).MsgWireBytes, sign.SignRound2MsgAccept)
+			}
+		}
+	}
+	finish(sign.SignRound2Finish)
+	for i := range keys {
+		r := sign.SignRound3Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignRound3MsgAccept)
+			}
+		}
+	}
+	finish(sign.SignRound3Finish)
+	for i := range keys {
+		r := sign.SignEchoExec(keys[i], 3)
+		require.True(t, r.Ok, r.Err)
+		for j := range keys {
+			if i != j {
+				deliver(i, j, r.MsgWireBytes, sign.SignEchoAccept)
+			}
+		}
+	}
+	finish(func(key string) sign.SignResult { return sign.SignEchoFinish(key, 3) })
+
+	var sig *common.SignatureData
+	for i := range keys {
+		r := sign.SignFinalExec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		data := &common.SignatureData{}
+		require.NoError(t, json.Unmarshal(r.MsgWireBytes, data))
+		if sig != nil {
+			require.Equal(t, sig.Signature, data.Signature)
+		}
+		sig = data
+	}
+	return sig
+}
+
+// outputKey is the BIP-341 taproot output key of the internal key p
+func outputKey(p *crypto.ECPoint, tapscriptRoot []byte) *crypto.ECPoint {
+	if p.Y().Bit(0) == 1 {
+		p = p.ScalarMult(big.NewInt(-1))
+	}
+	tweak := new(big.Int).SetBytes(chainhash.TaggedHash(chainhash.TagTapTweak, xOnly(p), tapscriptRoot)[:])
+	q, _ := p.Add(crypto.ScalarBaseMult(tss.S256(), tweak))
+	return q
+}
+
+func checkSignature(t *testing.T, pub *crypto.ECPoint, msg []byte, data *common.SignatureData) {
+	sig, err := schnorr.ParseSignature(data.Signature)
+	require.NoError(t, err)
+	pk, err := schnorr.ParsePubKey(xOnly(pub))
+	require.NoError(t, err)
+	require.True(t, sig.Verify(msg, pk))
+}
+
+func xOnly(p *crypto.ECPoint) []byte {
+	return p.X().FillBytes(make([]byte, 32))
+}
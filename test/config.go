@@ -0,0 +1,29 @@
+// Package test runs whole protocol sessions for the package tests: every party lives in this process and the
+// messages are delivered in memory, in the order a coordinator would relay them.
+package test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// PartyIDs are the keygen party ids of an n party committee
+func PartyIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%d", 1000+i*7)
+	}
+	return ids
+}
+
+// B64 encodes bz the way the coordinator relays messages to the Accept functions
+func B64(bz []byte) string {
+	return base64.StdEncoding.EncodeToString(bz)
+}
+
+func fixturesDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "_fixtures")
+}
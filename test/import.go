@@ -0,0 +1,63 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/resharing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ImporterID is the party id the importer takes in Import sessions
+const ImporterID = "9999"
+
+// Import runs an eddsacmp/resharing import of privKey into a new committee of newPIDs expecting walletPub, and
+// returns their save data or the first error of the new committee
+func Import(t *testing.T, privKey, format, pubKey, walletPub string, newPIDs []string, threshold int) ([][]byte, string) {
+	manifest := Manifest(t, resharing.ImportTaskName, []string{ImporterID}, newPIDs, threshold)
+	r := resharing.NewImporterParty("importer", ImporterID, newPIDs, threshold, privKey, format, pubKey, "")
+	require.True(t, r.Ok, r.Err)
+	t.Cleanup(func() { resharing.RemoveParty("importer") })
+	r = resharing.SetSessionManifest("importer", manifest, SignManifest(manifest), CoordinatorPub)
+	require.True(t, r.Ok, r.Err)
+	newKeys := make([]string, len(newPIDs))
+	for i := range newPIDs {
+		newKeys[i] = fmt.Sprintf("import-%d", i)
+		r := resharing.NewImportParty(newKeys[i], i, ImporterID, newPIDs, threshold, walletPub)
+		require.True(t, r.Ok, r.Err)
+		key := newKeys[i]
+		t.Cleanup(func() { resharing.RemoveParty(key) })
+		r = resharing.SetSessionManifest(newKeys[i], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+	}
+	setAuxInfo(t, newKeys)
+	deal(t, []string{"importer"}, newKeys)
+	out := make([][]byte, len(newKeys))
+	for i := range newKeys {
+		r := resharing.ResharingRound2Exec(newKeys[i])
+		if !r.Ok {
+			return nil, r.Err
+		}
+		out[i] = r.MsgWireBytes
+	}
+	for i := range newKeys {
+		for j := range newKeys {
+			if i != j {
+				r := resharing.ResharingRound2Accept(newKeys[i], j, B64(out[j]))
+				require.True(t, r.Ok, r.Err)
+			}
+		}
+		r := resharing.ResharingRound2Finish(newKeys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+	saves := make([][]byte, len(newKeys))
+	for i := range newKeys {
+		r := resharing.ResharingRound3Exec(newKeys[i])
+		if !r.Ok {
+			return nil, r.Err
+		}
+		saves[i] = r.MsgWireBytes
+	}
+	return saves, ""
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/crypto"
+	"tss_sdk/crypto/ckd"
+	ecdsakeygen "tss_sdk/ecdsacmp/keygen"
+	"tss_sdk/eddsacmp/keygen"
+
+	edwards "github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// NewKeygenParty is the constructor of a keygen party, eddsacmp/keygen.NewLocalParty or the one of another curve
+type NewKeygenParty func(key string, partyIndex int, partyCount int, threshold int, pIDs []string, rootPrivKey string) keygen.KeygenResult
+
+// Keygen runs an ed25519 keygen of n parties and returns their save data
+func Keygen(t *testing.T, n, threshold int) [][]byte {
+	return KeygenWith(t, n, threshold, keygen.TaskName, keygen.NewLocalParty)
+}
+
+// KeygenWith runs a keygen of n parties created by newParty for the task
+func KeygenWith(t *testing.T, n, threshold int, task string, newParty NewKeygenParty) [][]byte {
+	pIDs := PartyIDs(n)
+	keys := make([]string, n)
+	manifest := Manifest(t, task, pIDs, nil, threshold)
+	codes := make([]string, n)
+	for j := range codes {
+		codes[j] = fmt.Sprintf("%x", 0xabc0+j)
+	}
+	for i := range keys {
+		keys[i] = fmt.Sprintf("keygen-%d", i)
+		rootPrivKey, err := common.GetRandomBytes(rand.Reader, 32)
+		require.NoError(t, err)
+		r := newParty(keys[i], i, n, threshold, pIDs, hex.EncodeToString(rootPrivKey))
+		require.True(t, r.Ok, r.Err)
+		key := keys[i]
+		t.Cleanup(func() { keygen.RemoveParty(key) })
+		r = keygen.SetSessionManifest(keys[i], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+		r = keygen.SaveChainCodes(keys[i], strings.Join(codes, "|"))
+		require.True(t, r.Ok, r.Err)
+	}
+	round := func(exec func(string) keygen.KeygenExecResult, accept func(string, int, string) keygen.KeygenResult, finish func(string) keygen.KeygenResult) {
+		out := make([][]byte, n)
+		for i := range keys {
+			r := exec(keys[i])
+			require.True(t, r.Ok, r.Err)
+			out[i] = r.MsgWireBytes
+		}
+		for i := range keys {
+			for j := range keys {
+				if i != j {
+					r := accept(keys[i], j, B64(out[j]))
+					require.True(t, r.Ok, r.Err)
+				}
+			}
+			r := finish(keys[i])
+			require.True(t, r.Ok, r.Err)
+		}
+	}
+	round(keygen.KeygenRound1Exec, keygen.KeygenRound1Accept, keygen.KeygenRound1Finish)
+	// threshold keygens follow the round 2 broadcast with the p2p share dealt to each party
+	round(keygen.KeygenRound2Exec, func(key string, from int, msg string) keygen.KeygenResult {
+		r := keygen.KeygenRound2Accept(key, from, msg)
+		if !r.Ok || threshold == n {
+			return r
+		}
+		to := indexOf(keys, key)
+		return keygen.KeygenRound2Accept(key, from, B64(keygen.GetRound2Msg2(keys[from], to).MsgWireBytes))
+	}, keygen.KeygenRound2Finish)
+	round(keygen.KeygenRound3Exec, keygen.KeygenRound3Accept, keygen.KeygenRound3Finish)
+	saves := make([][]byte, n)
+	for i := range keys {
+		r := keygen.KeygenRound4Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		saves[i] = r.MsgWireBytes
+	}
+	return saves
+}
+
+// KeygenFixtures returns the save data of an ed25519 keygen of n parties from _fixtures, running and saving the
+// keygen the first time: the Paillier safe primes make a keygen take minutes
+func KeygenFixtures(t *testing.T, n, threshold int) [][]byte {
+	return keygenFixtures(t, "ed25519", n, threshold, keygen.TaskName, keygen.NewLocalParty)
+}
+
+// Secp256k1KeygenFixtures is KeygenFixtures for a secp256k1 keygen, see ecdsacmp/keygen
+func Secp256k1KeygenFixtures(t *testing.T, n, threshold int) [][]byte {
+	return keygenFixtures(t, "secp256k1", n, threshold, ecdsakeygen.TaskName, ecdsakeygen.NewLocalParty)
+}
+
+func keygenFixtures(t *testing.T, curve string, n, threshold int, task string, newParty NewKeygenParty) [][]byte {
+	file := filepath.Join(fixturesDir(), fmt.Sprintf("keygen_%s_%d_%d.json", curve, n, threshold))
+	var saves [][]byte
+	if bz, err := os.ReadFile(file); err == nil {
+		require.NoError(t, json.Unmarshal(bz, &saves))
+		return saves
+	}
+	saves = KeygenWith(t, n, threshold, task, newParty)
+	bz, err := json.Marshal(saves)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, bz, 0600))
+	return saves
+}
+
+// SingleChainCode converts the save data of every keygen party with keygen.SingleChainCodeKey
+func SingleChainCode(t *testing.T, saves [][]byte) [][]byte {
+	out := make([][]byte, len(saves))
+	for i, save := range saves {
+		r := keygen.SingleChainCodeKey(B64(save))
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	return out
+}
+
+// Bip32Ed25519 switches the single chain code save data of every keygen party to the BIP32-Ed25519 scheme
+func Bip32Ed25519(t *testing.T, saves [][]byte) [][]byte {
+	out := make([][]byte, len(saves))
+	for i, save := range saves {
+		r := keygen.SetDerivationScheme(B64(save), ckd.SchemeBip32Ed25519)
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	return out
+}
+
+// SaveData unmarshals keygen, refresh or resharing output
+func SaveData(t *testing.T, save []byte) *keygen.LocalPartySaveData {
+	data := &keygen.LocalPartySaveData{}
+	require.NoError(t, json.Unmarshal(save, data))
+	return data
+}
+
+// ChildPub is the ed25519 public key the save data derives at path
+func ChildPub(t *testing.T, save []byte, path string) ed25519.PublicKey {
+	data := SaveData(t, save)
+	tweak, err := data.ChildTweak(path)
+	require.NoError(t, err)
+	pub, err := data.PubKey().Add(crypto.ScalarBaseMult(data.PubKey().Curve(), tweak))
+	require.NoError(t, err)
+	return edwards.NewPublicKey(pub.X(), pub.Y()).Serialize()
+}
+
+func indexOf(keys []string, key string) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
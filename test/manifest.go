@@ -0,0 +1,41 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"tss_sdk/tss"
+
+	"github.com/stretchr/testify/require"
+)
+
+var coordinatorPub, coordinatorKey, _ = ed25519.GenerateKey(rand.Reader)
+
+// CoordinatorPub is the hex public key of the test coordinator
+var CoordinatorPub = hex.EncodeToString(coordinatorPub)
+
+// Manifest returns a fresh base64 session manifest
+func Manifest(t *testing.T, protocol string, parties, newParties []string, threshold int) string {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	require.NoError(t, err)
+	bz, err := json.Marshal(&tss.SessionManifest{
+		Protocol:   protocol,
+		Parties:    parties,
+		NewParties: newParties,
+		Threshold:  threshold,
+		Nonce:      hex.EncodeToString(nonce),
+	})
+	require.NoError(t, err)
+	return B64(bz)
+}
+
+// SignManifest is the hex coordinator signature of the base64 manifest
+func SignManifest(manifest string) string {
+	bz, _ := base64.StdEncoding.DecodeString(manifest)
+	return hex.EncodeToString(ed25519.Sign(coordinatorKey, bz))
+}
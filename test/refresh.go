@@ -0,0 +1,61 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"tss_sdk/eddsacmp/refresh"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Refresh runs an eddsacmp/refresh session of all the keygen parties and returns their refreshed save data
+func Refresh(t *testing.T, saves [][]byte) [][]byte {
+	n := len(saves)
+	pIDs := PartyIDs(n)
+	keys := make([]string, n)
+	manifest := Manifest(t, refresh.TaskName, pIDs, nil, SaveData(t, saves[0]).SignThreshold())
+	for i := range keys {
+		keys[i] = fmt.Sprintf("refresh-%d", i)
+		r := refresh.NewLocalParty(keys[i], i, n, pIDs, B64(saves[i]))
+		require.True(t, r.Ok, r.Err)
+		key := keys[i]
+		t.Cleanup(func() { refresh.RemoveParty(key) })
+		r = refresh.SetSessionManifest(keys[i], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+	}
+	round := func(exec func(string) refresh.RefreshExecResult, accept func(string, int, string) refresh.RefreshResult, finish func(string) refresh.RefreshResult, p2p bool) {
+		out := make([][]byte, n)
+		for i := range keys {
+			r := exec(keys[i])
+			require.True(t, r.Ok, r.Err)
+			out[i] = r.MsgWireBytes
+		}
+		for i := range keys {
+			for j := range keys {
+				if i == j {
+					continue
+				}
+				r := accept(keys[i], j, B64(out[j]))
+				require.True(t, r.Ok, r.Err)
+				// round 2 follows the broadcast with the p2p share dealt to each party
+				if p2p {
+					r = accept(keys[i], j, B64(refresh.GetRound2Msg2(keys[j], i).MsgWireBytes))
+					require.True(t, r.Ok, r.Err)
+				}
+			}
+			r := finish(keys[i])
+			require.True(t, r.Ok, r.Err)
+		}
+	}
+	round(refresh.RefreshRound1Exec, refresh.RefreshRound1Accept, refresh.RefreshRound1Finish, false)
+	round(refresh.RefreshRound2Exec, refresh.RefreshRound2Accept, refresh.RefreshRound2Finish, true)
+	round(refresh.RefreshRound3Exec, refresh.RefreshRound3Accept, refresh.RefreshRound3Finish, false)
+	out := make([][]byte, n)
+	for i := range keys {
+		r := refresh.RefreshRound4Exec(keys[i])
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	return out
+}
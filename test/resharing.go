@@ -0,0 +1,156 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"tss_sdk/eddsacmp/keygen"
+	"tss_sdk/eddsacmp/resharing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Reshare runs an eddsacmp/resharing session from the old parties, indexes of saves, to a new committee of
+// newPIDs and returns the save data of the new committee
+func Reshare(t *testing.T, saves [][]byte, olds []int, newPIDs []string, threshold int) [][]byte {
+	groupKey := keygen.WatchOnlyKey(B64(saves[olds[0]]))
+	require.True(t, groupKey.Ok, groupKey.Err)
+	results := ReshareExpecting(t, saves, olds, newPIDs, threshold, string(groupKey.MsgWireBytes))
+	newSaves := make([][]byte, len(results))
+	for i, r := range results {
+		require.True(t, r.Ok, r.Err)
+		newSaves[i] = r.MsgWireBytes
+	}
+	return newSaves
+}
+
+// ReshareExpecting is Reshare with the new committee expecting groupKey, and returns the round 3 results
+func ReshareExpecting(t *testing.T, saves [][]byte, olds []int, newPIDs []string, threshold int, groupKey string) []resharing.ResharingExecResult {
+	oldKeys, newKeys := ResharingParties(t, saves, olds, newPIDs, threshold, groupKey)
+	deal(t, oldKeys, newKeys)
+	return auxInfo(t, newKeys)
+}
+
+// ResharingParties creates the parties of a resharing session and returns the keys of the old and new committees
+func ResharingParties(t *testing.T, saves [][]byte, olds []int, newPIDs []string, threshold int, groupKey string) ([]string, []string) {
+	oldPIDs := make([]string, len(olds))
+	for i, s := range olds {
+		oldPIDs[i] = SaveData(t, saves[s]).ShareID.String()
+	}
+	manifest := Manifest(t, resharing.TaskName, oldPIDs, newPIDs, threshold)
+	oldKeys := make([]string, len(olds))
+	for i, s := range olds {
+		oldKeys[i] = fmt.Sprintf("resharing-old-%d", i)
+		r := resharing.NewOldCommitteeParty(oldKeys[i], i, oldPIDs, newPIDs, threshold, B64(saves[s]))
+		require.True(t, r.Ok, r.Err)
+		key := oldKeys[i]
+		t.Cleanup(func() { resharing.RemoveParty(key) })
+		r = resharing.SetSessionManifest(oldKeys[i], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+	}
+	newKeys := make([]string, len(newPIDs))
+	for i := range newPIDs {
+		newKeys[i] = fmt.Sprintf("resharing-new-%d", i)
+		r := resharing.NewNewCommitteeParty(newKeys[i], i, oldPIDs, newPIDs, threshold, groupKey)
+		require.True(t, r.Ok, r.Err)
+		key := newKeys[i]
+		t.Cleanup(func() { resharing.RemoveParty(key) })
+		r = resharing.SetSessionManifest(newKeys[i], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+	}
+	setAuxInfo(t, newKeys)
+	return oldKeys, newKeys
+}
+
+// AuxInfoFixtures returns n outputs of resharing.GenerateAuxInfo from _fixtures, generating and saving the
+// missing ones: a Paillier key takes about half a minute, and every new party of a resharing needs one
+func AuxInfoFixtures(t *testing.T, n int) [][]byte {
+	file := filepath.Join(fixturesDir(), "aux_info.json")
+	var auxInfos [][]byte
+	if bz, err := os.ReadFile(file); err == nil {
+		require.NoError(t, json.Unmarshal(bz, &auxInfos))
+	}
+	if len(auxInfos) >= n {
+		return auxInfos[:n]
+	}
+	for len(auxInfos) < n {
+		r := resharing.GenerateAuxInfo()
+		require.True(t, r.Ok, r.Err)
+		auxInfos = append(auxInfos, r.MsgWireBytes)
+	}
+	bz, err := json.Marshal(auxInfos)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, bz, 0600))
+	return auxInfos
+}
+
+// setAuxInfo gives the new parties the Paillier keys of AuxInfoFixtures
+func setAuxInfo(t *testing.T, newKeys []string) {
+	for i, auxInfo := range AuxInfoFixtures(t, len(newKeys)) {
+		r := resharing.SetAuxInfo(newKeys[i], B64(auxInfo))
+		require.True(t, r.Ok, r.Err)
+	}
+}
+
+// deal runs round 1: the new parties send their enc keys and the old parties deal their shares to them
+func deal(t *testing.T, oldKeys, newKeys []string) {
+	SendEncKeys(t, oldKeys, newKeys)
+	for j := range oldKeys {
+		r := resharing.ResharingRound1Exec(oldKeys[j])
+		require.True(t, r.Ok, r.Err)
+		for i := range newKeys {
+			a := resharing.ResharingRound1Accept(newKeys[i], j, B64(r.MsgWireBytes))
+			require.True(t, a.Ok, a.Err)
+			a = resharing.ResharingRound1Accept(newKeys[i], j, B64(resharing.GetRound1Msg2(oldKeys[j], i).MsgWireBytes))
+			require.True(t, a.Ok, a.Err)
+		}
+	}
+	for i := range newKeys {
+		r := resharing.ResharingRound1Finish(newKeys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+}
+
+// SendEncKeys runs the enc key step: the new parties send the keys the dealt shares are sealed to
+func SendEncKeys(t *testing.T, oldKeys, newKeys []string) {
+	for i := range newKeys {
+		r := resharing.ResharingEncKeyExec(newKeys[i])
+		require.True(t, r.Ok, r.Err)
+		for j := range oldKeys {
+			a := resharing.ResharingEncKeyAccept(oldKeys[j], i, B64(r.MsgWireBytes))
+			require.True(t, a.Ok, a.Err)
+		}
+	}
+	for j := range oldKeys {
+		r := resharing.ResharingEncKeyFinish(oldKeys[j])
+		require.True(t, r.Ok, r.Err)
+	}
+}
+
+// auxInfo runs the aux info rounds of the new committee and returns the round 3 results
+func auxInfo(t *testing.T, newKeys []string) []resharing.ResharingExecResult {
+	out := make([][]byte, len(newKeys))
+	for i := range newKeys {
+		r := resharing.ResharingRound2Exec(newKeys[i])
+		require.True(t, r.Ok, r.Err)
+		out[i] = r.MsgWireBytes
+	}
+	for i := range newKeys {
+		for j := range newKeys {
+			if i != j {
+				r := resharing.ResharingRound2Accept(newKeys[i], j, B64(out[j]))
+				require.True(t, r.Ok, r.Err)
+			}
+		}
+		r := resharing.ResharingRound2Finish(newKeys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+	results := make([]resharing.ResharingExecResult, len(newKeys))
+	for i := range newKeys {
+		results[i] = resharing.ResharingRound3Exec(newKeys[i])
+	}
+	return results
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"tss_sdk/common"
+	"tss_sdk/eddsacmp/onsign"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Sign runs an eddsacmp/onsign session of the signers, indexes of saves, and returns the signature json
+func Sign(t *testing.T, saves [][]byte, signers []int, threshold int, msg, path, refresh string) []byte {
+	return SignWithMode(t, saves, signers, threshold, msg, path, refresh, "", "")
+}
+
+// SignWithMode is Sign under the ed25519 signing mode, see onsign.SetSignMode
+func SignWithMode(t *testing.T, saves [][]byte, signers []int, threshold int, msg, path, refresh, mode, context string) []byte {
+	m := len(signers)
+	pIDs := make([]string, m)
+	keys := make([]string, m)
+	for k, s := range signers {
+		pIDs[k] = SaveData(t, saves[s]).ShareID.String()
+	}
+	manifest := Manifest(t, onsign.TaskName, pIDs, nil, threshold)
+	for k, s := range signers {
+		keys[k] = fmt.Sprintf("sign-%d", k)
+		r := onsign.NewLocalParty(keys[k], k, m, threshold, pIDs, msg, B64(saves[s]), refresh, path)
+		require.True(t, r.Ok, r.Err)
+		key := keys[k]
+		t.Cleanup(func() { onsign.RemoveSignParty(key) })
+		r = onsign.SetSessionManifest(keys[k], manifest, SignManifest(manifest), CoordinatorPub)
+		require.True(t, r.Ok, r.Err)
+		if mode != "" {
+			r = onsign.SetSignMode(keys[k], mode, context)
+			require.True(t, r.Ok, r.Err)
+		}
+	}
+	accept := func(i, j int, msg []byte, accept func(string, int, string) onsign.OnsignResult) {
+		r := accept(keys[i], j, B64(msg))
+		require.True(t, r.Ok, r.Err)
+	}
+	step := func(i int, step func(string) onsign.OnsignResult) {
+		r := step(keys[i])
+		require.True(t, r.Ok, r.Err)
+	}
+	exec := func(exec func(string) onsign.OnsignExecResult) [][]byte {
+		out := make([][]byte, m)
+		for i := range keys {
+			r := exec(keys[i])
+			require.True(t, r.Ok, r.Err)
+			out[i] = r.MsgWireBytes
+		}
+		return out
+	}
+
+	r1 := exec(onsign.OnSignRound1Exec)
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				accept(i, j, r1[j], onsign.OnSignRound1MsgAccept)
+				accept(i, j, onsign.GetRound1Msg2(keys[j], i).MsgWireBytes, onsign.OnSignRound1MsgAccept)
+			}
+		}
+		step(i, onsign.OnSignRound1Finish)
+	}
+	for i := range keys {
+		step(i, onsign.OnsignRound2Exec)
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				accept(i, j, onsign.GetRound2Msg(keys[j], i).MsgWireBytes, onsign.OnSignRound2MsgAccept)
+			}
+		}
+		step(i, onsign.OnSignRound2Finish)
+	}
+	r3 := exec(onsign.OnsignRound3Exec)
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				accept(i, j, r3[j], onsign.OnSignRound3MsgAccept)
+			}
+		}
+		step(i, onsign.OnSignRound3Finish)
+	}
+	sigs := exec(onsign.OnsignFinalExec)
+	return sigs[0]
+}
+
+// CheckSig verifies the signature json of Sign under pub
+func CheckSig(t *testing.T, pub ed25519.PublicKey, sigJson []byte) {
+	data := &common.SignatureData{}
+	require.NoError(t, json.Unmarshal(sigJson, data))
+	require.True(t, ed25519.Verify(pub, data.M, data.Signature))
+}
@@ -0,0 +1,271 @@
+package tss
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"tss_sdk/common"
+)
+
+// Echo broadcast: after a broadcast round every party sends the hash of all the broadcast messages it
+// received in that round. The round is accepted only if every echo equals our own, which detects a sender
+// or a relay handing different messages to different parties when the transport is not a reliable broadcast.
+
+// EchoHash returns the echo of a broadcast round, msgs are the wire bytes of every broadcast message of the
+// round indexed by sender, our own included
+func EchoHash(ssid []byte, round int, msgs [][]byte) ([]byte, error) {
+	in := [][]byte{ssid, []byte(strconv.Itoa(round))}
+	for j, msg := range msgs {
+		if len(msg) == 0 {
+			return nil, fmt.Errorf("msg is null: %d", j)
+		}
+		in = append(in, msg)
+	}
+	return common.SHA512_256(in...), nil
+}
+
+func NewEchoMessage(
+	from *PartyID,
+	ssid []byte,
+	round int,
+	hash []byte,
+) ParsedMessage {
+	meta := MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &EchoMessage{
+		Ssid:  ssid,
+		Round: int32(round),
+		Hash:  hash,
+	}
+	msg := NewMessageWrapper(meta, content)
+	return NewMessage(meta, content, msg)
+}
+
+func (m *EchoMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSsid()) &&
+		m.GetRound() > 0 &&
+		common.NonEmptyBytes(m.GetHash())
+}
+
+// VerifyEchoes checks the echo messages of the other parties against our own echo hash,
+// it returns the indexes of the parties whose echo is malformed or differs
+func VerifyEchoes(ssid []byte, round int, hash []byte, self int, echoes [][]byte) []int {
+	culprits := make([]int, 0)
+	for j, echo := range echoes {
+		if j == self {
+			continue
+		}
+		msg, err := ParseWireMsg(echo)
+		if err != nil {
+			culprits = append(culprits, j)
+			continue
+		}
+		content, ok := msg.Content().(*EchoMessage)
+		if !ok || !content.ValidateBasic() ||
+			!bytes.Equal(content.GetSsid(), ssid) ||
+			int(content.GetRound()) != round ||
+			!bytes.Equal(content.GetHash(), hash) {
+			culprits = append(culprits, j)
+		}
+	}
+	return culprits
+}
+
+// Echo collects the echoes of the broadcast rounds of a party and checks them. The protocol passes in its
+// own broadcast messages of a round, by sender, and an error for a round that is not broadcast.
+type Echo struct {
+	task     string
+	self     *PartyID
+	parties  SortedPartyIDs
+	messages func(round int) ([][]byte, error)
+	echoes   map[int][][]byte // echoes of broadcast round n, by sender
+	last     int              // the last round we echoed
+}
+
+func NewEcho(task string, self *PartyID, parties SortedPartyIDs, messages func(round int) ([][]byte, error)) *Echo {
+	return &Echo{
+		task:     task,
+		self:     self,
+		parties:  parties,
+		messages: messages,
+		echoes:   make(map[int][][]byte),
+	}
+}
+
+func (e *Echo) round(round int) [][]byte {
+	if _, ok := e.echoes[round]; !ok {
+		e.echoes[round] = make([][]byte, len(e.parties))
+	}
+	return e.echoes[round]
+}
+
+// Exec returns the wire bytes of our echo, the hash of every message we received in the broadcast round
+func (e *Echo) Exec(ssid []byte, round int) ([]byte, error) {
+	msgs, err := e.messages(round)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := EchoHash(ssid, round, msgs)
+	if err != nil {
+		return nil, fmt.Errorf("echo hash err: %s", err.Error())
+	}
+
+	msg := NewEchoMessage(e.self, ssid, round, hash)
+	msgWireBytes, _, err := msg.WireBytes()
+	if err != nil {
+		return nil, fmt.Errorf("get msg wire bytes error: %s", err.Error())
+	}
+	e.round(round)[e.self.Index] = msgWireBytes
+	e.last = round
+	return msgWireBytes, nil
+}
+
+// Accept keeps the echo of party from, an echo that is malformed or of a round that is not broadcast is
+// blamed on from
+func (e *Echo) Accept(from int, msgWireBytes []byte) *Error {
+	if from < 0 || from >= len(e.parties) {
+		return NewError(fmt.Errorf("party index err: %d", from), e.task, e.last, e.self)
+	}
+
+	msg, err := ParseWireMsg(msgWireBytes)
+	if err != nil {
+		return e.blame(e.last, from, fmt.Errorf("msg error, parse wire msg fail, err:%s", err.Error()))
+	}
+	echo, ok := msg.Content().(*EchoMessage)
+	if !ok || !echo.ValidateBasic() {
+		return e.blame(e.last, from, errors.New("not EchoMessage"))
+	}
+	round := int(echo.GetRound())
+	if _, err := e.messages(round); err != nil {
+		return e.blame(round, from, err)
+	}
+	e.round(round)[from] = msgWireBytes
+	return nil
+}
+
+// Finish checks every party echoed the same broadcast messages of the round as we received, the parties
+// whose echo differs are the culprits
+func (e *Echo) Finish(ssid []byte, round int) *Error {
+	msgs, err := e.messages(round)
+	if err != nil {
+		return NewError(err, e.task, round, e.self)
+	}
+
+	echoes := e.round(round)
+	for j, msg := range echoes {
+		if len(msg) == 0 {
+			return NewError(fmt.Errorf("echo is null: %d", j), e.task, round, e.self)
+		}
+	}
+
+	hash, err := EchoHash(ssid, round, msgs)
+	if err != nil {
+		return NewError(fmt.Errorf("echo hash err: %s", err.Error()), e.task, round, e.self)
+	}
+	if culprits := VerifyEchoes(ssid, round, hash, e.self.Index, echoes); len(culprits) > 0 {
+		culpritIDs := make([]*PartyID, len(culprits))
+		for k, j := range culprits {
+			culpritIDs[k] = e.parties[j]
+		}
+		err := fmt.Errorf("broadcast echo mismatch, round: %d, parties: %v", round, culprits)
+		return NewAbortError(err, ReasonEchoMismatch, e.task, round, e.self, culpritIDs...)
+	}
+	return nil
+}
+
+func (e *Echo) blame(round, j int, err error) *Error {
+	return NewAbortError(err, ReasonBadMessage, e.task, round, e.self, e.parties[j])
+}
+
+// EchoExecResult and EchoResult have the fields of the round results of every protocol, which convert them
+// to their own types
+type EchoExecResult struct {
+	Ok           bool   `json:"ok"`
+	Err          string `json:"error"`
+	MsgWireBytes []byte `json:"data"`
+	Culprits     string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason       string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+type EchoResult struct {
+	Ok       bool   `json:"ok"`
+	Err      string `json:"error"`
+	Culprits string `json:"culprits,omitempty"` // indexes of the parties to blame, comma separated
+	Reason   string `json:"reason,omitempty"`   // tss.Reason* code of an identifiable abort
+}
+
+func (result *EchoResult) abort(err *Error) {
+	common.Logger.Errorf(err.Error())
+	result.Err, result.Culprits, result.Reason = err.Error(), err.CulpritList(), err.Reason()
+}
+
+// EchoLookup returns the echo state and the ssid of the party of key, false if there is no such party
+type EchoLookup func(key string) (*Echo, []byte, bool)
+
+// EchoExec broadcasts the hash of every message the party of key received in the broadcast round
+func EchoExec(lookup EchoLookup, key string, round int) (result EchoExecResult) {
+	echo, ssid, ok := lookup(key)
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	msgWireBytes, err := echo.Exec(ssid, round)
+	if err != nil {
+		common.Logger.Errorf("echo exec err: %s", err.Error())
+		result.Err = fmt.Sprintf("echo exec err: %s", err.Error())
+		return
+	}
+
+	result.Ok = true
+	result.MsgWireBytes = msgWireBytes
+	return result
+}
+
+func EchoAccept(lookup EchoLookup, key string, from int, msgWireBytes string) (result EchoResult) {
+	echo, _, ok := lookup(key)
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	rMsgBytes, err := base64.StdEncoding.DecodeString(msgWireBytes)
+	if err != nil {
+		common.Logger.Errorf("msg error, msg base64 decode fail, err:%s", err.Error())
+		result.Err = fmt.Sprintf("msg error, msg base64 decode fail, err:%s", err.Error())
+		return
+	}
+	if err := echo.Accept(from, rMsgBytes); err != nil {
+		result.abort(err)
+		return
+	}
+
+	result.Ok = true
+	return
+}
+
+// EchoFinish checks every party echoed the same broadcast messages as the party of key received
+func EchoFinish(lookup EchoLookup, key string, round int) (result EchoResult) {
+	echo, ssid, ok := lookup(key)
+	if !ok {
+		common.Logger.Errorf("party not found: %s", key)
+		result.Err = fmt.Sprintf("party not found: %s", key)
+		return
+	}
+
+	if err := echo.Finish(ssid, round); err != nil {
+		result.abort(err)
+		return
+	}
+
+	result.Ok = true
+	return
+}
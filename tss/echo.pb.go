@@ -0,0 +1,162 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.21.4
+// source: protob/echo.proto
+
+package tss
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties after a broadcast round, the hash of every broadcast message received in that round.
+type EchoMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssid  []byte `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Round int32  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Hash  []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *EchoMessage) Reset() {
+	*x = EchoMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_echo_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EchoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EchoMessage) ProtoMessage() {}
+
+func (x *EchoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_echo_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EchoMessage.ProtoReflect.Descriptor instead.
+func (*EchoMessage) Descriptor() ([]byte, []int) {
+	return file_protob_echo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EchoMessage) GetSsid() []byte {
+	if x != nil {
+		return x.Ssid
+	}
+	return nil
+}
+
+func (x *EchoMessage) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *EchoMessage) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+var File_protob_echo_proto protoreflect.FileDescriptor
+
+var file_protob_echo_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x68, 0x6f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6c, 0x65, 0x67, 0x65, 0x6e, 0x64, 0x2e, 0x74, 0x73, 0x73, 0x6c,
+	0x69, 0x62, 0x2e, 0x74, 0x73, 0x73, 0x22, 0x4b, 0x0a, 0x0b, 0x45, 0x63, 0x68, 0x6f, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x73, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x2f, 0x74, 0x73, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_echo_proto_rawDescOnce sync.Once
+	file_protob_echo_proto_rawDescData = file_protob_echo_proto_rawDesc
+)
+
+func file_protob_echo_proto_rawDescGZIP() []byte {
+	file_protob_echo_proto_rawDescOnce.Do(func() {
+		file_protob_echo_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_echo_proto_rawDescData)
+	})
+	return file_protob_echo_proto_rawDescData
+}
+
+var file_protob_echo_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_echo_proto_goTypes = []interface{}{
+	(*EchoMessage)(nil), // 0: legend.tsslib.tss.EchoMessage
+}
+var file_protob_echo_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_echo_proto_init() }
+func file_protob_echo_proto_init() {
+	if File_protob_echo_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_echo_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EchoMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_echo_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_echo_proto_goTypes,
+		DependencyIndexes: file_protob_echo_proto_depIdxs,
+		MessageInfos:      file_protob_echo_proto_msgTypes,
+	}.Build()
+	File_protob_echo_proto = out.File
+	file_protob_echo_proto_rawDesc = nil
+	file_protob_echo_proto_goTypes = nil
+	file_protob_echo_proto_depIdxs = nil
+}
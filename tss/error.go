@@ -8,6 +8,18 @@ package tss
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reason codes of an identifiable abort, the misbehaviour the culprits are blamed for
+const (
+	ReasonBadMessage      = "bad_message"      // malformed message or message of the wrong type
+	ReasonSessionMismatch = "session_mismatch" // message of another session
+	ReasonBadCommitment   = "bad_commitment"   // decommitment does not open the commitment
+	ReasonBadShare        = "bad_share"        // secret share does not match the vss commitments
+	ReasonBadProof        = "bad_proof"        // zero knowledge proof does not verify
+	ReasonEchoMismatch    = "echo_mismatch"    // echo differs, either the culprit or a broadcast sender equivocated
 )
 
 // fundamental is an error that has a message and a stack, but no caller.
@@ -17,12 +29,18 @@ type Error struct {
 	round    int
 	victim   *PartyID
 	culprits []*PartyID
+	reason   string
 }
 
 func NewError(err error, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
 	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits}
 }
 
+// NewAbortError returns an error blaming the culprits for the misbehaviour given by the reason code
+func NewAbortError(err error, reason string, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
+	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits, reason: reason}
+}
+
 func (err *Error) Unwrap() error { return err.cause }
 
 func (err *Error) Cause() error { return err.cause }
@@ -35,6 +53,26 @@ func (err *Error) Victim() *PartyID { return err.victim }
 
 func (err *Error) Culprits() []*PartyID { return err.culprits }
 
+func (err *Error) Reason() string { return err.reason }
+
+// CulpritIndexes returns the indexes of the culprits in the sorted party ids
+func (err *Error) CulpritIndexes() []int {
+	indexes := make([]int, len(err.culprits))
+	for k, culprit := range err.culprits {
+		indexes[k] = culprit.Index
+	}
+	return indexes
+}
+
+// CulpritList returns the indexes of the culprits comma separated, as the results of the round functions carry them
+func (err *Error) CulpritList() string {
+	strs := make([]string, len(err.culprits))
+	for k, culprit := range err.culprits {
+		strs[k] = strconv.Itoa(culprit.Index)
+	}
+	return strings.Join(strs, ",")
+}
+
 func (err *Error) Error() string {
 	if err == nil || err.cause == nil {
 		return "Error is nil"
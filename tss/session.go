@@ -0,0 +1,113 @@
+package tss
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"tss_sdk/common"
+)
+
+// MinSessionNonceLen is the minimum length in bytes of the session nonce
+const MinSessionNonceLen = 16
+
+// SessionManifest describes a single protocol session. The app gets it from the session coordinator with
+// the coordinator's signature over it, and gives the same manifest to every party of the session; every
+// party checks the signature against the coordinator key it trusts before the manifest is used.
+type SessionManifest struct {
+	Protocol   string            `json:"protocol"`              // task name, e.g. "eddsa-cmp-keygen"
+	Parties    []string          `json:"parties"`               // ids of the parties, the old committee for resharing
+	NewParties []string          `json:"new_parties,omitempty"` // ids of the new committee, resharing only
+	Threshold  int               `json:"threshold"`             // key threshold, the new threshold for resharing
+	Nonce      string            `json:"nonce"`                 // hex string, fresh for every session
+	Params     map[string]string `json:"params,omitempty"`      // other session parameters, bound into the ssid
+}
+
+// ParseSessionManifest decodes a base64 JSON manifest once its signature is verified: signature is the
+// Ed25519 signature of the coordinator over the decoded JSON bytes, coordinatorPub the Ed25519 public key
+// of the coordinator, both hex strings
+func ParseSessionManifest(manifest, signature, coordinatorPub string) (*SessionManifest, error) {
+	bz, err := base64.StdEncoding.DecodeString(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode session manifest err: %s", err.Error())
+	}
+	pub, err := hex.DecodeString(coordinatorPub)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, errors.New("coordinator pubkey err")
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil || !ed25519.Verify(pub, bz, sig) {
+		return nil, errors.New("session manifest signature verify failed")
+	}
+	sm := &SessionManifest{}
+	if err := json.Unmarshal(bz, sm); err != nil {
+		return nil, fmt.Errorf("unmarshal session manifest err: %s", err.Error())
+	}
+	nonce, err := hex.DecodeString(sm.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("hex decode session nonce err: %s", err.Error())
+	}
+	if len(nonce) < MinSessionNonceLen {
+		return nil, fmt.Errorf("session nonce too short: %d", len(nonce))
+	}
+	return sm, nil
+}
+
+// Check verifies the manifest describes the session the local party was created for
+func (sm *SessionManifest) Check(protocol string, parties, newParties []*big.Int, threshold int) error {
+	if sm.Protocol != protocol {
+		return fmt.Errorf("session protocol mismatch: %s, expected %s", sm.Protocol, protocol)
+	}
+	if !sameIDs(sm.Parties, parties) {
+		return errors.New("session parties mismatch")
+	}
+	if !sameIDs(sm.NewParties, newParties) {
+		return errors.New("session new parties mismatch")
+	}
+	if sm.Threshold != threshold {
+		return fmt.Errorf("session threshold mismatch: %d, expected %d", sm.Threshold, threshold)
+	}
+	return nil
+}
+
+// SSID returns the session identifier: the hash of the curve, the sorted party keys, the given
+// session data such as public key shares and ring-Pedersen parameters, and the manifest
+func (sm *SessionManifest) SSID(ec elliptic.Curve, keys []*big.Int, in ...*big.Int) []byte {
+	ssidList := []*big.Int{ec.Params().P, ec.Params().N, ec.Params().Gx, ec.Params().Gy} // ec curve
+	ssidList = append(ssidList, keys...)                                                 // parties
+	ssidList = append(ssidList, in...)
+	ssidList = append(ssidList, new(big.Int).SetBytes(sm.hash())) // manifest, with the nonce
+	return common.SHA512_256i(ssidList...).Bytes()
+}
+
+// hash of the manifest re-encoded, so any encoding of the same manifest gives the same ssid
+func (sm *SessionManifest) hash() []byte {
+	bz, _ := json.Marshal(sm)
+	return common.SHA512_256(bz)
+}
+
+func sameIDs(ids []string, keys []*big.Int) bool {
+	if len(ids) != len(keys) {
+		return false
+	}
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k.String()] = struct{}{}
+	}
+	for _, id := range ids {
+		k, ok := new(big.Int).SetString(id, 10)
+		if !ok {
+			return false
+		}
+		if _, ok := set[k.String()]; !ok {
+			return false
+		}
+		delete(set, k.String())
+	}
+	return true
+}